@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * カテゴリコントローラーのインターフェース
+ */
+type ICategoryController interface {
+	GetCategories(c echo.Context) error
+}
+
+/**
+ * カテゴリコントローラーの構造体
+ */
+type categoryController struct {
+	cu usecase.ICategoryUsecase
+}
+
+/**
+ * カテゴリコントローラーのコンストラクタ
+ * @param cu カテゴリユースケースのインターフェース
+ * @return カテゴリコントローラーのインターフェース
+ */
+func NewCategoryController(cu usecase.ICategoryUsecase) ICategoryController {
+	return &categoryController{cu}
+}
+
+/**
+ * カテゴリの木構造を取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (cc *categoryController) GetCategories(c echo.Context) error {
+	categories, err := cc.cu.GetCategoryTree()
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: categories,
+	})
+}
@@ -1,10 +1,17 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
+	"go-rest-api/errors"
 	"go-rest-api/model"
 	"go-rest-api/usecase"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
@@ -18,7 +25,28 @@ type IFoodItemController interface {
 	GetFoodItemById(c echo.Context) error
 	CreateFoodItem(c echo.Context) error
 	UpdateFoodItem(c echo.Context) error
+	PatchFoodItem(c echo.Context) error
 	DeleteFoodItem(c echo.Context) error
+	GetTrashedFoodItems(c echo.Context) error
+	RestoreFoodItem(c echo.Context) error
+	MoveFoodItem(c echo.Context) error
+	OpenFoodItem(c echo.Context) error
+	FreezeFoodItem(c echo.Context) error
+	ThawFoodItem(c echo.Context) error
+	CookFoodItem(c echo.Context) error
+	GetFoodStateTransitions(c echo.Context) error
+	GetFoodLots(c echo.Context) error
+	RestockFoodItem(c echo.Context) error
+	ConsumeFoodItem(c echo.Context) error
+	GetInventoryMovements(c echo.Context) error
+	DiscardFoodItem(c echo.Context) error
+	ImportFoodItems(c echo.Context) error
+	ParseReceipt(c echo.Context) error
+	CommitReceipt(c echo.Context) error
+	UpdateNutrition(c echo.Context) error
+	DeleteNutrition(c echo.Context) error
+	GetNutritionSummary(c echo.Context) error
+	ExportFoodItems(c echo.Context) error
 }
 
 /**
@@ -32,10 +60,20 @@ type foodItemController struct {
  * APIレスポンスの構造体
  */
 type Response struct {
-	Data    interface{} `json:"data"`
-	Message string      `json:"message,omitempty"`
+	Data       interface{} `json:"data"`
+	Message    string      `json:"message,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	// Errors は検証エラーの項目（JSON のキー）ごとのメッセージ
+	Errors map[string]string `json:"errors,omitempty"`
 }
 
+// 食材一覧の1ページあたりの件数の上限と、cursor だけを指定した場合の件数。
+// limit と cursor のどちらも指定しない場合は全件を返す
+const (
+	defaultFoodItemLimit = 100
+	maxFoodItemLimit     = 500
+)
+
 /**
  * 食材コントローラーのコンストラクタ
  * @param fu 食材ユースケースのインターフェース
@@ -46,19 +84,171 @@ func NewFoodItemController(fu usecase.IFoodItemUsecase) IFoodItemController {
 }
 
 /**
- * 全ての食材を取得
+ * JWTトークンから認証済みユーザーのIDを取得
+ * @param c コンテキスト
+ * @return ユーザーID
+ */
+func userIdFromToken(c echo.Context) uint {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*jwt.MapClaims)
+	return uint((*claims)["user_id"].(float64))
+}
+
+/**
+ * クエリパラメータから食材一覧の絞り込み条件を取得
+ * @param c コンテキスト
+ * @return 絞り込み条件, エラー
+ */
+func parseFoodItemFilter(c echo.Context) (model.FoodItemFilter, error) {
+	filter := model.FoodItemFilter{}
+	if location := c.QueryParam("location"); location != "" {
+		locationId, err := strconv.Atoi(location)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid location format")
+		}
+		id := uint(locationId)
+		filter.StorageLocationId = &id
+	}
+	if category := c.QueryParam("category"); category != "" {
+		categoryId, err := strconv.Atoi(category)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid category format")
+		}
+		id := uint(categoryId)
+		filter.CategoryId = &id
+	}
+	if tag := c.QueryParam("tag"); tag != "" {
+		tagId, err := strconv.Atoi(tag)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid tag format")
+		}
+		id := uint(tagId)
+		filter.TagId = &id
+	}
+	filter.Title = strings.TrimSpace(c.QueryParam("q"))
+	if before := c.QueryParam("expires_before"); before != "" {
+		t, err := parseDateTime(before)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid expires_before format")
+		}
+		filter.ExpiresBefore = &t
+	}
+	if after := c.QueryParam("expires_after"); after != "" {
+		t, err := parseDateTime(after)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid expires_after format")
+		}
+		filter.ExpiresAfter = &t
+	}
+	if expired := c.QueryParam("expired"); expired != "" {
+		b, err := strconv.ParseBool(expired)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid expired format")
+		}
+		filter.Expired = &b
+	}
+	if min := c.QueryParam("min_quantity"); min != "" {
+		v, err := strconv.ParseFloat(min, 64)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid min_quantity format")
+		}
+		filter.MinQuantity = &v
+	}
+	if max := c.QueryParam("max_quantity"); max != "" {
+		v, err := strconv.ParseFloat(max, 64)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid max_quantity format")
+		}
+		filter.MaxQuantity = &v
+	}
+	if sort := c.QueryParam("sort"); sort != "" {
+		filter.Sort.Field = model.FoodItemSortField(sort)
+		if !filter.Sort.Field.IsValid() {
+			return filter, fmt.Errorf("Invalid sort value")
+		}
+	}
+	switch c.QueryParam("order") {
+	case "", "asc":
+	case "desc":
+		filter.Sort.Desc = true
+	default:
+		return filter, fmt.Errorf("Invalid order value")
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		v, err := strconv.Atoi(limit)
+		if err != nil || v < 1 || v > maxFoodItemLimit {
+			return filter, fmt.Errorf("Invalid limit value")
+		}
+		filter.Limit = v
+	}
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		decoded, err := model.DecodeFoodItemCursor(cursor, filter.Sort)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid cursor")
+		}
+		filter.Cursor = &decoded
+		if filter.Limit == 0 {
+			filter.Limit = defaultFoodItemLimit
+		}
+	}
+	return filter, nil
+}
+
+/**
+ * 日付（YYYY-MM-DD）またはRFC3339形式の日時を解析
+ * @param s 文字列
+ * @return 日時, エラー
+ */
+func parseDateTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+/**
+ * 認証済みユーザーの全ての食材を取得
+ * group_by=category を指定するとカテゴリごとにまとめて返す
+ * 続きがある場合は next_cursor を cursor に指定して次のページを取得する
  * @param c コンテキスト
  * @return エラー
  */
 func (fc *foodItemController) GetAllFoodItems(c echo.Context) error {
-	foodItems, err := fc.fu.GetAllFoodItems()
+	filter, err := parseFoodItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: err.Error(),
+		})
+	}
+
+	switch c.QueryParam("group_by") {
+	case "":
+	case "category":
+		groups, nextCursor, err := fc.fu.GetFoodItemsByCategory(userIdFromToken(c), filter)
+		if err != nil {
+			return c.JSON(errors.GetHTTPStatus(err), Response{
+				Message: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusOK, Response{
+			Data:       groups,
+			NextCursor: nextCursor,
+		})
+	default:
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid group_by value",
+		})
+	}
+
+	foodItems, nextCursor, err := fc.fu.GetAllFoodItems(userIdFromToken(c), filter)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, Response{
+		return c.JSON(errors.GetHTTPStatus(err), Response{
 			Message: err.Error(),
 		})
 	}
 	return c.JSON(http.StatusOK, Response{
-		Data: foodItems,
+		Data:       foodItems,
+		NextCursor: nextCursor,
 	})
 }
 
@@ -76,12 +266,13 @@ func (fc *foodItemController) GetFoodItemById(c echo.Context) error {
 		})
 	}
 
-	foodItem, err := fc.fu.GetFoodItemById(uint(foodItemId))
+	foodItem, err := fc.fu.GetFoodItemById(userIdFromToken(c), uint(foodItemId))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, Response{
+		return c.JSON(errors.GetHTTPStatus(err), Response{
 			Message: err.Error(),
 		})
 	}
+	setETag(c, foodItem.Version)
 	return c.JSON(http.StatusOK, Response{
 		Data: foodItem,
 	})
@@ -101,17 +292,13 @@ func (fc *foodItemController) CreateFoodItem(c echo.Context) error {
 	}
 
 	// JWTトークンからユーザーIDを取得
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(*jwt.MapClaims)
-	userId := uint((*claims)["user_id"].(float64))
-	foodItem.UserId = userId
+	foodItem.UserId = userIdFromToken(c)
 
 	createdFoodItem, err := fc.fu.CreateFoodItem(foodItem)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, Response{
-			Message: err.Error(),
-		})
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
 	}
+	setETag(c, createdFoodItem.Version)
 	return c.JSON(http.StatusCreated, Response{
 		Data:    createdFoodItem,
 		Message: "Food item created successfully",
@@ -119,17 +306,34 @@ func (fc *foodItemController) CreateFoodItem(c echo.Context) error {
 }
 
 /**
- * 食材の更新
+ * 食材の更新（置き換え）
+ * title・category_id・tags をすべて指定する。所有者・作成日時などの読み取り専用の項目は
+ * 取得時の内容をそのまま送ってもよいが、変更されない
+ * 数量・単位・賞味期限はロットで管理するため、指定した場合は 400 を返す（補充・消費・廃棄で変更する）
+ * 取得時の ETag を If-Match ヘッダーに指定する。他の更新が先に行われていた場合は
+ * 412 と最新の食材を返す
  * @param c コンテキスト
  * @return エラー
  */
 func (fc *foodItemController) UpdateFoodItem(c echo.Context) error {
-	foodItem := model.FoodItem{}
-	if err := c.Bind(&foodItem); err != nil {
+	fields, patch, err := readFoodItemPatch(c)
+	if err != nil {
 		return c.JSON(http.StatusBadRequest, Response{
 			Message: "Invalid request format",
 		})
 	}
+	if message := stockFieldMessage(fields); message != "" {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: message,
+		})
+	}
+	for _, key := range model.FoodItemEditableFields {
+		if _, ok := fields[key]; !ok {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: fmt.Sprintf("Missing field: %s", key),
+			})
+		}
+	}
 
 	// IDの存在確認
 	id := c.Param("id")
@@ -139,21 +343,177 @@ func (fc *foodItemController) UpdateFoodItem(c echo.Context) error {
 			Message: "Invalid ID format",
 		})
 	}
-	foodItem.ID = uint(foodItemId)
 
-	if err := fc.fu.UpdateFoodItem(foodItem); err != nil {
-		return c.JSON(http.StatusInternalServerError, Response{
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
 			Message: err.Error(),
 		})
 	}
+	foodItem := model.FoodItem{Version: version}
+	patch.ApplyTo(&foodItem)
+
+	updatedFoodItem, err := fc.fu.UpdateFoodItem(foodItem, userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return versionConflictOrError(c, updatedFoodItem, err)
+	}
+	setETag(c, updatedFoodItem.Version)
 	return c.JSON(http.StatusOK, Response{
-		Data:    foodItem,
+		Data:    updatedFoodItem,
+		Message: "Food item updated successfully",
+	})
+}
+
+/**
+ * 食材の部分更新（JSON Merge Patch）
+ * 指定した項目だけを変更し、null を指定した項目は値を外す
+ * 変更できるのは title・category_id・tags のみで、それ以外の項目を含む場合は 400 を返す
+ * 取得時の ETag を If-Match ヘッダーに指定する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) PatchFoodItem(c echo.Context) error {
+	fields, patch, err := readFoodItemPatch(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+	if message := stockFieldMessage(fields); message != "" {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: message,
+		})
+	}
+	for key := range fields {
+		if !slices.Contains(model.FoodItemEditableFields, key) {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: fmt.Sprintf("Field cannot be patched: %s", key),
+			})
+		}
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+
+	updatedFoodItem, err := fc.fu.PatchFoodItem(patch, userIdFromToken(c), uint(foodItemId), version)
+	if err != nil {
+		return versionConflictOrError(c, updatedFoodItem, err)
+	}
+	setETag(c, updatedFoodItem.Version)
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedFoodItem,
 		Message: "Food item updated successfully",
 	})
 }
 
+/**
+ * リクエストの本文を食材の変更内容として読み込む
+ * @param c コンテキスト
+ * @return 本文に含まれていたキー, 変更内容, エラー
+ */
+func readFoodItemPatch(c echo.Context) (map[string]json.RawMessage, model.FoodItemPatch, error) {
+	patch := model.FoodItemPatch{}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, patch, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, patch, err
+	}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, patch, err
+	}
+	return fields, patch, nil
+}
+
+/**
+ * 本文にロットで管理する項目（数量・単位・賞味期限）が含まれている場合のエラーメッセージを返す
+ * これらは補充・消費・廃棄の操作で変更するため、更新では受け付けない
+ * @param fields 本文に含まれていたキー
+ * @return エラーメッセージ（含まれていない場合は空文字列）
+ */
+func stockFieldMessage(fields map[string]json.RawMessage) string {
+	for _, key := range model.FoodItemStockFields {
+		if _, ok := fields[key]; ok {
+			return fmt.Sprintf("Field cannot be updated: %s (use /food-items/:id/restock, /consume or /discard to change stock)", key)
+		}
+	}
+	return ""
+}
+
+/**
+ * 食材のバージョンを ETag ヘッダーに設定
+ * @param c コンテキスト
+ * @param version 食材のバージョン
+ */
+func setETag(c echo.Context, version uint) {
+	c.Response().Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+/**
+ * If-Match ヘッダーから更新前の食材のバージョンを取得
+ * ヘッダーがない場合は 428、形式が正しくない場合は 400 のエラーを返す
+ * @param c コンテキスト
+ * @return バージョン, エラー
+ */
+func parseIfMatch(c echo.Context) (uint, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if ifMatch == "" {
+		return 0, errors.New(errors.ValidationError, "If-Match header is required", http.StatusPreconditionRequired, nil)
+	}
+	version, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 0)
+	if err != nil || version == 0 {
+		return 0, errors.New(errors.ValidationError, "Invalid If-Match format", http.StatusBadRequest, nil)
+	}
+	return uint(version), nil
+}
+
+/**
+ * 更新の競合の場合は 412 と最新の食材を、それ以外はエラーを返す
+ * @param c コンテキスト
+ * @param current 競合した場合の最新の食材
+ * @param err エラー
+ * @return エラー
+ */
+func versionConflictOrError(c echo.Context, current model.FoodItemResponse, err error) error {
+	if err == errors.VersionConflict {
+		setETag(c, current.Version)
+		return c.JSON(http.StatusPreconditionFailed, Response{
+			Data:    current,
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+}
+
+/**
+ * エラーのレスポンスを作成する。検証エラーの場合は項目ごとのメッセージも含める
+ * @param err エラー
+ * @return レスポンス
+ */
+func errorResponse(err error) Response {
+	return Response{
+		Message: err.Error(),
+		Errors:  errors.GetFields(err),
+	}
+}
+
 /**
  * 食材の削除
+ * 食材はごみ箱に移動し、保持期間を過ぎると完全に削除される
  * @param c コンテキスト
  * @return エラー
  */
@@ -166,8 +526,8 @@ func (fc *foodItemController) DeleteFoodItem(c echo.Context) error {
 		})
 	}
 
-	if err := fc.fu.DeleteFoodItem(uint(foodItemId)); err != nil {
-		return c.JSON(http.StatusInternalServerError, Response{
+	if err := fc.fu.DeleteFoodItem(userIdFromToken(c), uint(foodItemId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
 			Message: err.Error(),
 		})
 	}
@@ -175,3 +535,699 @@ func (fc *foodItemController) DeleteFoodItem(c echo.Context) error {
 		Message: "Food item deleted successfully",
 	})
 }
+
+/**
+ * ごみ箱にある食材の取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) GetTrashedFoodItems(c echo.Context) error {
+	foodItems, err := fc.fu.GetTrashedFoodItems(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: foodItems,
+	})
+}
+
+/**
+ * ごみ箱にある食材を元に戻す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) RestoreFoodItem(c echo.Context) error {
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	foodItem, err := fc.fu.RestoreFoodItem(userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    foodItem,
+		Message: "Food item restored successfully",
+	})
+}
+
+/**
+ * 保管場所移動のリクエスト
+ */
+type moveFoodItemRequest struct {
+	StorageLocationId *uint `json:"storage_location_id"`
+}
+
+/**
+ * 食材を別の保管場所へ移動
+ * storage_location_id に null を指定すると保管場所なしになる
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) MoveFoodItem(c echo.Context) error {
+	req := moveFoodItemRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	move, err := fc.fu.MoveFoodItem(userIdFromToken(c), uint(foodItemId), req.StorageLocationId)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    move,
+		Message: "Food item moved successfully",
+	})
+}
+
+/**
+ * 開封のリクエスト
+ * opened_at を省略した場合は現在時刻、opened_shelf_life_days を省略した場合は食材またはカテゴリの日数を使う
+ */
+type openFoodItemRequest struct {
+	OpenedAt            *time.Time `json:"opened_at"`
+	OpenedShelfLifeDays *int       `json:"opened_shelf_life_days"`
+}
+
+/**
+ * 食材の開封
+ * 開封日時を記録し、開封後の期限と賞味期限の早いほうを食材の期限とする
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) OpenFoodItem(c echo.Context) error {
+	req := openFoodItemRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	foodItem, err := fc.fu.OpenFoodItem(userIdFromToken(c), uint(foodItemId), req.OpenedAt, req.OpenedShelfLifeDays)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	setETag(c, foodItem.Version)
+	return c.JSON(http.StatusOK, Response{
+		Data:    foodItem,
+		Message: "Food item opened successfully",
+	})
+}
+
+/**
+ * 食材の栄養成分の更新
+ * basis（100g / unit）と値を指定して置き換える。すべて省略した場合は同梱の成分表の値を設定し直す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) UpdateNutrition(c echo.Context) error {
+	nutrition := model.Nutrition{}
+	if err := c.Bind(&nutrition); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	foodItem, err := fc.fu.UpdateNutrition(userIdFromToken(c), uint(foodItemId), nutrition)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	setETag(c, foodItem.Version)
+	return c.JSON(http.StatusOK, Response{
+		Data:    foodItem,
+		Message: "Nutrition updated successfully",
+	})
+}
+
+/**
+ * 食材の栄養成分の削除
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) DeleteNutrition(c echo.Context) error {
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	foodItem, err := fc.fu.DeleteNutrition(userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	setETag(c, foodItem.Version)
+	return c.JSON(http.StatusOK, Response{
+		Data:    foodItem,
+		Message: "Nutrition deleted successfully",
+	})
+}
+
+/**
+ * 在庫の栄養成分の合計を取得
+ * 一覧の取得と同じ絞り込みの条件を指定できる。ページングはせずに全件を集計する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) GetNutritionSummary(c echo.Context) error {
+	filter, err := parseFoodItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: err.Error(),
+		})
+	}
+
+	summary, err := fc.fu.GetNutritionSummary(userIdFromToken(c), filter)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: summary,
+	})
+}
+
+/**
+ * 保存状態の変更のリクエスト
+ * shelf_life_days を省略した場合はカテゴリの日数（カテゴリにもなければ既定の日数）を使う
+ */
+type changeFoodStateRequest struct {
+	ShelfLifeDays *int `json:"shelf_life_days"`
+}
+
+/**
+ * 食材の冷凍
+ * 期限を冷凍した後の日持ちで計算し直す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) FreezeFoodItem(c echo.Context) error {
+	return fc.changeFoodState(c, model.StateFrozen, "Food item frozen successfully")
+}
+
+/**
+ * 食材の解凍
+ * 期限を解凍した後の日持ちで計算し直す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) ThawFoodItem(c echo.Context) error {
+	return fc.changeFoodState(c, model.StateThawed, "Food item thawed successfully")
+}
+
+/**
+ * 食材の調理
+ * 期限を調理した後の日持ちで計算し直す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) CookFoodItem(c echo.Context) error {
+	return fc.changeFoodState(c, model.StateCooked, "Food item cooked successfully")
+}
+
+/**
+ * 食材の保存状態を変更する
+ * 現在の状態から変更できない場合は 409 を返す
+ * @param c コンテキスト
+ * @param state 変更後の状態
+ * @param message 成功時のメッセージ
+ * @return エラー
+ */
+func (fc *foodItemController) changeFoodState(c echo.Context, state model.FoodState, message string) error {
+	req := changeFoodStateRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	foodItem, err := fc.fu.ChangeFoodState(userIdFromToken(c), uint(foodItemId), state, req.ShelfLifeDays)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	setETag(c, foodItem.Version)
+	return c.JSON(http.StatusOK, Response{
+		Data:    foodItem,
+		Message: message,
+	})
+}
+
+/**
+ * 食材の保存状態の変更履歴を新しい順に取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) GetFoodStateTransitions(c echo.Context) error {
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	transitions, err := fc.fu.GetFoodStateTransitions(userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: transitions,
+	})
+}
+
+/**
+ * 食材のロット一覧を賞味期限の早い順に取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) GetFoodLots(c echo.Context) error {
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	lots, err := fc.fu.GetFoodLots(userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: lots,
+	})
+}
+
+/**
+ * 補充のリクエスト
+ * unit を省略した場合は食材の単位とみなす。price は購入金額で、currency を省略した場合は JPY とみなす
+ */
+type restockFoodItemRequest struct {
+	Quantity   float64    `json:"quantity"`
+	Unit       model.Unit `json:"unit"`
+	ExpiryDate time.Time  `json:"expiry_date"`
+	Price      *float64   `json:"price"`
+	Currency   string     `json:"currency"`
+	Note       string     `json:"note"`
+}
+
+/**
+ * 食材の補充
+ * 購入した分を新しいロットとして追加し、増減を記録する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) RestockFoodItem(c echo.Context) error {
+	req := restockFoodItemRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	amount := model.Quantity{Amount: req.Quantity, Unit: req.Unit}
+	var price *model.Money
+	if req.Price != nil {
+		price = &model.Money{Amount: *req.Price, Currency: req.Currency}
+	}
+	change, err := fc.fu.RestockFoodItem(userIdFromToken(c), uint(foodItemId), amount, req.ExpiryDate, price, req.Note)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    change,
+		Message: "Food item restocked successfully",
+	})
+}
+
+/**
+ * 消費のリクエスト
+ * unit を省略した場合は食材の単位とみなす
+ */
+type consumeFoodItemRequest struct {
+	Quantity float64    `json:"quantity"`
+	Unit     model.Unit `json:"unit"`
+	Note     string     `json:"note"`
+}
+
+/**
+ * 食材の消費
+ * 賞味期限の早いロットから順に差し引き、増減を記録する
+ * 使い切った食材はユーザー設定に応じて削除されるか数量 0 で残る
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) ConsumeFoodItem(c echo.Context) error {
+	req := consumeFoodItemRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	amount := model.Quantity{Amount: req.Quantity, Unit: req.Unit}
+	change, err := fc.fu.ConsumeFoodItem(userIdFromToken(c), uint(foodItemId), amount, req.Note)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    change,
+		Message: "Food item consumed successfully",
+	})
+}
+
+/**
+ * 食材の在庫の増減履歴を新しい順に取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) GetInventoryMovements(c echo.Context) error {
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	movements, err := fc.fu.GetInventoryMovements(userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: movements,
+	})
+}
+
+/**
+ * 食材の廃棄
+ * reason は expired / spoiled / leftover / other のいずれか
+ * 在庫は消費と同じく賞味期限の早いロットから差し引き、廃棄の記録を消費とは別に残す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) DiscardFoodItem(c echo.Context) error {
+	waste := model.WasteRecord{}
+	if err := c.Bind(&waste); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	foodItemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	change, err := fc.fu.DiscardFoodItem(waste, userIdFromToken(c), uint(foodItemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    change,
+		Message: "Food item discarded successfully",
+	})
+}
+
+/**
+ * CSVファイルからの食材の一括登録
+ * multipart/form-data の file、または text/csv の本文で CSV を受け取る
+ * format=json（または application/json の本文、.json のファイル）の場合は書き出した JSON を受け取る
+ * dry_run=true の場合は登録せずに登録予定の食材を返す
+ * encoding は utf-8 / shift_jis（省略時は自動判定）
+ * mapping には列名から項目への対応を JSON で指定できる（例：{"品名":"title"}）
+ * 誤りのある行が 1 件でもあれば何も登録せず、行ごとの誤りを 422 で返す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) ImportFoodItems(c echo.Context) error {
+	options := model.ImportOptions{
+		Format:   model.ExportFormat(c.QueryParam("format")),
+		Encoding: model.ImportEncoding(strings.ToLower(c.QueryParam("encoding"))),
+	}
+	if options.Format == "" && strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		options.Format = model.ExportJSON
+	}
+	if dryRun := c.QueryParam("dry_run"); dryRun != "" {
+		v, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid dry_run format",
+			})
+		}
+		options.DryRun = v
+	}
+
+	body := c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "CSV file is required",
+			})
+		}
+		if options.Format == "" && strings.HasSuffix(strings.ToLower(fileHeader.Filename), ".json") {
+			options.Format = model.ExportJSON
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid request format",
+			})
+		}
+		defer file.Close()
+		body = file
+	}
+	if mapping := c.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &options.Mapping); err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid mapping format",
+			})
+		}
+	}
+
+	result, err := fc.fu.ImportFoodItems(userIdFromToken(c), body, options)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	if len(result.Errors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, Response{
+			Data:    result,
+			Message: "Some rows could not be imported",
+		})
+	}
+	if options.DryRun {
+		return c.JSON(http.StatusOK, Response{
+			Data: result,
+		})
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    result,
+		Message: "Food items imported successfully",
+	})
+}
+
+/**
+ * レシートの解析のリクエスト
+ */
+type parseReceiptRequest struct {
+	Text string `json:"text"`
+}
+
+/**
+ * 貼り付けたレシートの文字列を解析し、登録前に確認する下書きを返す
+ * text/plain の本文、または {"text": "..."} の JSON でレシートを受け取る。解析しても何も登録しない
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) ParseReceipt(c echo.Context) error {
+	req := parseReceiptRequest{}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMETextPlain) {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid request format",
+			})
+		}
+		req.Text = string(body)
+	} else if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	draft, err := fc.fu.ParseReceipt(userIdFromToken(c), req.Text)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: draft,
+	})
+}
+
+/**
+ * レシートの下書きを確認した品目を食材としてまとめて登録
+ * 誤りのある品目が 1 件でもあれば何も登録せず、品目ごとの誤りを 422 で返す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) CommitReceipt(c echo.Context) error {
+	receipt := model.ReceiptCommit{}
+	if err := c.Bind(&receipt); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	result, err := fc.fu.CommitReceipt(userIdFromToken(c), receipt)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	if len(result.Errors) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, Response{
+			Data:    result,
+			Message: "Some items could not be registered",
+		})
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    result,
+		Message: "Food items registered successfully",
+	})
+}
+
+/**
+ * 食材一覧の書き出し
+ * format は csv / json / md（省略時は csv）。絞り込みと並び替えは一覧の取得と同じで、ページングはせずに全件を返す
+ * csv と json は取り込みでそのまま読み込める
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (fc *foodItemController) ExportFoodItems(c echo.Context) error {
+	format := model.ExportFormat(c.QueryParam("format"))
+	if format == "" {
+		format = model.ExportCSV
+	}
+	if !format.IsValid() {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid format value",
+		})
+	}
+	filter, err := parseFoodItemFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: err.Error(),
+		})
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, exportContentTypes[format])
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="food-items-%s.%s"`, time.Now().Format("20060102"), format))
+	if err := fc.fu.ExportFoodItems(userIdFromToken(c), filter, format, c.Response()); err != nil {
+		if c.Response().Committed {
+			// 書き出しを始めた後はステータスを変更できないため、途中で打ち切る
+			return err
+		}
+		header.Del(echo.HeaderContentType)
+		header.Del(echo.HeaderContentDisposition)
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return nil
+}
+
+// 書き出し形式ごとの Content-Type
+var exportContentTypes = map[model.ExportFormat]string{
+	model.ExportCSV:      "text/csv; charset=UTF-8",
+	model.ExportJSON:     echo.MIMEApplicationJSONCharsetUTF8,
+	model.ExportMarkdown: "text/markdown; charset=UTF-8",
+}
@@ -1,10 +1,14 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	apperrors "go-rest-api/errors"
 	"go-rest-api/mock"
 	"go-rest-api/model"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -17,6 +21,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestToken は JWT ミドルウェアと同じ *jwt.MapClaims を持つトークンを生成する
+func newTestToken(userId uint) *jwt.Token {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.MapClaims{
+		"user_id": float64(userId),
+	})
+}
+
 func TestFoodItemController_GetAllFoodItems(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -34,19 +45,18 @@ func TestFoodItemController_GetAllFoodItems(t *testing.T) {
 			name: "正常系：食材一覧の取得成功",
 			buildStubs: func() {
 				mockFoodItemUsecase.EXPECT().
-					GetAllFoodItems().
+					GetAllFoodItems(uint(1), model.FoodItemFilter{}).
 					Times(1).
-					Return([]model.FoodItem{
+					Return([]model.FoodItemResponse{
 						{
 							ID:         1,
 							Title:      "りんご",
 							Quantity:   5,
 							ExpiryDate: time.Now().Add(24 * time.Hour),
-							UserId:     1,
 							CreatedAt:  time.Now(),
 							UpdatedAt:  time.Now(),
 						},
-					}, nil)
+					}, "", nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusOK, recorder.Code)
@@ -64,9 +74,9 @@ func TestFoodItemController_GetAllFoodItems(t *testing.T) {
 			name: "異常系：内部エラー",
 			buildStubs: func() {
 				mockFoodItemUsecase.EXPECT().
-					GetAllFoodItems().
+					GetAllFoodItems(uint(1), model.FoodItemFilter{}).
 					Times(1).
-					Return(nil, errors.New("internal error"))
+					Return(nil, "", errors.New("internal error"))
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -85,6 +95,7 @@ func TestFoodItemController_GetAllFoodItems(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/api/food-items", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
 
 			tt.buildStubs()
 
@@ -115,14 +126,13 @@ func TestFoodItemController_GetFoodItemById(t *testing.T) {
 			id:   "1",
 			buildStubs: func() {
 				mockFoodItemUsecase.EXPECT().
-					GetFoodItemById(uint(1)).
+					GetFoodItemById(uint(1), uint(1)).
 					Times(1).
-					Return(&model.FoodItem{
+					Return(model.FoodItemResponse{
 						ID:         1,
 						Title:      "りんご",
 						Quantity:   5,
 						ExpiryDate: time.Now().Add(24 * time.Hour),
-						UserId:     1,
 						CreatedAt:  time.Now(),
 						UpdatedAt:  time.Now(),
 					}, nil)
@@ -140,6 +150,25 @@ func TestFoodItemController_GetFoodItemById(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "異常系：他ユーザーの食材は404",
+			id:   "2",
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					GetFoodItemById(uint(1), uint(2)).
+					Times(1).
+					Return(model.FoodItemResponse{}, apperrors.FoodItemNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+				var response Response
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Nil(t, response.Data)
+				assert.Equal(t, "食材が見つかりません", response.Message)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
 		{
 			name: "異常系：不正なID",
 			id:   "invalid",
@@ -163,6 +192,7 @@ func TestFoodItemController_GetFoodItemById(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/api/food-items/:id", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
 			c.SetParamNames("id")
 			c.SetParamValues(tt.id)
 
@@ -183,10 +213,6 @@ func TestFoodItemController_CreateFoodItem(t *testing.T) {
 	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
 	foodItemController := NewFoodItemController(mockFoodItemUsecase)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": float64(1),
-	})
-
 	tests := []struct {
 		name           string
 		body           string
@@ -201,12 +227,11 @@ func TestFoodItemController_CreateFoodItem(t *testing.T) {
 				mockFoodItemUsecase.EXPECT().
 					CreateFoodItem(gomock.Any()).
 					Times(1).
-					Return(&model.FoodItem{
+					Return(model.FoodItemResponse{
 						ID:         1,
 						Title:      "りんご",
 						Quantity:   5,
 						ExpiryDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
-						UserId:     1,
 						CreatedAt:  time.Now(),
 						UpdatedAt:  time.Now(),
 					}, nil)
@@ -220,6 +245,28 @@ func TestFoodItemController_CreateFoodItem(t *testing.T) {
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name: "異常系：検証エラーは項目ごとのメッセージを返す",
+			body: `{"title":"","quantity":-1,"expiry_date":"2024-02-01T00:00:00Z"}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					CreateFoodItem(gomock.Any()).
+					Times(1).
+					Return(model.FoodItemResponse{}, apperrors.NewValidationError("quantity: must be no less than 0; title: title is required.", map[string]string{
+						"title":    "title is required",
+						"quantity": "must be no less than 0",
+					}))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				var response Response
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "title is required", response.Errors["title"])
+				assert.Equal(t, "must be no less than 0", response.Errors["quantity"])
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "異常系：不正なリクエストボディ",
 			body: `{"title": 123}`, // 不正な型
@@ -244,7 +291,7 @@ func TestFoodItemController_CreateFoodItem(t *testing.T) {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.Set("user", token)
+			c.Set("user", newTestToken(1))
 
 			tt.buildStubs()
 
@@ -266,23 +313,27 @@ func TestFoodItemController_UpdateFoodItem(t *testing.T) {
 	tests := []struct {
 		name           string
 		id             string
+		ifMatch        string
 		body           string
 		buildStubs     func()
 		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder)
 		expectedStatus int
 	}{
 		{
-			name: "正常系：食材の更新成功",
-			id:   "1",
-			body: `{"title":"更新済みりんご","quantity":3,"expiry_date":"2024-02-01T00:00:00Z"}`,
+			name:    "正常系：食材の更新成功",
+			id:      "1",
+			ifMatch: `"2"`,
+			body:    `{"title":"更新済みりんご","category_id":3,"tags":["果物"]}`,
 			buildStubs: func() {
+				categoryId := uint(3)
 				mockFoodItemUsecase.EXPECT().
-					UpdateFoodItem(gomock.Any()).
+					UpdateFoodItem(model.FoodItem{Title: "更新済みりんご", CategoryId: &categoryId, TagNames: []string{"果物"}, Version: 2}, uint(1), uint(1)).
 					Times(1).
-					Return(nil)
+					Return(model.FoodItemResponse{ID: 1, Title: "更新済みりんご", Quantity: 3, Version: 3}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, `"3"`, recorder.Header().Get("ETag"))
 				var response Response
 				err := json.Unmarshal(recorder.Body.Bytes(), &response)
 				assert.NoError(t, err)
@@ -290,10 +341,116 @@ func TestFoodItemController_UpdateFoodItem(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:    "正常系：取得した内容をそのまま送ると読み取り専用の項目は無視される",
+			id:      "1",
+			ifMatch: `"2"`,
+			body:    `{"id":1,"title":"りんご","user_id":99,"created_at":"2020-01-01T00:00:00Z","category_id":null,"tags":null,"version":2}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					UpdateFoodItem(model.FoodItem{Title: "りんご", TagNames: []string{}, Version: 2}, uint(1), uint(1)).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Title: "りんご", Version: 3}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "異常系：数量・単位・賞味期限は変更できない",
+			id:      "1",
+			ifMatch: `"2"`,
+			body:    `{"title":"りんご","quantity":5,"category_id":null,"tags":[]}`,
+			buildStubs: func() {
+				// 在庫は補充・消費・廃棄で変更するため、黙って無視せずに誤りにする
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), "Field cannot be updated: quantity")
+				assert.Contains(t, recorder.Body.String(), "/restock")
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "異常系：項目が足りない",
+			id:      "1",
+			ifMatch: `"2"`,
+			body:    `{"title":"りんご","tags":[]}`,
+			buildStubs: func() {
+				// 一部の項目だけの更新は PATCH を使う
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				var response Response
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "Missing field: category_id", response.Message)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "異常系：他ユーザーの食材は更新できない",
+			id:      "2",
+			ifMatch: `"1"`,
+			body:    `{"title":"横取り","category_id":null,"tags":[]}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					UpdateFoodItem(gomock.Any(), uint(1), uint(2)).
+					Times(1).
+					Return(model.FoodItemResponse{}, apperrors.FoodItemNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:    "異常系：他の更新が先に行われていた",
+			id:      "1",
+			ifMatch: `W/"2"`,
+			body:    `{"title":"古い画面からの更新","category_id":null,"tags":[]}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					UpdateFoodItem(gomock.Any(), uint(1), uint(1)).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Title: "先に更新された名前", Version: 3}, apperrors.VersionConflict)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+				assert.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+				assert.Contains(t, recorder.Body.String(), "先に更新された名前")
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name: "異常系：If-Match がない",
+			id:   "1",
+			body: `{"title":"りんご","category_id":null,"tags":[]}`,
+			buildStubs: func() {
+				// バージョンが分からない場合はusecaseは呼ばれない
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "異常系：不正な If-Match",
+			id:      "1",
+			ifMatch: "*",
+			body:    `{"title":"りんご","category_id":null,"tags":[]}`,
+			buildStubs: func() {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "異常系：不正なID",
 			id:   "invalid",
-			body: `{"title":"りんご","quantity":5}`,
+			body: `{"title":"りんご","category_id":null,"tags":[]}`,
 			buildStubs: func() {
 				// 不正なIDの場合はusecaseは呼ばれない
 			},
@@ -313,8 +470,12 @@ func TestFoodItemController_UpdateFoodItem(t *testing.T) {
 			e := echo.New()
 			req := httptest.NewRequest(http.MethodPut, "/api/food-items/:id", strings.NewReader(tt.body))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
 			c.SetParamNames("id")
 			c.SetParamValues(tt.id)
 
@@ -328,6 +489,133 @@ func TestFoodItemController_UpdateFoodItem(t *testing.T) {
 	}
 }
 
+func TestFoodItemController_PatchFoodItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	tests := []struct {
+		name          string
+		ifMatch       string
+		body          string
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "正常系：指定した項目だけを変更する",
+			ifMatch: `"2"`,
+			body:    `{"title":"青りんご"}`,
+			buildStubs: func() {
+				patch := model.FoodItemPatch{Title: model.PatchField[string]{Set: true, Value: "青りんご"}}
+				mockFoodItemUsecase.EXPECT().
+					PatchFoodItem(patch, uint(1), uint(1), uint(2)).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Title: "青りんご", Version: 3}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:    "正常系：null で値を外す",
+			ifMatch: `"2"`,
+			body:    `{"category_id":null}`,
+			buildStubs: func() {
+				patch := model.FoodItemPatch{CategoryId: model.PatchField[*uint]{Set: true}}
+				mockFoodItemUsecase.EXPECT().
+					PatchFoodItem(patch, uint(1), uint(1), uint(2)).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Version: 3}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "異常系：賞味期限はロットで管理する",
+			ifMatch: `"2"`,
+			body:    `{"expiry_date":"2026-12-31T00:00:00Z"}`,
+			buildStubs: func() {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), "Field cannot be updated: expiry_date")
+			},
+		},
+		{
+			name:    "異常系：変更できない項目を含む",
+			ifMatch: `"2"`,
+			body:    `{"title":"りんご","user_id":2}`,
+			buildStubs: func() {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), "Field cannot be patched: user_id")
+			},
+		},
+		{
+			name:    "異常系：他の更新が先に行われていた",
+			ifMatch: `"1"`,
+			body:    `{"tags":["果物"]}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					PatchFoodItem(gomock.Any(), uint(1), uint(1), uint(1)).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Version: 2}, apperrors.VersionConflict)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+				assert.Equal(t, `"2"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name: "異常系：If-Match がない",
+			body: `{"title":"りんご"}`,
+			buildStubs: func() {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "異常系：オブジェクトではない",
+			ifMatch: `"2"`,
+			body:    `["title"]`,
+			buildStubs: func() {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/api/food-items/:id", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.buildStubs()
+
+			err := foodItemController.PatchFoodItem(c)
+			assert.NoError(t, err)
+
+			tt.checkResponse(t, rec)
+		})
+	}
+}
+
 func TestFoodItemController_DeleteFoodItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -347,7 +635,7 @@ func TestFoodItemController_DeleteFoodItem(t *testing.T) {
 			id:   "1",
 			buildStubs: func() {
 				mockFoodItemUsecase.EXPECT().
-					DeleteFoodItem(uint(1)).
+					DeleteFoodItem(uint(1), uint(1)).
 					Times(1).
 					Return(nil)
 			},
@@ -360,6 +648,20 @@ func TestFoodItemController_DeleteFoodItem(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "異常系：他ユーザーの食材は削除できない",
+			id:   "2",
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					DeleteFoodItem(uint(1), uint(2)).
+					Times(1).
+					Return(apperrors.FoodItemNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
 		{
 			name: "異常系：不正なID",
 			id:   "invalid",
@@ -383,6 +685,7 @@ func TestFoodItemController_DeleteFoodItem(t *testing.T) {
 			req := httptest.NewRequest(http.MethodDelete, "/api/food-items/:id", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
 			c.SetParamNames("id")
 			c.SetParamValues(tt.id)
 
@@ -395,3 +698,700 @@ func TestFoodItemController_DeleteFoodItem(t *testing.T) {
 		})
 	}
 }
+
+func TestFoodItemController_GetAllFoodItems_LocationFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：保管場所で絞り込み", func(t *testing.T) {
+		locationId := uint(3)
+		mockFoodItemUsecase.EXPECT().
+			GetAllFoodItems(uint(1), model.FoodItemFilter{StorageLocationId: &locationId}).
+			Times(1).
+			Return([]model.FoodItemResponse{}, "", nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?location=3", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("異常系：不正な保管場所ID", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?location=fridge", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFoodItemController_MoveFoodItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	locationId := uint(5)
+
+	tests := []struct {
+		name          string
+		body          string
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "正常系：冷凍庫へ移動",
+			body: `{"storage_location_id":5}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					MoveFoodItem(uint(1), uint(1), &locationId).
+					Times(1).
+					Return(model.LocationMove{FoodItemId: 1, ToLocationId: &locationId, ToType: model.LocationFreezer}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var response Response
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "Food item moved successfully", response.Message)
+				move, ok := response.Data.(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, "freezer", move["to_type"])
+			},
+		},
+		{
+			name: "異常系：他ユーザーの保管場所",
+			body: `{"storage_location_id":5}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					MoveFoodItem(uint(1), uint(1), &locationId).
+					Times(1).
+					Return(model.LocationMove{}, apperrors.StorageLocationNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/food-items/:id/move", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.buildStubs()
+
+			err := foodItemController.MoveFoodItem(c)
+			assert.NoError(t, err)
+
+			tt.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestFoodItemController_GetAllFoodItems_Classification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：カテゴリとタグで絞り込み", func(t *testing.T) {
+		categoryId, tagId := uint(1), uint(4)
+		mockFoodItemUsecase.EXPECT().
+			GetAllFoodItems(uint(1), model.FoodItemFilter{CategoryId: &categoryId, TagId: &tagId}).
+			Times(1).
+			Return([]model.FoodItemResponse{}, "", nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?category=1&tag=4", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("正常系：カテゴリごとにまとめる", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			GetFoodItemsByCategory(uint(1), model.FoodItemFilter{}).
+			Times(1).
+			Return([]model.FoodItemGroup{
+				{
+					Category: &model.CategoryResponse{ID: 1, Code: "vegetables", Name: "野菜"},
+					Items:    []model.FoodItemResponse{{ID: 1, Title: "キャベツ"}},
+				},
+			}, "", nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?group_by=category", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "野菜")
+	})
+
+	t.Run("異常系：未対応の group_by", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?group_by=location", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFoodItemController_GetAllFoodItems_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：検索・並び替え・ページング", func(t *testing.T) {
+		sort := model.FoodItemSort{Field: model.SortByQuantity, Desc: true}
+		cursor := model.NewFoodItemCursor(model.FoodItem{ID: 5, Quantity: 3}, sort)
+		expired, min := false, 1.0
+		before := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		mockFoodItemUsecase.EXPECT().
+			GetAllFoodItems(uint(1), model.FoodItemFilter{
+				Title:         "トマト",
+				ExpiresBefore: &before,
+				Expired:       &expired,
+				MinQuantity:   &min,
+				Sort:          sort,
+				Limit:         20,
+				Cursor:        &cursor,
+			}).
+			Times(1).
+			Return([]model.FoodItemResponse{{ID: 4, Title: "トマト"}}, "next", nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?q=%E3%83%88%E3%83%9E%E3%83%88&expires_before=2026-11-01&expired=false&min_quantity=1&sort=quantity&order=desc&limit=20&cursor="+cursor.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response Response
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "next", response.NextCursor)
+	})
+
+	t.Run("正常系：cursorだけを指定した場合は既定の件数", func(t *testing.T) {
+		cursor := model.NewFoodItemCursor(model.FoodItem{ID: 5}, model.FoodItemSort{})
+		mockFoodItemUsecase.EXPECT().
+			GetAllFoodItems(uint(1), model.FoodItemFilter{Limit: defaultFoodItemLimit, Cursor: &cursor}).
+			Times(1).
+			Return([]model.FoodItemResponse{}, "", nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items?cursor="+cursor.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetAllFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	for _, query := range []string{
+		"sort=user_id",
+		"order=up",
+		"limit=0",
+		"limit=501",
+		"expired=maybe",
+		"expires_after=tomorrow",
+		"max_quantity=many",
+		"cursor=broken",
+		// 並び順が異なるページのカーソル
+		"sort=title&cursor=" + model.NewFoodItemCursor(model.FoodItem{ID: 1}, model.FoodItemSort{}).Encode(),
+	} {
+		t.Run("異常系："+query, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/food-items?"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
+
+			err := foodItemController.GetAllFoodItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestFoodItemController_ConsumeFoodItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	tests := []struct {
+		name          string
+		body          string
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "正常系：単位を指定して消費",
+			body: `{"quantity":200,"unit":"ml"}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					ConsumeFoodItem(uint(1), uint(1), model.Quantity{Amount: 200, Unit: "ml"}, "").
+					Times(1).
+					Return(model.StockChange{
+						FoodItem: model.FoodItemResponse{ID: 1, Title: "牛乳", Quantity: 1.3, Unit: model.UnitLiter},
+						Movement: model.InventoryMovement{Delta: -0.2, Unit: model.UnitLiter, Reason: model.MovementConsume},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var response Response
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				change, ok := response.Data.(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, 1.3, change["food_item"].(map[string]interface{})["quantity"])
+				assert.Equal(t, -0.2, change["movement"].(map[string]interface{})["delta"])
+			},
+		},
+		{
+			name: "異常系：在庫不足",
+			body: `{"quantity":5}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					ConsumeFoodItem(uint(1), uint(1), model.Quantity{Amount: 5}, "").
+					Times(1).
+					Return(model.StockChange{}, apperrors.InsufficientStock)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/food-items/:id/consume", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.buildStubs()
+
+			err := foodItemController.ConsumeFoodItem(c)
+			assert.NoError(t, err)
+
+			tt.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestFoodItemController_ImportFoodItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	newMultipartRequest := func(t *testing.T, target string, csv string, mapping string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "pantry.csv")
+		assert.NoError(t, err)
+		_, _ = part.Write([]byte(csv))
+		if mapping != "" {
+			assert.NoError(t, writer.WriteField("mapping", mapping))
+		}
+		assert.NoError(t, writer.Close())
+		req := httptest.NewRequest(http.MethodPost, target, body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("正常系：ファイルを指定してドライラン", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ImportFoodItems(uint(1), gomock.Any(), model.ImportOptions{
+				DryRun:   true,
+				Encoding: model.EncodingShiftJIS,
+				Mapping:  map[string]model.ImportField{"商品": model.ImportFieldTitle},
+			}).
+			Times(1).
+			Return(model.ImportResult{DryRun: true, Total: 1, Items: []model.FoodItemResponse{{Title: "卵"}}}, nil)
+
+		e := echo.New()
+		req := newMultipartRequest(t, "/api/food-items/import?dry_run=true&encoding=Shift_JIS", "商品,数量,賞味期限\n卵,10,2026-10-30\n", `{"商品":"title"}`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ImportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "卵")
+	})
+
+	t.Run("正常系：CSV本文を登録", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ImportFoodItems(uint(1), gomock.Any(), model.ImportOptions{}).
+			Times(1).
+			Return(model.ImportResult{Total: 1, Imported: 1}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/food-items/import", strings.NewReader("title,quantity,expiry_date\n卵,10,2026-10-30\n"))
+		req.Header.Set(echo.HeaderContentType, "text/csv")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ImportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("異常系：行ごとの誤り", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ImportFoodItems(uint(1), gomock.Any(), model.ImportOptions{}).
+			Times(1).
+			Return(model.ImportResult{Total: 1, Errors: []model.ImportRowError{{Row: 2, Column: "quantity", Message: "数量は 0 以上の数値で指定してください"}}}, nil)
+
+		e := echo.New()
+		req := newMultipartRequest(t, "/api/food-items/import", "title,quantity,expiry_date\n卵,たくさん,2026-10-30\n", "")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ImportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"row":2`)
+	})
+
+	t.Run("異常系：mapping の形式", func(t *testing.T) {
+		e := echo.New()
+		req := newMultipartRequest(t, "/api/food-items/import", "title\n", "title")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ImportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFoodItemController_ParseReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：text/plain の本文", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ParseReceipt(uint(1), "ｷｬﾍﾞﾂ ¥198\n").
+			Times(1).
+			Return(model.ReceiptDraft{Items: []model.ReceiptDraftItem{{Title: "キャベツ", Price: 198}}}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/receipts/parse", strings.NewReader("ｷｬﾍﾞﾂ ¥198\n"))
+		req.Header.Set(echo.HeaderContentType, "text/plain; charset=UTF-8")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ParseReceipt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "キャベツ")
+	})
+
+	t.Run("正常系：JSON の本文", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ParseReceipt(uint(1), "ｷｬﾍﾞﾂ ¥198").
+			Times(1).
+			Return(model.ReceiptDraft{}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/receipts/parse", strings.NewReader(`{"text":"ｷｬﾍﾞﾂ ¥198"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ParseReceipt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("異常系：空のレシート", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ParseReceipt(uint(1), "").
+			Times(1).
+			Return(model.ReceiptDraft{}, apperrors.New(apperrors.ValidationError, "レシートの文字列を指定してください", http.StatusBadRequest, nil))
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/receipts/parse", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ParseReceipt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFoodItemController_CommitReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：まとめて登録", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			CommitReceipt(uint(1), model.ReceiptCommit{Items: []model.ReceiptCommitItem{{Title: "キャベツ", Quantity: 1}}}).
+			Times(1).
+			Return(model.ImportResult{Total: 1, Imported: 1}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/receipts/commit", strings.NewReader(`{"items":[{"title":"キャベツ","quantity":1}]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.CommitReceipt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("異常系：品目ごとの誤り", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			CommitReceipt(uint(1), gomock.Any()).
+			Times(1).
+			Return(model.ImportResult{Total: 1, Errors: []model.ImportRowError{{Row: 1, Column: "title", Message: "title is required"}}}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/receipts/commit", strings.NewReader(`{"items":[{"quantity":1}]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.CommitReceipt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"row":1`)
+	})
+}
+
+func TestFoodItemController_UpdateNutrition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	tests := []struct {
+		name          string
+		body          string
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "正常系：100gあたりの値を指定",
+			body: `{"basis":"100g","kcal":190,"protein":16.6}`,
+			buildStubs: func() {
+				nutrition := model.Nutrition{Basis: model.NutritionPer100g, NutritionValues: model.NutritionValues{Kcal: 190, Protein: 16.6}}
+				mockFoodItemUsecase.EXPECT().
+					UpdateNutrition(uint(1), uint(1), nutrition).
+					Times(1).
+					Return(model.FoodItemResponse{ID: 1, Title: "鶏もも肉", Version: 3, Nutrition: &nutrition}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+				assert.Contains(t, recorder.Body.String(), `"kcal":190`)
+			},
+		},
+		{
+			name: "異常系：成分表にない食材",
+			body: `{}`,
+			buildStubs: func() {
+				mockFoodItemUsecase.EXPECT().
+					UpdateNutrition(uint(1), uint(1), model.Nutrition{}).
+					Times(1).
+					Return(model.FoodItemResponse{}, apperrors.NutritionUnknown)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/food-items/:id/nutrition", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", newTestToken(1))
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.buildStubs()
+
+			err := foodItemController.UpdateNutrition(c)
+			assert.NoError(t, err)
+
+			tt.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestFoodItemController_GetNutritionSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：絞り込んで集計", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			GetNutritionSummary(uint(1), gomock.Any()).
+			DoAndReturn(func(userId uint, filter model.FoodItemFilter) (model.NutritionSummary, error) {
+				assert.Equal(t, "卵", filter.Title)
+				return model.NutritionSummary{
+					Totals:  model.NutritionValues{Kcal: 142},
+					Items:   []model.NutritionSummaryItem{{FoodItemId: 1, Title: "卵", Quantity: 2, Unit: model.UnitPiece, Nutrition: &model.NutritionValues{Kcal: 142}}},
+					Missing: []model.NutritionSummaryItem{},
+				}, nil
+			})
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items/nutrition-summary?q=%E5%8D%B5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetNutritionSummary(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"totals":{"kcal":142`)
+	})
+
+	t.Run("異常系：不正な絞り込み", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items/nutrition-summary?location=abc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.GetNutritionSummary(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFoodItemController_ExportFoodItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFoodItemUsecase := mock.NewMockIFoodItemUsecase(ctrl)
+	foodItemController := NewFoodItemController(mockFoodItemUsecase)
+
+	t.Run("正常系：絞り込んでMarkdownで書き出し", func(t *testing.T) {
+		tagId := uint(2)
+		mockFoodItemUsecase.EXPECT().
+			ExportFoodItems(uint(1), model.FoodItemFilter{TagId: &tagId}, model.ExportMarkdown, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ uint, _ model.FoodItemFilter, _ model.ExportFormat, w io.Writer) error {
+				_, err := io.WriteString(w, "| 名前 |\n")
+				return err
+			})
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items/export?format=md&tag=2", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ExportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/markdown; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), ".md")
+		assert.Equal(t, "| 名前 |\n", rec.Body.String())
+	})
+
+	t.Run("異常系：書き出し前のエラー", func(t *testing.T) {
+		mockFoodItemUsecase.EXPECT().
+			ExportFoodItems(uint(1), gomock.Any(), model.ExportCSV, gomock.Any()).
+			Times(1).
+			Return(errors.New("internal error"))
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items/export", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ExportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	})
+
+	t.Run("異常系：未対応の形式", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/food-items/export?format=xlsx", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := foodItemController.ExportFoodItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,360 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 世帯コントローラーのインターフェース
+ */
+type IHouseholdController interface {
+	GetHouseholds(c echo.Context) error
+	GetHouseholdById(c echo.Context) error
+	CreateHousehold(c echo.Context) error
+	UpdateHousehold(c echo.Context) error
+	UpdateMemberRole(c echo.Context) error
+	RemoveMember(c echo.Context) error
+	TransferOwnership(c echo.Context) error
+	LeaveHousehold(c echo.Context) error
+	CreateInvitation(c echo.Context) error
+	GetInvitations(c echo.Context) error
+	AcceptInvitation(c echo.Context) error
+	DeclineInvitation(c echo.Context) error
+}
+
+/**
+ * 世帯コントローラーの構造体
+ */
+type householdController struct {
+	hu usecase.IHouseholdUsecase
+}
+
+/**
+ * 世帯コントローラーのコンストラクタ
+ * @param hu 世帯ユースケースのインターフェース
+ * @return 世帯コントローラーのインターフェース
+ */
+func NewHouseholdController(hu usecase.IHouseholdUsecase) IHouseholdController {
+	return &householdController{hu}
+}
+
+/**
+ * 認証済みユーザーが所属する世帯の取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) GetHouseholds(c echo.Context) error {
+	households, err := hc.hu.GetHouseholds(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: households,
+	})
+}
+
+/**
+ * 特定の世帯をメンバーとともに取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) GetHouseholdById(c echo.Context) error {
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	household, err := hc.hu.GetHouseholdById(userIdFromToken(c), uint(householdId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: household,
+	})
+}
+
+/**
+ * 世帯の作成
+ * 作成したユーザーが最初のメンバーになる
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) CreateHousehold(c echo.Context) error {
+	household := model.Household{}
+	if err := c.Bind(&household); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	createdHousehold, err := hc.hu.CreateHousehold(household, userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    createdHousehold,
+		Message: "Household created successfully",
+	})
+}
+
+/**
+ * 世帯名の変更
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) UpdateHousehold(c echo.Context) error {
+	household := model.Household{}
+	if err := c.Bind(&household); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	updatedHousehold, err := hc.hu.UpdateHousehold(household, userIdFromToken(c), uint(householdId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedHousehold,
+		Message: "Household updated successfully",
+	})
+}
+
+/**
+ * メンバーのロールの変更（所有者のみ）
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) UpdateMemberRole(c echo.Context) error {
+	memberRole := model.HouseholdMemberRole{}
+	if err := c.Bind(&memberRole); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	householdId, memberId, err := householdMemberParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	household, err := hc.hu.UpdateMemberRole(memberRole, userIdFromToken(c), householdId, memberId)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    household,
+		Message: "Member role updated successfully",
+	})
+}
+
+/**
+ * メンバーを世帯から外す（所有者のみ）
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) RemoveMember(c echo.Context) error {
+	householdId, memberId, err := householdMemberParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := hc.hu.RemoveMember(userIdFromToken(c), householdId, memberId); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Member removed successfully",
+	})
+}
+
+/**
+ * 所有権を他のメンバーに移す（所有者のみ）
+ * 元の所有者は編集者になる
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) TransferOwnership(c echo.Context) error {
+	transfer := model.HouseholdTransfer{}
+	if err := c.Bind(&transfer); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	household, err := hc.hu.TransferOwnership(transfer, userIdFromToken(c), uint(householdId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    household,
+		Message: "Ownership transferred successfully",
+	})
+}
+
+/**
+ * 世帯から抜ける
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) LeaveHousehold(c echo.Context) error {
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := hc.hu.LeaveHousehold(userIdFromToken(c), uint(householdId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Left household successfully",
+	})
+}
+
+/**
+ * 世帯への招待の作成（所有者のみ）
+ * トークンはこのレスポンスでのみ返す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) CreateInvitation(c echo.Context) error {
+	request := model.HouseholdInvitationRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	invitation, err := hc.hu.CreateInvitation(request, userIdFromToken(c), uint(householdId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    invitation,
+		Message: "Invitation created successfully",
+	})
+}
+
+/**
+ * 未回答で有効期限内の招待の取得（所有者のみ）
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) GetInvitations(c echo.Context) error {
+	id := c.Param("id")
+	householdId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	invitations, err := hc.hu.GetInvitations(uint(householdId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: invitations,
+	})
+}
+
+/**
+ * 招待の承諾
+ * 招待のロールで世帯のメンバーになる。招待は 1 回だけ使える
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) AcceptInvitation(c echo.Context) error {
+	household, err := hc.hu.AcceptInvitation(c.Param("token"), userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    household,
+		Message: "Invitation accepted successfully",
+	})
+}
+
+/**
+ * 招待の辞退
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (hc *householdController) DeclineInvitation(c echo.Context) error {
+	if err := hc.hu.DeclineInvitation(c.Param("token"), userIdFromToken(c)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Invitation declined successfully",
+	})
+}
+
+/**
+ * パスパラメータから世帯の ID とメンバーのユーザー ID を取得
+ * @param c コンテキスト
+ * @return 世帯の ID、メンバーのユーザー ID、エラー
+ */
+func householdMemberParams(c echo.Context) (uint, uint, error) {
+	householdId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	memberId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint(householdId), uint(memberId), nil
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 世帯のロールによるアクセス制御のミドルウェアのインターフェース
+ */
+type IHouseholdRoleMiddleware interface {
+	Require(resource model.HouseholdResource, param string, role model.HouseholdRole) echo.MiddlewareFunc
+	ReadWrite(resource model.HouseholdResource, param string) echo.MiddlewareFunc
+}
+
+/**
+ * 世帯のロールによるアクセス制御のミドルウェアの構造体
+ */
+type householdRoleMiddleware struct {
+	hu usecase.IHouseholdUsecase
+}
+
+/**
+ * 世帯のロールによるアクセス制御のミドルウェアのコンストラクタ
+ * @param hu 世帯ユースケースのインターフェース
+ * @return 世帯のロールによるアクセス制御のミドルウェアのインターフェース
+ */
+func NewHouseholdRoleMiddleware(hu usecase.IHouseholdUsecase) IHouseholdRoleMiddleware {
+	return &householdRoleMiddleware{hu}
+}
+
+/**
+ * パスパラメータ param の世帯で role 以上のロールを必要とする
+ * 世帯に所属していない場合は 404、ロールが足りない場合は 403 を返す
+ * @param resource データの種類
+ * @param param 世帯（またはデータ）の ID のパスパラメータ名
+ * @param role 必要なロール
+ * @return ミドルウェア
+ */
+func (hm *householdRoleMiddleware) Require(resource model.HouseholdResource, param string, role model.HouseholdRole) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return hm.check(c, next, resource, param, role, false)
+		}
+	}
+}
+
+/**
+ * 食材などの世帯のデータのルートグループ用。GET は閲覧者以上、それ以外のメソッドは編集者以上のロールを必要とする
+ * ID を含まないルート（一覧・登録）と、存在しないデータの扱いはハンドラーに任せる
+ * @param resource データの種類
+ * @param param データの ID のパスパラメータ名
+ * @return ミドルウェア
+ */
+func (hm *householdRoleMiddleware) ReadWrite(resource model.HouseholdResource, param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := model.RoleEditor
+			if m := c.Request().Method; m == http.MethodGet || m == http.MethodHead {
+				role = model.RoleViewer
+			}
+			return hm.check(c, next, resource, param, role, true)
+		}
+	}
+}
+
+func (hm *householdRoleMiddleware) check(c echo.Context, next echo.HandlerFunc, resource model.HouseholdResource, param string, required model.HouseholdRole, passNotFound bool) error {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		// ID の形式の誤りはハンドラーで 400 にする
+		return next(c)
+	}
+	role, err := hm.hu.GetMemberRole(userIdFromToken(c), resource, uint(id))
+	if errors.Is(err, apperrors.HouseholdNotFound) && passNotFound {
+		return next(c)
+	}
+	if err != nil {
+		return c.JSON(apperrors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	if !role.Allows(required) {
+		return c.JSON(http.StatusForbidden, Response{
+			Message: apperrors.HouseholdForbidden.Error(),
+		})
+	}
+	return next(c)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/mock"
+	"go-rest-api/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHouseholdRoleMiddleware_ReadWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdUsecase := mock.NewMockIHouseholdUsecase(ctrl)
+	middleware := NewHouseholdRoleMiddleware(mockHouseholdUsecase).ReadWrite(model.ResourceFoodItem, "id")
+
+	serve := func(method string, id string) (*httptest.ResponseRecorder, bool) {
+		called := false
+		handler := middleware(func(c echo.Context) error {
+			called = true
+			return c.NoContent(http.StatusOK)
+		})
+		e := echo.New()
+		req := httptest.NewRequest(method, "/api/food-items/"+id, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		c.Set("user", newTestToken(1))
+		assert.NoError(t, handler(c))
+		return rec, called
+	}
+
+	t.Run("閲覧者は参照できる", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceFoodItem, uint(5)).Return(model.RoleViewer, nil)
+
+		rec, called := serve(http.MethodGet, "5")
+		assert.True(t, called)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("閲覧者は編集できない", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceFoodItem, uint(5)).Return(model.RoleViewer, nil)
+
+		rec, called := serve(http.MethodPut, "5")
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("編集者は編集できる", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceFoodItem, uint(5)).Return(model.RoleEditor, nil)
+
+		_, called := serve(http.MethodDelete, "5")
+		assert.True(t, called)
+	})
+
+	t.Run("存在しないデータはハンドラーに任せる", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceFoodItem, uint(9)).Return(model.HouseholdRole(""), apperrors.HouseholdNotFound)
+
+		_, called := serve(http.MethodPut, "9")
+		assert.True(t, called)
+	})
+}
+
+func TestHouseholdRoleMiddleware_Require(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdUsecase := mock.NewMockIHouseholdUsecase(ctrl)
+	middleware := NewHouseholdRoleMiddleware(mockHouseholdUsecase).Require(model.ResourceHousehold, "id", model.RoleOwner)
+	handler := middleware(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/households/2/invitations", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+		c.Set("user", newTestToken(1))
+		assert.NoError(t, handler(c))
+		return rec
+	}
+
+	t.Run("所有者のみ", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceHousehold, uint(2)).Return(model.RoleOwner, nil)
+		assert.Equal(t, http.StatusOK, serve().Code)
+
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceHousehold, uint(2)).Return(model.RoleEditor, nil)
+		assert.Equal(t, http.StatusForbidden, serve().Code)
+	})
+
+	t.Run("所属していない世帯は 404", func(t *testing.T) {
+		mockHouseholdUsecase.EXPECT().GetMemberRole(uint(1), model.ResourceHousehold, uint(2)).Return(model.HouseholdRole(""), apperrors.HouseholdNotFound)
+		assert.Equal(t, http.StatusNotFound, serve().Code)
+	})
+}
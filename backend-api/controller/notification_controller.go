@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 通知コントローラーのインターフェース
+ */
+type INotificationController interface {
+	GetNotifications(c echo.Context) error
+	MarkAsRead(c echo.Context) error
+	MarkAllAsRead(c echo.Context) error
+}
+
+/**
+ * 通知コントローラーの構造体
+ */
+type notificationController struct {
+	nu usecase.INotificationUsecase
+}
+
+/**
+ * 通知コントローラーのコンストラクタ
+ * @param nu 通知ユースケースのインターフェース
+ * @return 通知コントローラーのインターフェース
+ */
+func NewNotificationController(nu usecase.INotificationUsecase) INotificationController {
+	return &notificationController{nu}
+}
+
+/**
+ * 認証済みユーザーの通知を新しい順に取得
+ * unread=true を指定すると未読の通知のみを返す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (nc *notificationController) GetNotifications(c echo.Context) error {
+	unreadOnly := false
+	if unread := c.QueryParam("unread"); unread != "" {
+		v, err := strconv.ParseBool(unread)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid unread format",
+			})
+		}
+		unreadOnly = v
+	}
+
+	notifications, err := nc.nu.GetNotifications(userIdFromToken(c), unreadOnly)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: notifications,
+	})
+}
+
+/**
+ * 通知を既読にする
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (nc *notificationController) MarkAsRead(c echo.Context) error {
+	id := c.Param("id")
+	notificationId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := nc.nu.MarkAsRead(userIdFromToken(c), uint(notificationId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Notification marked as read",
+	})
+}
+
+/**
+ * 全ての通知を既読にする
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (nc *notificationController) MarkAllAsRead(c echo.Context) error {
+	if err := nc.nu.MarkAllAsRead(userIdFromToken(c)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "All notifications marked as read",
+	})
+}
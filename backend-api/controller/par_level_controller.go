@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 最低在庫コントローラーのインターフェース
+ */
+type IParLevelController interface {
+	GetAllParLevels(c echo.Context) error
+	SaveParLevel(c echo.Context) error
+	UpdateParLevel(c echo.Context) error
+	DeleteParLevel(c echo.Context) error
+	GetRestockSuggestions(c echo.Context) error
+}
+
+/**
+ * 最低在庫コントローラーの構造体
+ */
+type parLevelController struct {
+	pu usecase.IParLevelUsecase
+}
+
+/**
+ * 最低在庫コントローラーのコンストラクタ
+ * @param pu 最低在庫ユースケースのインターフェース
+ * @return 最低在庫コントローラーのインターフェース
+ */
+func NewParLevelController(pu usecase.IParLevelUsecase) IParLevelController {
+	return &parLevelController{pu}
+}
+
+/**
+ * 認証済みユーザーの全ての最低在庫を取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (pc *parLevelController) GetAllParLevels(c echo.Context) error {
+	parLevels, err := pc.pu.GetAllParLevels(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: parLevels,
+	})
+}
+
+/**
+ * 最低在庫の登録
+ * 同じ名前の最低在庫がある場合は数量と単位を置き換える
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (pc *parLevelController) SaveParLevel(c echo.Context) error {
+	parLevel := model.ParLevel{}
+	if err := c.Bind(&parLevel); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+	parLevel.UserId = userIdFromToken(c)
+
+	savedParLevel, err := pc.pu.SaveParLevel(parLevel)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    savedParLevel,
+		Message: "Par level saved successfully",
+	})
+}
+
+/**
+ * 最低在庫の更新
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (pc *parLevelController) UpdateParLevel(c echo.Context) error {
+	parLevel := model.ParLevel{}
+	if err := c.Bind(&parLevel); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	parLevelId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	updatedParLevel, err := pc.pu.UpdateParLevel(parLevel, userIdFromToken(c), uint(parLevelId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedParLevel,
+		Message: "Par level updated successfully",
+	})
+}
+
+/**
+ * 最低在庫の削除
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (pc *parLevelController) DeleteParLevel(c echo.Context) error {
+	id := c.Param("id")
+	parLevelId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := pc.pu.DeleteParLevel(userIdFromToken(c), uint(parLevelId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Par level deleted successfully",
+	})
+}
+
+/**
+ * 最低在庫を下回っている常備品の取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (pc *parLevelController) GetRestockSuggestions(c echo.Context) error {
+	suggestions, err := pc.pu.GetRestockSuggestions(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: suggestions,
+	})
+}
@@ -21,7 +21,12 @@ func NewRecipeController(ru usecase.IRecipeUsecase) IRecipeController {
 }
 
 func (rc *recipeController) GetRecipeSuggestions(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
+	user, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "レシピの提案に失敗しました",
+		})
+	}
 	claims := user.Claims.(*jwt.MapClaims)
 	userId := uint((*claims)["user_id"].(float64))
 
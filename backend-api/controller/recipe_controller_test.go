@@ -8,7 +8,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -23,9 +22,7 @@ func TestRecipeController_GetRecipeSuggestions(t *testing.T) {
 	recipeController := NewRecipeController(mockRecipeUsecase)
 
 	// JWTトークンの生成
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": float64(1),
-	})
+	token := newTestToken(1)
 
 	tests := []struct {
 		name           string
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/usecase"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * レポートコントローラーのインターフェース
+ */
+type IReportController interface {
+	GetWasteReport(c echo.Context) error
+	GetSpendingReport(c echo.Context) error
+}
+
+/**
+ * レポートコントローラーの構造体
+ */
+type reportController struct {
+	ru usecase.IReportUsecase
+}
+
+/**
+ * レポートコントローラーのコンストラクタ
+ * @param ru レポートユースケースのインターフェース
+ * @return レポートコントローラーのインターフェース
+ */
+func NewReportController(ru usecase.IReportUsecase) IReportController {
+	return &reportController{ru}
+}
+
+/**
+ * クエリパラメータ from / to（YYYY-MM-DD）からレポートの期間を取得
+ * to は指定した日を含む。未指定の場合はゼロ値を返し、既定の期間はユースケースで決める
+ * @param c コンテキスト
+ * @return 開始日時, 終了日時（この日時を含まない）, エラー
+ */
+func parseReportPeriod(c echo.Context) (time.Time, time.Time, error) {
+	var from, to time.Time
+	if v := c.QueryParam("from"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return from, to, err
+		}
+		from = t
+	}
+	if v := c.QueryParam("to"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return from, to, err
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+/**
+ * 認証済みユーザーの廃棄レポートを取得
+ * 月・カテゴリ・廃棄理由ごとに件数、数量、推定金額を集計する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (rc *reportController) GetWasteReport(c echo.Context) error {
+	from, to, err := parseReportPeriod(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid date format",
+		})
+	}
+
+	report, err := rc.ru.GetWasteReport(userIdFromToken(c), from, to)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: report,
+	})
+}
+
+/**
+ * 認証済みユーザーの支出レポートを取得
+ * 週・月・カテゴリごとに購入金額と、廃棄した食材の金額を通貨ごとに集計する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (rc *reportController) GetSpendingReport(c echo.Context) error {
+	from, to, err := parseReportPeriod(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid date format",
+		})
+	}
+
+	report, err := rc.ru.GetSpendingReport(userIdFromToken(c), from, to)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: report,
+	})
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"go-rest-api/mock"
+	"go-rest-api/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportController_GetWasteReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportUsecase := mock.NewMockIReportUsecase(ctrl)
+	reportController := NewReportController(mockReportUsecase)
+
+	t.Run("正常系：to は指定した日を含む", func(t *testing.T) {
+		from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+		to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+		mockReportUsecase.EXPECT().
+			GetWasteReport(uint(1), from, to).
+			Times(1).
+			Return(model.WasteReport{From: from, To: to}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/reports/waste?from=2026-09-01&to=2026-09-30", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := reportController.GetWasteReport(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("異常系：不正な日付", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/reports/waste?from=2026/09/01", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := reportController.GetWasteReport(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestReportController_GetSpendingReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportUsecase := mock.NewMockIReportUsecase(ctrl)
+	reportController := NewReportController(mockReportUsecase)
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	mockReportUsecase.EXPECT().
+		GetSpendingReport(uint(1), from, time.Time{}).
+		Times(1).
+		Return(model.SpendingReport{From: from, Amounts: model.MoneyTotals{"JPY": 636}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/reports/spending?from=2026-09-01", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", newTestToken(1))
+
+	err := reportController.GetSpendingReport(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"JPY":636`)
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 日持ちコントローラーのインターフェース
+ */
+type IShelfLifeController interface {
+	EstimateShelfLife(c echo.Context) error
+}
+
+/**
+ * 日持ちコントローラーの構造体
+ */
+type shelfLifeController struct {
+	su usecase.IShelfLifeUsecase
+}
+
+/**
+ * 日持ちコントローラーのコンストラクタ
+ * @param su 日持ちユースケースのインターフェース
+ * @return 日持ちコントローラーのインターフェース
+ */
+func NewShelfLifeController(su usecase.IShelfLifeUsecase) IShelfLifeController {
+	return &shelfLifeController{su}
+}
+
+/**
+ * 食材の日持ちの目安と賞味期限の見積もりを取得
+ * クエリパラメータ title（食材名）、location（fridge / freezer / pantry、省略可）、
+ * purchased_at（購入日時、省略時は現在時刻）を受け取る。目安がない場合は 404 を返す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shelfLifeController) EstimateShelfLife(c echo.Context) error {
+	purchasedAt := time.Now()
+	if v := c.QueryParam("purchased_at"); v != "" {
+		t, err := parseDateTime(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Message: "Invalid purchased_at format",
+			})
+		}
+		purchasedAt = t
+	}
+
+	estimate, err := sc.su.EstimateShelfLife(userIdFromToken(c), c.QueryParam("title"), model.LocationType(c.QueryParam("location")), purchasedAt)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: estimate,
+	})
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/mock"
+	"go-rest-api/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShelfLifeController_EstimateShelfLife(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShelfLifeUsecase := mock.NewMockIShelfLifeUsecase(ctrl)
+	shelfLifeController := NewShelfLifeController(mockShelfLifeUsecase)
+
+	t.Run("正常系：食材名・保管場所・購入日で見積もる", func(t *testing.T) {
+		purchasedAt := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+		mockShelfLifeUsecase.EXPECT().
+			EstimateShelfLife(uint(1), "キャベツ", model.LocationFridge, purchasedAt).
+			Times(1).
+			Return(model.ShelfLifeEstimate{Ingredient: "cabbage", Days: 14}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/shelf-life/estimate?title=%E3%82%AD%E3%83%A3%E3%83%99%E3%83%84&location=fridge&purchased_at=2026-10-17", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := shelfLifeController.EstimateShelfLife(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"ingredient":"cabbage"`)
+	})
+
+	t.Run("異常系：目安がない", func(t *testing.T) {
+		mockShelfLifeUsecase.EXPECT().
+			EstimateShelfLife(uint(1), "ケチャップ", model.LocationType(""), gomock.Any()).
+			Times(1).
+			Return(model.ShelfLifeEstimate{}, apperrors.ShelfLifeUnknown)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/shelf-life/estimate?title=%E3%82%B1%E3%83%81%E3%83%A3%E3%83%83%E3%83%97", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := shelfLifeController.EstimateShelfLife(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("異常系：不正な購入日", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/shelf-life/estimate?title=x&purchased_at=2026/10/17", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", newTestToken(1))
+
+		err := shelfLifeController.EstimateShelfLife(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 買い物リストコントローラーのインターフェース
+ */
+type IShoppingListController interface {
+	GetShoppingListItems(c echo.Context) error
+	CreateShoppingListItem(c echo.Context) error
+	UpdateShoppingListItem(c echo.Context) error
+	DeleteShoppingListItem(c echo.Context) error
+	ReorderShoppingListItems(c echo.Context) error
+	PurchaseShoppingListItems(c echo.Context) error
+}
+
+/**
+ * 買い物リストコントローラーの構造体
+ */
+type shoppingListController struct {
+	su usecase.IShoppingListUsecase
+}
+
+/**
+ * 買い物リストコントローラーのコンストラクタ
+ * @param su 買い物リストユースケースのインターフェース
+ * @return 買い物リストコントローラーのインターフェース
+ */
+func NewShoppingListController(su usecase.IShoppingListUsecase) IShoppingListController {
+	return &shoppingListController{su}
+}
+
+/**
+ * 認証済みユーザーの買い物リストを並び順で取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) GetShoppingListItems(c echo.Context) error {
+	items, err := sc.su.GetShoppingListItems(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: items,
+	})
+}
+
+/**
+ * 買い物リストへの項目の追加
+ * 項目はリストの末尾に追加される
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) CreateShoppingListItem(c echo.Context) error {
+	item := model.ShoppingListItem{}
+	if err := c.Bind(&item); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+	item.UserId = userIdFromToken(c)
+
+	createdItem, err := sc.su.CreateShoppingListItem(item)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    createdItem,
+		Message: "Shopping list item created successfully",
+	})
+}
+
+/**
+ * 買い物リストの項目の更新（名前・数量・単位・メモ・チェック）
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) UpdateShoppingListItem(c echo.Context) error {
+	item := model.ShoppingListItem{}
+	if err := c.Bind(&item); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	itemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	updatedItem, err := sc.su.UpdateShoppingListItem(item, userIdFromToken(c), uint(itemId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedItem,
+		Message: "Shopping list item updated successfully",
+	})
+}
+
+/**
+ * 買い物リストの項目の削除
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) DeleteShoppingListItem(c echo.Context) error {
+	id := c.Param("id")
+	itemId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := sc.su.DeleteShoppingListItem(userIdFromToken(c), uint(itemId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Shopping list item deleted successfully",
+	})
+}
+
+/**
+ * 買い物リストの並び替え
+ * リストのすべての項目の ID を新しい順序で指定する（{"item_ids": [3, 1, 2]}）
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) ReorderShoppingListItems(c echo.Context) error {
+	order := model.ShoppingListOrder{}
+	if err := c.Bind(&order); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	items, err := sc.su.ReorderShoppingListItems(userIdFromToken(c), order.ItemIds)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    items,
+		Message: "Shopping list reordered successfully",
+	})
+}
+
+/**
+ * チェック済みの項目の購入
+ * 項目を食材として登録し、リストから削除する。賞味期限を指定しなかった項目は推定する
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *shoppingListController) PurchaseShoppingListItems(c echo.Context) error {
+	purchase := model.ShoppingListPurchase{}
+	if err := c.Bind(&purchase); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	purchased, err := sc.su.PurchaseShoppingListItems(userIdFromToken(c), purchase)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), errorResponse(err))
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    purchased,
+		Message: "Shopping list items purchased successfully",
+	})
+}
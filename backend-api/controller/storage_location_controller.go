@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * 保管場所コントローラーのインターフェース
+ */
+type IStorageLocationController interface {
+	GetAllStorageLocations(c echo.Context) error
+	GetStorageLocationById(c echo.Context) error
+	CreateStorageLocation(c echo.Context) error
+	UpdateStorageLocation(c echo.Context) error
+	DeleteStorageLocation(c echo.Context) error
+}
+
+/**
+ * 保管場所コントローラーの構造体
+ */
+type storageLocationController struct {
+	su usecase.IStorageLocationUsecase
+}
+
+/**
+ * 保管場所コントローラーのコンストラクタ
+ * @param su 保管場所ユースケースのインターフェース
+ * @return 保管場所コントローラーのインターフェース
+ */
+func NewStorageLocationController(su usecase.IStorageLocationUsecase) IStorageLocationController {
+	return &storageLocationController{su}
+}
+
+/**
+ * 認証済みユーザーの全ての保管場所を取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *storageLocationController) GetAllStorageLocations(c echo.Context) error {
+	locations, err := sc.su.GetAllStorageLocations(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: locations,
+	})
+}
+
+/**
+ * IDによる保管場所の取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *storageLocationController) GetStorageLocationById(c echo.Context) error {
+	id := c.Param("id")
+	locationId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	location, err := sc.su.GetStorageLocationById(userIdFromToken(c), uint(locationId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: location,
+	})
+}
+
+/**
+ * 保管場所の作成
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *storageLocationController) CreateStorageLocation(c echo.Context) error {
+	location := model.StorageLocation{}
+	if err := c.Bind(&location); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+	location.UserId = userIdFromToken(c)
+
+	createdLocation, err := sc.su.CreateStorageLocation(location)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    createdLocation,
+		Message: "Storage location created successfully",
+	})
+}
+
+/**
+ * 保管場所の更新
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *storageLocationController) UpdateStorageLocation(c echo.Context) error {
+	location := model.StorageLocation{}
+	if err := c.Bind(&location); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	locationId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	updatedLocation, err := sc.su.UpdateStorageLocation(location, userIdFromToken(c), uint(locationId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedLocation,
+		Message: "Storage location updated successfully",
+	})
+}
+
+/**
+ * 保管場所の削除
+ * 保管されていた食材は保管場所なしになる
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *storageLocationController) DeleteStorageLocation(c echo.Context) error {
+	id := c.Param("id")
+	locationId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := sc.su.DeleteStorageLocation(userIdFromToken(c), uint(locationId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Storage location deleted successfully",
+	})
+}
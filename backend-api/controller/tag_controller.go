@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * タグコントローラーのインターフェース
+ */
+type ITagController interface {
+	GetAllTags(c echo.Context) error
+	CreateTag(c echo.Context) error
+	UpdateTag(c echo.Context) error
+	DeleteTag(c echo.Context) error
+}
+
+/**
+ * タグコントローラーの構造体
+ */
+type tagController struct {
+	tu usecase.ITagUsecase
+}
+
+/**
+ * タグコントローラーのコンストラクタ
+ * @param tu タグユースケースのインターフェース
+ * @return タグコントローラーのインターフェース
+ */
+func NewTagController(tu usecase.ITagUsecase) ITagController {
+	return &tagController{tu}
+}
+
+/**
+ * 認証済みユーザーの全てのタグを取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (tc *tagController) GetAllTags(c echo.Context) error {
+	tags, err := tc.tu.GetAllTags(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: tags,
+	})
+}
+
+/**
+ * タグの作成
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (tc *tagController) CreateTag(c echo.Context) error {
+	tag := model.Tag{}
+	if err := c.Bind(&tag); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+	tag.UserId = userIdFromToken(c)
+
+	createdTag, err := tc.tu.CreateTag(tag)
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusCreated, Response{
+		Data:    createdTag,
+		Message: "Tag created successfully",
+	})
+}
+
+/**
+ * タグ名の変更
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (tc *tagController) UpdateTag(c echo.Context) error {
+	tag := model.Tag{}
+	if err := c.Bind(&tag); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	id := c.Param("id")
+	tagId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	updatedTag, err := tc.tu.UpdateTag(tag, userIdFromToken(c), uint(tagId))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedTag,
+		Message: "Tag updated successfully",
+	})
+}
+
+/**
+ * タグの削除
+ * 食材からも取り外される
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (tc *tagController) DeleteTag(c echo.Context) error {
+	id := c.Param("id")
+	tagId, err := strconv.Atoi(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid ID format",
+		})
+	}
+
+	if err := tc.tu.DeleteTag(userIdFromToken(c), uint(tagId)); err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Message: "Tag deleted successfully",
+	})
+}
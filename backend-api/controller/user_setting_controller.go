@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"go-rest-api/errors"
+	"go-rest-api/usecase"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+/**
+ * ユーザー設定コントローラーのインターフェース
+ */
+type IUserSettingController interface {
+	GetUserSetting(c echo.Context) error
+	UpdateUserSetting(c echo.Context) error
+}
+
+/**
+ * ユーザー設定コントローラーの構造体
+ */
+type userSettingController struct {
+	su usecase.IUserSettingUsecase
+}
+
+/**
+ * ユーザー設定コントローラーのコンストラクタ
+ * @param su ユーザー設定ユースケースのインターフェース
+ * @return ユーザー設定コントローラーのインターフェース
+ */
+func NewUserSettingController(su usecase.IUserSettingUsecase) IUserSettingController {
+	return &userSettingController{su}
+}
+
+/**
+ * 認証済みユーザーの設定を取得
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *userSettingController) GetUserSetting(c echo.Context) error {
+	setting, err := sc.su.GetUserSetting(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data: setting,
+	})
+}
+
+/**
+ * 認証済みユーザーの設定を更新
+ * リクエストで省略した項目は現在の値のまま残す
+ * @param c コンテキスト
+ * @return エラー
+ */
+func (sc *userSettingController) UpdateUserSetting(c echo.Context) error {
+	setting, err := sc.su.GetUserSetting(userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	if err := c.Bind(&setting); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Message: "Invalid request format",
+		})
+	}
+
+	updatedSetting, err := sc.su.UpdateUserSetting(setting, userIdFromToken(c))
+	if err != nil {
+		return c.JSON(errors.GetHTTPStatus(err), Response{
+			Message: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, Response{
+		Data:    updatedSetting,
+		Message: "Settings updated successfully",
+	})
+}
@@ -24,7 +24,8 @@ func NewDB() *gorm.DB {
 		os.Getenv("POSTGRES_DB"),
 		os.Getenv("POSTGRES_PORT"))
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// 一意制約の違反などを gorm.ErrDuplicatedKey などの共通のエラーに変換する
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -23,6 +23,8 @@ type AppError struct {
 	Message    string    `json:"message"`
 	HTTPStatus int       `json:"-"`
 	Err        error     `json:"-"`
+	// Fields holds per-field messages keyed by the JSON field name
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // Error implements the error interface
@@ -48,6 +50,21 @@ func New(errType ErrorType, message string, httpStatus int, err error) *AppError
 	}
 }
 
+// NewValidationError creates a validation AppError with per-field messages
+func NewValidationError(message string, fields map[string]string) *AppError {
+	appErr := New(ValidationError, message, http.StatusBadRequest, nil)
+	appErr.Fields = fields
+	return appErr
+}
+
+// GetFields returns the per-field messages of a validation error, or nil
+func GetFields(err error) map[string]string {
+	if appErr, ok := err.(*AppError); ok {
+		return appErr.Fields
+	}
+	return nil
+}
+
 // Common validation errors
 var (
 	InvalidEmail = New(
@@ -91,6 +108,174 @@ var (
 		http.StatusInternalServerError,
 		nil,
 	)
+
+	// FoodItemNotFound は存在しない、または他ユーザーの食材を指定した場合に返す。
+	// 他ユーザーの食材の存在を漏らさないため、両者を区別しない。
+	FoodItemNotFound = New(
+		BusinessError,
+		"食材が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// LocationHouseholdMismatch は食材と異なる世帯の保管場所を指定した場合に返す
+	LocationHouseholdMismatch = New(
+		ValidationError,
+		"保管場所は食材と同じ世帯のものを指定してください",
+		http.StatusBadRequest,
+		nil,
+	)
+
+	StorageLocationNotFound = New(
+		BusinessError,
+		"保管場所が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	CategoryNotFound = New(
+		BusinessError,
+		"カテゴリが見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	TagNotFound = New(
+		BusinessError,
+		"タグが見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// TagExists は同じ名前のタグを作成、またはタグ名を変更しようとした場合に返す
+	TagExists = New(
+		BusinessError,
+		"同じ名前のタグが既に存在します",
+		http.StatusConflict,
+		nil,
+	)
+
+	ShoppingListItemNotFound = New(
+		BusinessError,
+		"買い物リストの項目が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	ParLevelNotFound = New(
+		BusinessError,
+		"最低在庫の設定が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// HouseholdNotFound は存在しない、または所属していない世帯を指定した場合に返す。
+	HouseholdNotFound = New(
+		BusinessError,
+		"世帯が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	NotificationNotFound = New(
+		BusinessError,
+		"通知が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// NutritionUnknown は栄養成分を指定せず、食材が同梱の成分表にもない場合に返す
+	NutritionUnknown = New(
+		BusinessError,
+		"この食材の栄養成分が成分表に見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// ShelfLifeUnknown は食材と保管場所の組み合わせに日持ちの目安がない場合に返す
+	ShelfLifeUnknown = New(
+		BusinessError,
+		"この食材と保管場所の日持ちの目安が見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// VersionConflict は更新しようとした食材が、取得後に他のユーザーによって変更されていた場合に返す
+	VersionConflict = New(
+		BusinessError,
+		"食材は他のユーザーによって更新されています。最新の内容を確認してください",
+		http.StatusPreconditionFailed,
+		nil,
+	)
+
+	InsufficientStock = New(
+		BusinessError,
+		"在庫が不足しています",
+		http.StatusConflict,
+		nil,
+	)
+
+	// InvalidStateTransition は食材の現在の保存状態から変更できない状態を指定した場合に返す（例：解凍済みの食材の冷凍）
+	InvalidStateTransition = New(
+		BusinessError,
+		"現在の保存状態からはこの状態に変更できません",
+		http.StatusConflict,
+		nil,
+	)
+
+	// HouseholdForbidden は世帯でのロールが操作に必要な権限を持たない場合に返す
+	HouseholdForbidden = New(
+		BusinessError,
+		"この世帯でこの操作を行う権限がありません",
+		http.StatusForbidden,
+		nil,
+	)
+
+	HouseholdMemberNotFound = New(
+		BusinessError,
+		"世帯のメンバーが見つかりません",
+		http.StatusNotFound,
+		nil,
+	)
+
+	// OwnershipTransferRequired は所有者が自分のロールの変更や世帯からの脱退をしようとした場合に返す
+	OwnershipTransferRequired = New(
+		BusinessError,
+		"所有者は先に他のメンバーに所有権を移してください",
+		http.StatusConflict,
+		nil,
+	)
+
+	LastHousehold = New(
+		BusinessError,
+		"最後の世帯からは抜けられません",
+		http.StatusConflict,
+		nil,
+	)
+
+	// MemberLastHousehold はメンバーが編集できる最後の世帯から、そのメンバーを外すか閲覧者にしようとした場合に返す。
+	// 編集できる世帯がなくなると、そのメンバーは食材などを登録できなくなる
+	MemberLastHousehold = New(
+		BusinessError,
+		"このメンバーが編集できる最後の世帯のため、外すことや閲覧者にすることはできません",
+		http.StatusConflict,
+		nil,
+	)
+
+	// InvitationNotFound は存在しない、使用済み、または有効期限が切れた招待を指定した場合に返す
+	InvitationNotFound = New(
+		BusinessError,
+		"招待が見つからないか、使用済みまたは有効期限切れです",
+		http.StatusNotFound,
+		nil,
+	)
+
+	AlreadyHouseholdMember = New(
+		BusinessError,
+		"すでに世帯のメンバーです",
+		http.StatusConflict,
+		nil,
+	)
 )
 
 // AsAppError converts a standard error to an AppError if possible
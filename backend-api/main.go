@@ -1,25 +1,47 @@
 package main
 
 import (
+	"context"
 	"go-rest-api/controller"
 	"go-rest-api/db"
 	"go-rest-api/repository"
 	"go-rest-api/router"
+	"go-rest-api/scheduler"
 	"go-rest-api/services"
 	"go-rest-api/usecase"
 	"go-rest-api/validator"
 	"log"
+	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
 	db := db.NewDB()
 	userValidator := validator.NewUserValidator()
 	taskValidator := validator.NewTaskValidator()
+	storageLocationValidator := validator.NewStorageLocationValidator()
+	tagValidator := validator.NewTagValidator()
+	foodItemValidator := validator.NewFoodItemValidator()
+	shoppingListItemValidator := validator.NewShoppingListItemValidator()
+	parLevelValidator := validator.NewParLevelValidator()
+	householdValidator := validator.NewHouseholdValidator()
 
 	// リポジトリの初期化
 	userRepository := repository.NewUserRepository(db)
 	taskRepository := repository.NewTaskRepository(db)
 	foodItemRepository := repository.NewFoodItemRepository(db)
+	storageLocationRepository := repository.NewStorageLocationRepository(db)
+	categoryRepository := repository.NewCategoryRepository(db)
+	tagRepository := repository.NewTagRepository(db)
+	userSettingRepository := repository.NewUserSettingRepository(db)
+	wasteRepository := repository.NewWasteRepository(db)
+	purchaseRepository := repository.NewPurchaseRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	shoppingListRepository := repository.NewShoppingListRepository(db)
+	parLevelRepository := repository.NewParLevelRepository(db)
+	householdRepository := repository.NewHouseholdRepository(db)
+	shelfLifeRepository := repository.NewShelfLifeRepository(db)
 
 	// サービスの初期化
 	geminiService, err := services.NewGeminiService()
@@ -30,16 +52,59 @@ func main() {
 	// ユースケースの初期化
 	userUsecase := usecase.NewUserUsecase(userRepository, userValidator)
 	taskUsecase := usecase.NewTaskUsecase(taskRepository, taskValidator)
-	foodItemUsecase := usecase.NewFoodItemUsecase(foodItemRepository)
+	parLevelUsecase := usecase.NewParLevelUsecase(parLevelRepository, foodItemRepository, shoppingListRepository, parLevelValidator)
+	shelfLifeUsecase := usecase.NewShelfLifeUsecase(shelfLifeRepository)
+	foodItemUsecase := usecase.NewFoodItemUsecase(foodItemRepository, storageLocationRepository, categoryRepository, tagRepository, userSettingRepository, foodItemValidator, parLevelUsecase, shelfLifeUsecase)
+	storageLocationUsecase := usecase.NewStorageLocationUsecase(storageLocationRepository, storageLocationValidator)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepository)
+	tagUsecase := usecase.NewTagUsecase(tagRepository, tagValidator)
+	userSettingUsecase := usecase.NewUserSettingUsecase(userSettingRepository)
+	reportUsecase := usecase.NewReportUsecase(wasteRepository, purchaseRepository)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepository)
 	recipeUsecase := usecase.NewRecipeUsecase(foodItemRepository, geminiService)
+	householdUsecase := usecase.NewHouseholdUsecase(householdRepository, householdValidator)
+	shoppingListUsecase := usecase.NewShoppingListUsecase(shoppingListRepository, storageLocationRepository, shoppingListItemValidator, foodItemValidator, shelfLifeUsecase)
 
 	// コントローラーの初期化
 	userController := controller.NewUserController(userUsecase)
 	taskController := controller.NewTaskController(taskUsecase)
 	foodItemController := controller.NewFoodItemController(foodItemUsecase)
 	recipeController := controller.NewRecipeController(recipeUsecase)
+	storageLocationController := controller.NewStorageLocationController(storageLocationUsecase)
+	categoryController := controller.NewCategoryController(categoryUsecase)
+	tagController := controller.NewTagController(tagUsecase)
+	userSettingController := controller.NewUserSettingController(userSettingUsecase)
+	reportController := controller.NewReportController(reportUsecase)
+	notificationController := controller.NewNotificationController(notificationUsecase)
+	shoppingListController := controller.NewShoppingListController(shoppingListUsecase)
+	parLevelController := controller.NewParLevelController(parLevelUsecase)
+	householdController := controller.NewHouseholdController(householdUsecase)
+	shelfLifeController := controller.NewShelfLifeController(shelfLifeUsecase)
+	householdRoleMiddleware := controller.NewHouseholdRoleMiddleware(householdUsecase)
+
+	// 賞味期限の通知を定期的に作成する
+	alertInterval := scheduler.DefaultExpiryAlertInterval
+	if v := os.Getenv("EXPIRY_ALERT_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			alertInterval = d
+		} else {
+			log.Printf("Invalid EXPIRY_ALERT_INTERVAL %q, using %s: %v", v, alertInterval, err)
+		}
+	}
+	scheduler.NewExpiryAlertScheduler(notificationUsecase, alertInterval).Start(context.Background())
+
+	// ごみ箱の食材を保持期間の経過後に完全に削除する
+	trashRetention := scheduler.DefaultTrashRetention
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil && days > 0 {
+			trashRetention = time.Duration(days) * 24 * time.Hour
+		} else {
+			log.Printf("Invalid TRASH_RETENTION_DAYS %q, using %s", v, trashRetention)
+		}
+	}
+	scheduler.NewTrashPurgeScheduler(foodItemUsecase, trashRetention, scheduler.DefaultTrashPurgeInterval).Start(context.Background())
 
 	// ルーターの設定
-	e := router.NewRouter(taskController, userController, foodItemController, recipeController)
+	e := router.NewRouter(taskController, userController, foodItemController, recipeController, storageLocationController, categoryController, tagController, userSettingController, reportController, notificationController, shoppingListController, parLevelController, householdController, shelfLifeController, householdRoleMiddleware)
 	e.Logger.Fatal(e.Start(":8080"))
 }
@@ -4,11 +4,82 @@ import (
 	"fmt"
 	"go-rest-api/db"
 	"go-rest-api/model"
+	"go-rest-api/repository"
+	"log"
+
+	"gorm.io/gorm"
 )
 
 func main() {
 	dbConn := db.NewDB()
 	defer fmt.Println("Successfully Migrated")
 	defer db.CloseDB(dbConn)
-	dbConn.AutoMigrate(&model.User{}, &model.Task{}, &model.FoodItem{})
+	if err := migrateHouseholds(dbConn); err != nil {
+		log.Fatalln(err)
+	}
+	dbConn.AutoMigrate(&model.User{}, &model.Task{}, &model.StorageLocation{}, &model.Category{}, &model.Tag{}, &model.FoodItem{}, &model.FoodLot{}, &model.LocationMove{}, &model.InventoryMovement{}, &model.WasteRecord{}, &model.Purchase{}, &model.UserSetting{}, &model.Notification{}, &model.ShoppingListItem{}, &model.ParLevel{}, &model.Household{}, &model.HouseholdMember{}, &model.HouseholdInvitation{}, &model.FoodStateTransition{}, &model.ShelfLifeOverride{})
+
+	// ロット導入前の食材は、現在の数量と賞味期限をそのまま1つのロットにする
+	if err := dbConn.Exec(`INSERT INTO food_lots (food_item_id, quantity, expiry_date, purchased_at, created_at, updated_at)
+		SELECT f.id, f.quantity, f.expiry_date, f.created_at, NOW(), NOW() FROM food_items f
+		WHERE f.quantity > 0 AND NOT EXISTS (SELECT 1 FROM food_lots l WHERE l.food_item_id = f.id)`).Error; err != nil {
+		log.Fatalln(err)
+	}
+
+	// 期限の列の導入前の食材は、開封後の期限と保存状態を考慮した期限を埋め、賞味期限だけの索引は削除する
+	if err := dbConn.Exec(`UPDATE food_items SET effective_expiry = COALESCE(state_expiry_date, LEAST(expiry_date, opened_at + opened_shelf_life_days * INTERVAL '1 day'))
+		WHERE effective_expiry IS NULL`).Error; err != nil {
+		log.Fatalln(err)
+	}
+	if err := dbConn.Exec(`DROP INDEX IF EXISTS idx_food_items_user_expiry, idx_food_items_household_expiry`).Error; err != nil {
+		log.Fatalln(err)
+	}
+	// 通知は世帯のメンバーごとに作成するため、ユーザーを含まない重複防止の索引は削除する
+	if err := dbConn.Exec(`DROP INDEX IF EXISTS idx_notifications_alert`).Error; err != nil {
+		log.Fatalln(err)
+	}
+
+	// 初期カテゴリの投入
+	if err := repository.NewCategoryRepository(dbConn).SeedCategories(model.DefaultCategories); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// migrateHouseholds は世帯の導入前に登録したユーザーに個人の世帯を作成し、
+// 既存の食材・保管場所・タスクを登録したユーザーの個人の世帯のものにする。
+// household_id は NOT NULL のため、AutoMigrate で制約を付ける前に値を埋めておく。
+// ロールの導入前のメンバーは、それぞれの世帯の所有者にする。
+func migrateHouseholds(dbConn *gorm.DB) error {
+	if dbConn.Migrator().HasTable(&model.HouseholdMember{}) {
+		if err := dbConn.Exec(`ALTER TABLE household_members ADD COLUMN IF NOT EXISTS role varchar(16) NOT NULL DEFAULT 'owner'`).Error; err != nil {
+			return err
+		}
+	}
+	if err := dbConn.AutoMigrate(&model.User{}, &model.Household{}, &model.HouseholdMember{}); err != nil {
+		return err
+	}
+	hr := repository.NewHouseholdRepository(dbConn)
+	users := []model.User{}
+	if err := hr.GetUsersWithoutHousehold(&users); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := hr.CreateHousehold(&model.Household{Name: model.PersonalHouseholdName}, user.ID); err != nil {
+			return err
+		}
+	}
+	for _, table := range []string{"tasks", "storage_locations", "food_items"} {
+		if !dbConn.Migrator().HasTable(table) {
+			continue
+		}
+		if err := dbConn.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS household_id bigint`, table)).Error; err != nil {
+			return err
+		}
+		if err := dbConn.Exec(fmt.Sprintf(`UPDATE %s t SET household_id = (
+			SELECT m.household_id FROM household_members m WHERE m.user_id = t.user_id ORDER BY m.created_at, m.household_id LIMIT 1
+		) WHERE t.household_id IS NULL`, table)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: category_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICategoryUsecase is a mock of ICategoryUsecase interface.
+type MockICategoryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICategoryUsecaseMockRecorder
+}
+
+// MockICategoryUsecaseMockRecorder is the mock recorder for MockICategoryUsecase.
+type MockICategoryUsecaseMockRecorder struct {
+	mock *MockICategoryUsecase
+}
+
+// NewMockICategoryUsecase creates a new mock instance.
+func NewMockICategoryUsecase(ctrl *gomock.Controller) *MockICategoryUsecase {
+	mock := &MockICategoryUsecase{ctrl: ctrl}
+	mock.recorder = &MockICategoryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICategoryUsecase) EXPECT() *MockICategoryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetCategoryTree mocks base method.
+func (m *MockICategoryUsecase) GetCategoryTree() ([]model.CategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTree")
+	ret0, _ := ret[0].([]model.CategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTree indicates an expected call of GetCategoryTree.
+func (mr *MockICategoryUsecaseMockRecorder) GetCategoryTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTree", reflect.TypeOf((*MockICategoryUsecase)(nil).GetCategoryTree))
+}
@@ -0,0 +1,516 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: food_item_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIFoodItemUsecase is a mock of IFoodItemUsecase interface.
+type MockIFoodItemUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFoodItemUsecaseMockRecorder
+}
+
+// MockIFoodItemUsecaseMockRecorder is the mock recorder for MockIFoodItemUsecase.
+type MockIFoodItemUsecaseMockRecorder struct {
+	mock *MockIFoodItemUsecase
+}
+
+// NewMockIFoodItemUsecase creates a new mock instance.
+func NewMockIFoodItemUsecase(ctrl *gomock.Controller) *MockIFoodItemUsecase {
+	mock := &MockIFoodItemUsecase{ctrl: ctrl}
+	mock.recorder = &MockIFoodItemUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFoodItemUsecase) EXPECT() *MockIFoodItemUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangeFoodState mocks base method.
+func (m *MockIFoodItemUsecase) ChangeFoodState(userId, foodItemId uint, state model.FoodState, shelfLifeDays *int) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeFoodState", userId, foodItemId, state, shelfLifeDays)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeFoodState indicates an expected call of ChangeFoodState.
+func (mr *MockIFoodItemUsecaseMockRecorder) ChangeFoodState(userId, foodItemId, state, shelfLifeDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeFoodState", reflect.TypeOf((*MockIFoodItemUsecase)(nil).ChangeFoodState), userId, foodItemId, state, shelfLifeDays)
+}
+
+// CommitReceipt mocks base method.
+func (m *MockIFoodItemUsecase) CommitReceipt(userId uint, receipt model.ReceiptCommit) (model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitReceipt", userId, receipt)
+	ret0, _ := ret[0].(model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitReceipt indicates an expected call of CommitReceipt.
+func (mr *MockIFoodItemUsecaseMockRecorder) CommitReceipt(userId, receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitReceipt", reflect.TypeOf((*MockIFoodItemUsecase)(nil).CommitReceipt), userId, receipt)
+}
+
+// ConsumeFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) ConsumeFoodItem(userId, foodItemId uint, amount model.Quantity, note string) (model.StockChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeFoodItem", userId, foodItemId, amount, note)
+	ret0, _ := ret[0].(model.StockChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeFoodItem indicates an expected call of ConsumeFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) ConsumeFoodItem(userId, foodItemId, amount, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).ConsumeFoodItem), userId, foodItemId, amount, note)
+}
+
+// CreateFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) CreateFoodItem(foodItem model.FoodItem) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFoodItem", foodItem)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFoodItem indicates an expected call of CreateFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) CreateFoodItem(foodItem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).CreateFoodItem), foodItem)
+}
+
+// DeleteFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) DeleteFoodItem(userId, foodItemId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFoodItem", userId, foodItemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFoodItem indicates an expected call of DeleteFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) DeleteFoodItem(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).DeleteFoodItem), userId, foodItemId)
+}
+
+// DeleteNutrition mocks base method.
+func (m *MockIFoodItemUsecase) DeleteNutrition(userId, foodItemId uint) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNutrition", userId, foodItemId)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteNutrition indicates an expected call of DeleteNutrition.
+func (mr *MockIFoodItemUsecaseMockRecorder) DeleteNutrition(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNutrition", reflect.TypeOf((*MockIFoodItemUsecase)(nil).DeleteNutrition), userId, foodItemId)
+}
+
+// DiscardFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) DiscardFoodItem(waste model.WasteRecord, userId, foodItemId uint) (model.StockChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardFoodItem", waste, userId, foodItemId)
+	ret0, _ := ret[0].(model.StockChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardFoodItem indicates an expected call of DiscardFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) DiscardFoodItem(waste, userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).DiscardFoodItem), waste, userId, foodItemId)
+}
+
+// ExportFoodItems mocks base method.
+func (m *MockIFoodItemUsecase) ExportFoodItems(userId uint, filter model.FoodItemFilter, format model.ExportFormat, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFoodItems", userId, filter, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportFoodItems indicates an expected call of ExportFoodItems.
+func (mr *MockIFoodItemUsecaseMockRecorder) ExportFoodItems(userId, filter, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFoodItems", reflect.TypeOf((*MockIFoodItemUsecase)(nil).ExportFoodItems), userId, filter, format, w)
+}
+
+// GetAllFoodItems mocks base method.
+func (m *MockIFoodItemUsecase) GetAllFoodItems(userId uint, filter model.FoodItemFilter) ([]model.FoodItemResponse, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllFoodItems", userId, filter)
+	ret0, _ := ret[0].([]model.FoodItemResponse)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllFoodItems indicates an expected call of GetAllFoodItems.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetAllFoodItems(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFoodItems", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetAllFoodItems), userId, filter)
+}
+
+// GetFoodItemById mocks base method.
+func (m *MockIFoodItemUsecase) GetFoodItemById(userId, foodItemId uint) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodItemById", userId, foodItemId)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodItemById indicates an expected call of GetFoodItemById.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetFoodItemById(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodItemById", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetFoodItemById), userId, foodItemId)
+}
+
+// GetFoodItemsByCategory mocks base method.
+func (m *MockIFoodItemUsecase) GetFoodItemsByCategory(userId uint, filter model.FoodItemFilter) ([]model.FoodItemGroup, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodItemsByCategory", userId, filter)
+	ret0, _ := ret[0].([]model.FoodItemGroup)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFoodItemsByCategory indicates an expected call of GetFoodItemsByCategory.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetFoodItemsByCategory(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodItemsByCategory", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetFoodItemsByCategory), userId, filter)
+}
+
+// GetFoodLots mocks base method.
+func (m *MockIFoodItemUsecase) GetFoodLots(userId, foodItemId uint) ([]model.FoodLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodLots", userId, foodItemId)
+	ret0, _ := ret[0].([]model.FoodLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodLots indicates an expected call of GetFoodLots.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetFoodLots(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodLots", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetFoodLots), userId, foodItemId)
+}
+
+// GetFoodStateTransitions mocks base method.
+func (m *MockIFoodItemUsecase) GetFoodStateTransitions(userId, foodItemId uint) ([]model.FoodStateTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodStateTransitions", userId, foodItemId)
+	ret0, _ := ret[0].([]model.FoodStateTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodStateTransitions indicates an expected call of GetFoodStateTransitions.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetFoodStateTransitions(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodStateTransitions", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetFoodStateTransitions), userId, foodItemId)
+}
+
+// GetInventoryMovements mocks base method.
+func (m *MockIFoodItemUsecase) GetInventoryMovements(userId, foodItemId uint) ([]model.InventoryMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryMovements", userId, foodItemId)
+	ret0, _ := ret[0].([]model.InventoryMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryMovements indicates an expected call of GetInventoryMovements.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetInventoryMovements(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryMovements", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetInventoryMovements), userId, foodItemId)
+}
+
+// GetNutritionSummary mocks base method.
+func (m *MockIFoodItemUsecase) GetNutritionSummary(userId uint, filter model.FoodItemFilter) (model.NutritionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNutritionSummary", userId, filter)
+	ret0, _ := ret[0].(model.NutritionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNutritionSummary indicates an expected call of GetNutritionSummary.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetNutritionSummary(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNutritionSummary", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetNutritionSummary), userId, filter)
+}
+
+// GetTrashedFoodItems mocks base method.
+func (m *MockIFoodItemUsecase) GetTrashedFoodItems(userId uint) ([]model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedFoodItems", userId)
+	ret0, _ := ret[0].([]model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedFoodItems indicates an expected call of GetTrashedFoodItems.
+func (mr *MockIFoodItemUsecaseMockRecorder) GetTrashedFoodItems(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedFoodItems", reflect.TypeOf((*MockIFoodItemUsecase)(nil).GetTrashedFoodItems), userId)
+}
+
+// ImportFoodItems mocks base method.
+func (m *MockIFoodItemUsecase) ImportFoodItems(userId uint, r io.Reader, options model.ImportOptions) (model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportFoodItems", userId, r, options)
+	ret0, _ := ret[0].(model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportFoodItems indicates an expected call of ImportFoodItems.
+func (mr *MockIFoodItemUsecaseMockRecorder) ImportFoodItems(userId, r, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFoodItems", reflect.TypeOf((*MockIFoodItemUsecase)(nil).ImportFoodItems), userId, r, options)
+}
+
+// MoveFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) MoveFoodItem(userId, foodItemId uint, locationId *uint) (model.LocationMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFoodItem", userId, foodItemId, locationId)
+	ret0, _ := ret[0].(model.LocationMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFoodItem indicates an expected call of MoveFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) MoveFoodItem(userId, foodItemId, locationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).MoveFoodItem), userId, foodItemId, locationId)
+}
+
+// OpenFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) OpenFoodItem(userId, foodItemId uint, openedAt *time.Time, shelfLifeDays *int) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFoodItem", userId, foodItemId, openedAt, shelfLifeDays)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFoodItem indicates an expected call of OpenFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) OpenFoodItem(userId, foodItemId, openedAt, shelfLifeDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).OpenFoodItem), userId, foodItemId, openedAt, shelfLifeDays)
+}
+
+// ParseReceipt mocks base method.
+func (m *MockIFoodItemUsecase) ParseReceipt(userId uint, text string) (model.ReceiptDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseReceipt", userId, text)
+	ret0, _ := ret[0].(model.ReceiptDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseReceipt indicates an expected call of ParseReceipt.
+func (mr *MockIFoodItemUsecaseMockRecorder) ParseReceipt(userId, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseReceipt", reflect.TypeOf((*MockIFoodItemUsecase)(nil).ParseReceipt), userId, text)
+}
+
+// PatchFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) PatchFoodItem(patch model.FoodItemPatch, userId, foodItemId, version uint) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchFoodItem", patch, userId, foodItemId, version)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchFoodItem indicates an expected call of PatchFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) PatchFoodItem(patch, userId, foodItemId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).PatchFoodItem), patch, userId, foodItemId, version)
+}
+
+// PurgeTrashedFoodItems mocks base method.
+func (m *MockIFoodItemUsecase) PurgeTrashedFoodItems(deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedFoodItems", deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedFoodItems indicates an expected call of PurgeTrashedFoodItems.
+func (mr *MockIFoodItemUsecaseMockRecorder) PurgeTrashedFoodItems(deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedFoodItems", reflect.TypeOf((*MockIFoodItemUsecase)(nil).PurgeTrashedFoodItems), deletedBefore)
+}
+
+// RestockFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) RestockFoodItem(userId, foodItemId uint, amount model.Quantity, expiryDate time.Time, price *model.Money, note string) (model.StockChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestockFoodItem", userId, foodItemId, amount, expiryDate, price, note)
+	ret0, _ := ret[0].(model.StockChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestockFoodItem indicates an expected call of RestockFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) RestockFoodItem(userId, foodItemId, amount, expiryDate, price, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestockFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).RestockFoodItem), userId, foodItemId, amount, expiryDate, price, note)
+}
+
+// RestoreFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) RestoreFoodItem(userId, foodItemId uint) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFoodItem", userId, foodItemId)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFoodItem indicates an expected call of RestoreFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) RestoreFoodItem(userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).RestoreFoodItem), userId, foodItemId)
+}
+
+// UpdateFoodItem mocks base method.
+func (m *MockIFoodItemUsecase) UpdateFoodItem(foodItem model.FoodItem, userId, foodItemId uint) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFoodItem", foodItem, userId, foodItemId)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFoodItem indicates an expected call of UpdateFoodItem.
+func (mr *MockIFoodItemUsecaseMockRecorder) UpdateFoodItem(foodItem, userId, foodItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFoodItem", reflect.TypeOf((*MockIFoodItemUsecase)(nil).UpdateFoodItem), foodItem, userId, foodItemId)
+}
+
+// UpdateNutrition mocks base method.
+func (m *MockIFoodItemUsecase) UpdateNutrition(userId, foodItemId uint, nutrition model.Nutrition) (model.FoodItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNutrition", userId, foodItemId, nutrition)
+	ret0, _ := ret[0].(model.FoodItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNutrition indicates an expected call of UpdateNutrition.
+func (mr *MockIFoodItemUsecaseMockRecorder) UpdateNutrition(userId, foodItemId, nutrition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNutrition", reflect.TypeOf((*MockIFoodItemUsecase)(nil).UpdateNutrition), userId, foodItemId, nutrition)
+}
+
+// MockRestockSyncer is a mock of RestockSyncer interface.
+type MockRestockSyncer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRestockSyncerMockRecorder
+}
+
+// MockRestockSyncerMockRecorder is the mock recorder for MockRestockSyncer.
+type MockRestockSyncerMockRecorder struct {
+	mock *MockRestockSyncer
+}
+
+// NewMockRestockSyncer creates a new mock instance.
+func NewMockRestockSyncer(ctrl *gomock.Controller) *MockRestockSyncer {
+	mock := &MockRestockSyncer{ctrl: ctrl}
+	mock.recorder = &MockRestockSyncerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRestockSyncer) EXPECT() *MockRestockSyncerMockRecorder {
+	return m.recorder
+}
+
+// SyncRestock mocks base method.
+func (m *MockRestockSyncer) SyncRestock(userId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncRestock", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncRestock indicates an expected call of SyncRestock.
+func (mr *MockRestockSyncerMockRecorder) SyncRestock(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRestock", reflect.TypeOf((*MockRestockSyncer)(nil).SyncRestock), userId)
+}
+
+// MockShelfLifeEstimator is a mock of ShelfLifeEstimator interface.
+type MockShelfLifeEstimator struct {
+	ctrl     *gomock.Controller
+	recorder *MockShelfLifeEstimatorMockRecorder
+}
+
+// MockShelfLifeEstimatorMockRecorder is the mock recorder for MockShelfLifeEstimator.
+type MockShelfLifeEstimatorMockRecorder struct {
+	mock *MockShelfLifeEstimator
+}
+
+// NewMockShelfLifeEstimator creates a new mock instance.
+func NewMockShelfLifeEstimator(ctrl *gomock.Controller) *MockShelfLifeEstimator {
+	mock := &MockShelfLifeEstimator{ctrl: ctrl}
+	mock.recorder = &MockShelfLifeEstimatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShelfLifeEstimator) EXPECT() *MockShelfLifeEstimatorMockRecorder {
+	return m.recorder
+}
+
+// EstimateShelfLife mocks base method.
+func (m *MockShelfLifeEstimator) EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateShelfLife", userId, title, locationType, purchasedAt)
+	ret0, _ := ret[0].(model.ShelfLifeEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateShelfLife indicates an expected call of EstimateShelfLife.
+func (mr *MockShelfLifeEstimatorMockRecorder) EstimateShelfLife(userId, title, locationType, purchasedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateShelfLife", reflect.TypeOf((*MockShelfLifeEstimator)(nil).EstimateShelfLife), userId, title, locationType, purchasedAt)
+}
+
+// LearnShelfLife mocks base method.
+func (m *MockShelfLifeEstimator) LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt, expiryDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LearnShelfLife", userId, title, locationType, purchasedAt, expiryDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LearnShelfLife indicates an expected call of LearnShelfLife.
+func (mr *MockShelfLifeEstimatorMockRecorder) LearnShelfLife(userId, title, locationType, purchasedAt, expiryDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LearnShelfLife", reflect.TypeOf((*MockShelfLifeEstimator)(nil).LearnShelfLife), userId, title, locationType, purchasedAt, expiryDate)
+}
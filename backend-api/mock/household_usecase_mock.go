@@ -0,0 +1,227 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: household_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIHouseholdUsecase is a mock of IHouseholdUsecase interface.
+type MockIHouseholdUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdUsecaseMockRecorder
+}
+
+// MockIHouseholdUsecaseMockRecorder is the mock recorder for MockIHouseholdUsecase.
+type MockIHouseholdUsecaseMockRecorder struct {
+	mock *MockIHouseholdUsecase
+}
+
+// NewMockIHouseholdUsecase creates a new mock instance.
+func NewMockIHouseholdUsecase(ctrl *gomock.Controller) *MockIHouseholdUsecase {
+	mock := &MockIHouseholdUsecase{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdUsecase) EXPECT() *MockIHouseholdUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockIHouseholdUsecase) AcceptInvitation(token string, userId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", token, userId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockIHouseholdUsecaseMockRecorder) AcceptInvitation(token, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockIHouseholdUsecase)(nil).AcceptInvitation), token, userId)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockIHouseholdUsecase) CreateHousehold(household model.Household, userId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", household, userId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) CreateHousehold(household, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).CreateHousehold), household, userId)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockIHouseholdUsecase) CreateInvitation(request model.HouseholdInvitationRequest, userId, householdId uint) (model.HouseholdInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", request, userId, householdId)
+	ret0, _ := ret[0].(model.HouseholdInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockIHouseholdUsecaseMockRecorder) CreateInvitation(request, userId, householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockIHouseholdUsecase)(nil).CreateInvitation), request, userId, householdId)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockIHouseholdUsecase) DeclineInvitation(token string, userId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", token, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockIHouseholdUsecaseMockRecorder) DeclineInvitation(token, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockIHouseholdUsecase)(nil).DeclineInvitation), token, userId)
+}
+
+// GetHouseholdById mocks base method.
+func (m *MockIHouseholdUsecase) GetHouseholdById(userId, householdId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdById", userId, householdId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdById indicates an expected call of GetHouseholdById.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetHouseholdById(userId, householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdById", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetHouseholdById), userId, householdId)
+}
+
+// GetHouseholds mocks base method.
+func (m *MockIHouseholdUsecase) GetHouseholds(userId uint) ([]model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholds", userId)
+	ret0, _ := ret[0].([]model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholds indicates an expected call of GetHouseholds.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetHouseholds(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholds", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetHouseholds), userId)
+}
+
+// GetInvitations mocks base method.
+func (m *MockIHouseholdUsecase) GetInvitations(householdId uint) ([]model.HouseholdInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", householdId)
+	ret0, _ := ret[0].([]model.HouseholdInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetInvitations(householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetInvitations), householdId)
+}
+
+// GetMemberRole mocks base method.
+func (m *MockIHouseholdUsecase) GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", userId, resource, resourceId)
+	ret0, _ := ret[0].(model.HouseholdRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetMemberRole(userId, resource, resourceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetMemberRole), userId, resource, resourceId)
+}
+
+// LeaveHousehold mocks base method.
+func (m *MockIHouseholdUsecase) LeaveHousehold(userId, householdId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveHousehold", userId, householdId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveHousehold indicates an expected call of LeaveHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) LeaveHousehold(userId, householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).LeaveHousehold), userId, householdId)
+}
+
+// RemoveMember mocks base method.
+func (m *MockIHouseholdUsecase) RemoveMember(userId, householdId, memberId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", userId, householdId, memberId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockIHouseholdUsecaseMockRecorder) RemoveMember(userId, householdId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockIHouseholdUsecase)(nil).RemoveMember), userId, householdId, memberId)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockIHouseholdUsecase) TransferOwnership(transfer model.HouseholdTransfer, userId, householdId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", transfer, userId, householdId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockIHouseholdUsecaseMockRecorder) TransferOwnership(transfer, userId, householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockIHouseholdUsecase)(nil).TransferOwnership), transfer, userId, householdId)
+}
+
+// UpdateHousehold mocks base method.
+func (m *MockIHouseholdUsecase) UpdateHousehold(household model.Household, userId, householdId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHousehold", household, userId, householdId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHousehold indicates an expected call of UpdateHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) UpdateHousehold(household, userId, householdId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).UpdateHousehold), household, userId, householdId)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockIHouseholdUsecase) UpdateMemberRole(memberRole model.HouseholdMemberRole, userId, householdId, memberId uint) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", memberRole, userId, householdId, memberId)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) UpdateMemberRole(memberRole, userId, householdId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).UpdateMemberRole), memberRole, userId, householdId, memberId)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockINotificationUsecase is a mock of INotificationUsecase interface.
+type MockINotificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationUsecaseMockRecorder
+}
+
+// MockINotificationUsecaseMockRecorder is the mock recorder for MockINotificationUsecase.
+type MockINotificationUsecaseMockRecorder struct {
+	mock *MockINotificationUsecase
+}
+
+// NewMockINotificationUsecase creates a new mock instance.
+func NewMockINotificationUsecase(ctrl *gomock.Controller) *MockINotificationUsecase {
+	mock := &MockINotificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockINotificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationUsecase) EXPECT() *MockINotificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GenerateExpiryAlerts mocks base method.
+func (m *MockINotificationUsecase) GenerateExpiryAlerts(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateExpiryAlerts", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateExpiryAlerts indicates an expected call of GenerateExpiryAlerts.
+func (mr *MockINotificationUsecaseMockRecorder) GenerateExpiryAlerts(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateExpiryAlerts", reflect.TypeOf((*MockINotificationUsecase)(nil).GenerateExpiryAlerts), now)
+}
+
+// GetNotifications mocks base method.
+func (m *MockINotificationUsecase) GetNotifications(userId uint, unreadOnly bool) ([]model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userId, unreadOnly)
+	ret0, _ := ret[0].([]model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockINotificationUsecaseMockRecorder) GetNotifications(userId, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockINotificationUsecase)(nil).GetNotifications), userId, unreadOnly)
+}
+
+// MarkAllAsRead mocks base method.
+func (m *MockINotificationUsecase) MarkAllAsRead(userId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllAsRead", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllAsRead indicates an expected call of MarkAllAsRead.
+func (mr *MockINotificationUsecaseMockRecorder) MarkAllAsRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllAsRead", reflect.TypeOf((*MockINotificationUsecase)(nil).MarkAllAsRead), userId)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockINotificationUsecase) MarkAsRead(userId, notificationId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", userId, notificationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockINotificationUsecaseMockRecorder) MarkAsRead(userId, notificationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockINotificationUsecase)(nil).MarkAsRead), userId, notificationId)
+}
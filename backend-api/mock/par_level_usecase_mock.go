@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: par_level_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIParLevelUsecase is a mock of IParLevelUsecase interface.
+type MockIParLevelUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIParLevelUsecaseMockRecorder
+}
+
+// MockIParLevelUsecaseMockRecorder is the mock recorder for MockIParLevelUsecase.
+type MockIParLevelUsecaseMockRecorder struct {
+	mock *MockIParLevelUsecase
+}
+
+// NewMockIParLevelUsecase creates a new mock instance.
+func NewMockIParLevelUsecase(ctrl *gomock.Controller) *MockIParLevelUsecase {
+	mock := &MockIParLevelUsecase{ctrl: ctrl}
+	mock.recorder = &MockIParLevelUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIParLevelUsecase) EXPECT() *MockIParLevelUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteParLevel mocks base method.
+func (m *MockIParLevelUsecase) DeleteParLevel(userId, parLevelId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteParLevel", userId, parLevelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteParLevel indicates an expected call of DeleteParLevel.
+func (mr *MockIParLevelUsecaseMockRecorder) DeleteParLevel(userId, parLevelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteParLevel", reflect.TypeOf((*MockIParLevelUsecase)(nil).DeleteParLevel), userId, parLevelId)
+}
+
+// GetAllParLevels mocks base method.
+func (m *MockIParLevelUsecase) GetAllParLevels(userId uint) ([]model.ParLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllParLevels", userId)
+	ret0, _ := ret[0].([]model.ParLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllParLevels indicates an expected call of GetAllParLevels.
+func (mr *MockIParLevelUsecaseMockRecorder) GetAllParLevels(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllParLevels", reflect.TypeOf((*MockIParLevelUsecase)(nil).GetAllParLevels), userId)
+}
+
+// GetRestockSuggestions mocks base method.
+func (m *MockIParLevelUsecase) GetRestockSuggestions(userId uint) ([]model.RestockSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestockSuggestions", userId)
+	ret0, _ := ret[0].([]model.RestockSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestockSuggestions indicates an expected call of GetRestockSuggestions.
+func (mr *MockIParLevelUsecaseMockRecorder) GetRestockSuggestions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestockSuggestions", reflect.TypeOf((*MockIParLevelUsecase)(nil).GetRestockSuggestions), userId)
+}
+
+// SaveParLevel mocks base method.
+func (m *MockIParLevelUsecase) SaveParLevel(parLevel model.ParLevel) (model.ParLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveParLevel", parLevel)
+	ret0, _ := ret[0].(model.ParLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveParLevel indicates an expected call of SaveParLevel.
+func (mr *MockIParLevelUsecaseMockRecorder) SaveParLevel(parLevel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveParLevel", reflect.TypeOf((*MockIParLevelUsecase)(nil).SaveParLevel), parLevel)
+}
+
+// SyncRestock mocks base method.
+func (m *MockIParLevelUsecase) SyncRestock(userId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncRestock", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncRestock indicates an expected call of SyncRestock.
+func (mr *MockIParLevelUsecaseMockRecorder) SyncRestock(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRestock", reflect.TypeOf((*MockIParLevelUsecase)(nil).SyncRestock), userId)
+}
+
+// UpdateParLevel mocks base method.
+func (m *MockIParLevelUsecase) UpdateParLevel(parLevel model.ParLevel, userId, parLevelId uint) (model.ParLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateParLevel", parLevel, userId, parLevelId)
+	ret0, _ := ret[0].(model.ParLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateParLevel indicates an expected call of UpdateParLevel.
+func (mr *MockIParLevelUsecaseMockRecorder) UpdateParLevel(parLevel, userId, parLevelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParLevel", reflect.TypeOf((*MockIParLevelUsecase)(nil).UpdateParLevel), parLevel, userId, parLevelId)
+}
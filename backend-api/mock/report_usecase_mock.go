@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReportUsecase is a mock of IReportUsecase interface.
+type MockIReportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReportUsecaseMockRecorder
+}
+
+// MockIReportUsecaseMockRecorder is the mock recorder for MockIReportUsecase.
+type MockIReportUsecaseMockRecorder struct {
+	mock *MockIReportUsecase
+}
+
+// NewMockIReportUsecase creates a new mock instance.
+func NewMockIReportUsecase(ctrl *gomock.Controller) *MockIReportUsecase {
+	mock := &MockIReportUsecase{ctrl: ctrl}
+	mock.recorder = &MockIReportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReportUsecase) EXPECT() *MockIReportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetSpendingReport mocks base method.
+func (m *MockIReportUsecase) GetSpendingReport(userId uint, from, to time.Time) (model.SpendingReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpendingReport", userId, from, to)
+	ret0, _ := ret[0].(model.SpendingReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpendingReport indicates an expected call of GetSpendingReport.
+func (mr *MockIReportUsecaseMockRecorder) GetSpendingReport(userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpendingReport", reflect.TypeOf((*MockIReportUsecase)(nil).GetSpendingReport), userId, from, to)
+}
+
+// GetWasteReport mocks base method.
+func (m *MockIReportUsecase) GetWasteReport(userId uint, from, to time.Time) (model.WasteReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteReport", userId, from, to)
+	ret0, _ := ret[0].(model.WasteReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWasteReport indicates an expected call of GetWasteReport.
+func (mr *MockIReportUsecaseMockRecorder) GetWasteReport(userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteReport", reflect.TypeOf((*MockIReportUsecase)(nil).GetWasteReport), userId, from, to)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shelf_life_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIShelfLifeUsecase is a mock of IShelfLifeUsecase interface.
+type MockIShelfLifeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShelfLifeUsecaseMockRecorder
+}
+
+// MockIShelfLifeUsecaseMockRecorder is the mock recorder for MockIShelfLifeUsecase.
+type MockIShelfLifeUsecaseMockRecorder struct {
+	mock *MockIShelfLifeUsecase
+}
+
+// NewMockIShelfLifeUsecase creates a new mock instance.
+func NewMockIShelfLifeUsecase(ctrl *gomock.Controller) *MockIShelfLifeUsecase {
+	mock := &MockIShelfLifeUsecase{ctrl: ctrl}
+	mock.recorder = &MockIShelfLifeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShelfLifeUsecase) EXPECT() *MockIShelfLifeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// EstimateShelfLife mocks base method.
+func (m *MockIShelfLifeUsecase) EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateShelfLife", userId, title, locationType, purchasedAt)
+	ret0, _ := ret[0].(model.ShelfLifeEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateShelfLife indicates an expected call of EstimateShelfLife.
+func (mr *MockIShelfLifeUsecaseMockRecorder) EstimateShelfLife(userId, title, locationType, purchasedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateShelfLife", reflect.TypeOf((*MockIShelfLifeUsecase)(nil).EstimateShelfLife), userId, title, locationType, purchasedAt)
+}
+
+// LearnShelfLife mocks base method.
+func (m *MockIShelfLifeUsecase) LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt, expiryDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LearnShelfLife", userId, title, locationType, purchasedAt, expiryDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LearnShelfLife indicates an expected call of LearnShelfLife.
+func (mr *MockIShelfLifeUsecaseMockRecorder) LearnShelfLife(userId, title, locationType, purchasedAt, expiryDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LearnShelfLife", reflect.TypeOf((*MockIShelfLifeUsecase)(nil).LearnShelfLife), userId, title, locationType, purchasedAt, expiryDate)
+}
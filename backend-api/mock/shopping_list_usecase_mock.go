@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shopping_list_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIShoppingListUsecase is a mock of IShoppingListUsecase interface.
+type MockIShoppingListUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShoppingListUsecaseMockRecorder
+}
+
+// MockIShoppingListUsecaseMockRecorder is the mock recorder for MockIShoppingListUsecase.
+type MockIShoppingListUsecaseMockRecorder struct {
+	mock *MockIShoppingListUsecase
+}
+
+// NewMockIShoppingListUsecase creates a new mock instance.
+func NewMockIShoppingListUsecase(ctrl *gomock.Controller) *MockIShoppingListUsecase {
+	mock := &MockIShoppingListUsecase{ctrl: ctrl}
+	mock.recorder = &MockIShoppingListUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShoppingListUsecase) EXPECT() *MockIShoppingListUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateShoppingListItem mocks base method.
+func (m *MockIShoppingListUsecase) CreateShoppingListItem(item model.ShoppingListItem) (model.ShoppingListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShoppingListItem", item)
+	ret0, _ := ret[0].(model.ShoppingListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShoppingListItem indicates an expected call of CreateShoppingListItem.
+func (mr *MockIShoppingListUsecaseMockRecorder) CreateShoppingListItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShoppingListItem", reflect.TypeOf((*MockIShoppingListUsecase)(nil).CreateShoppingListItem), item)
+}
+
+// DeleteShoppingListItem mocks base method.
+func (m *MockIShoppingListUsecase) DeleteShoppingListItem(userId, itemId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShoppingListItem", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShoppingListItem indicates an expected call of DeleteShoppingListItem.
+func (mr *MockIShoppingListUsecaseMockRecorder) DeleteShoppingListItem(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingListItem", reflect.TypeOf((*MockIShoppingListUsecase)(nil).DeleteShoppingListItem), userId, itemId)
+}
+
+// GetShoppingListItems mocks base method.
+func (m *MockIShoppingListUsecase) GetShoppingListItems(userId uint) ([]model.ShoppingListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingListItems", userId)
+	ret0, _ := ret[0].([]model.ShoppingListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingListItems indicates an expected call of GetShoppingListItems.
+func (mr *MockIShoppingListUsecaseMockRecorder) GetShoppingListItems(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingListItems", reflect.TypeOf((*MockIShoppingListUsecase)(nil).GetShoppingListItems), userId)
+}
+
+// PurchaseShoppingListItems mocks base method.
+func (m *MockIShoppingListUsecase) PurchaseShoppingListItems(userId uint, purchase model.ShoppingListPurchase) ([]model.PurchasedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseShoppingListItems", userId, purchase)
+	ret0, _ := ret[0].([]model.PurchasedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseShoppingListItems indicates an expected call of PurchaseShoppingListItems.
+func (mr *MockIShoppingListUsecaseMockRecorder) PurchaseShoppingListItems(userId, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseShoppingListItems", reflect.TypeOf((*MockIShoppingListUsecase)(nil).PurchaseShoppingListItems), userId, purchase)
+}
+
+// ReorderShoppingListItems mocks base method.
+func (m *MockIShoppingListUsecase) ReorderShoppingListItems(userId uint, itemIds []uint) ([]model.ShoppingListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderShoppingListItems", userId, itemIds)
+	ret0, _ := ret[0].([]model.ShoppingListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderShoppingListItems indicates an expected call of ReorderShoppingListItems.
+func (mr *MockIShoppingListUsecaseMockRecorder) ReorderShoppingListItems(userId, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderShoppingListItems", reflect.TypeOf((*MockIShoppingListUsecase)(nil).ReorderShoppingListItems), userId, itemIds)
+}
+
+// UpdateShoppingListItem mocks base method.
+func (m *MockIShoppingListUsecase) UpdateShoppingListItem(item model.ShoppingListItem, userId, itemId uint) (model.ShoppingListItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShoppingListItem", item, userId, itemId)
+	ret0, _ := ret[0].(model.ShoppingListItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShoppingListItem indicates an expected call of UpdateShoppingListItem.
+func (mr *MockIShoppingListUsecaseMockRecorder) UpdateShoppingListItem(item, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShoppingListItem", reflect.TypeOf((*MockIShoppingListUsecase)(nil).UpdateShoppingListItem), item, userId, itemId)
+}
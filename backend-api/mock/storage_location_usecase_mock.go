@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage_location_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIStorageLocationUsecase is a mock of IStorageLocationUsecase interface.
+type MockIStorageLocationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStorageLocationUsecaseMockRecorder
+}
+
+// MockIStorageLocationUsecaseMockRecorder is the mock recorder for MockIStorageLocationUsecase.
+type MockIStorageLocationUsecaseMockRecorder struct {
+	mock *MockIStorageLocationUsecase
+}
+
+// NewMockIStorageLocationUsecase creates a new mock instance.
+func NewMockIStorageLocationUsecase(ctrl *gomock.Controller) *MockIStorageLocationUsecase {
+	mock := &MockIStorageLocationUsecase{ctrl: ctrl}
+	mock.recorder = &MockIStorageLocationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStorageLocationUsecase) EXPECT() *MockIStorageLocationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateStorageLocation mocks base method.
+func (m *MockIStorageLocationUsecase) CreateStorageLocation(location model.StorageLocation) (model.StorageLocationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStorageLocation", location)
+	ret0, _ := ret[0].(model.StorageLocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStorageLocation indicates an expected call of CreateStorageLocation.
+func (mr *MockIStorageLocationUsecaseMockRecorder) CreateStorageLocation(location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStorageLocation", reflect.TypeOf((*MockIStorageLocationUsecase)(nil).CreateStorageLocation), location)
+}
+
+// DeleteStorageLocation mocks base method.
+func (m *MockIStorageLocationUsecase) DeleteStorageLocation(userId, locationId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStorageLocation", userId, locationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStorageLocation indicates an expected call of DeleteStorageLocation.
+func (mr *MockIStorageLocationUsecaseMockRecorder) DeleteStorageLocation(userId, locationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStorageLocation", reflect.TypeOf((*MockIStorageLocationUsecase)(nil).DeleteStorageLocation), userId, locationId)
+}
+
+// GetAllStorageLocations mocks base method.
+func (m *MockIStorageLocationUsecase) GetAllStorageLocations(userId uint) ([]model.StorageLocationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStorageLocations", userId)
+	ret0, _ := ret[0].([]model.StorageLocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStorageLocations indicates an expected call of GetAllStorageLocations.
+func (mr *MockIStorageLocationUsecaseMockRecorder) GetAllStorageLocations(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStorageLocations", reflect.TypeOf((*MockIStorageLocationUsecase)(nil).GetAllStorageLocations), userId)
+}
+
+// GetStorageLocationById mocks base method.
+func (m *MockIStorageLocationUsecase) GetStorageLocationById(userId, locationId uint) (model.StorageLocationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageLocationById", userId, locationId)
+	ret0, _ := ret[0].(model.StorageLocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageLocationById indicates an expected call of GetStorageLocationById.
+func (mr *MockIStorageLocationUsecaseMockRecorder) GetStorageLocationById(userId, locationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageLocationById", reflect.TypeOf((*MockIStorageLocationUsecase)(nil).GetStorageLocationById), userId, locationId)
+}
+
+// UpdateStorageLocation mocks base method.
+func (m *MockIStorageLocationUsecase) UpdateStorageLocation(location model.StorageLocation, userId, locationId uint) (model.StorageLocationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStorageLocation", location, userId, locationId)
+	ret0, _ := ret[0].(model.StorageLocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStorageLocation indicates an expected call of UpdateStorageLocation.
+func (mr *MockIStorageLocationUsecaseMockRecorder) UpdateStorageLocation(location, userId, locationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStorageLocation", reflect.TypeOf((*MockIStorageLocationUsecase)(nil).UpdateStorageLocation), location, userId, locationId)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tag_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITagUsecase is a mock of ITagUsecase interface.
+type MockITagUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITagUsecaseMockRecorder
+}
+
+// MockITagUsecaseMockRecorder is the mock recorder for MockITagUsecase.
+type MockITagUsecaseMockRecorder struct {
+	mock *MockITagUsecase
+}
+
+// NewMockITagUsecase creates a new mock instance.
+func NewMockITagUsecase(ctrl *gomock.Controller) *MockITagUsecase {
+	mock := &MockITagUsecase{ctrl: ctrl}
+	mock.recorder = &MockITagUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITagUsecase) EXPECT() *MockITagUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateTag mocks base method.
+func (m *MockITagUsecase) CreateTag(tag model.Tag) (model.TagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", tag)
+	ret0, _ := ret[0].(model.TagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockITagUsecaseMockRecorder) CreateTag(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockITagUsecase)(nil).CreateTag), tag)
+}
+
+// DeleteTag mocks base method.
+func (m *MockITagUsecase) DeleteTag(userId, tagId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", userId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockITagUsecaseMockRecorder) DeleteTag(userId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockITagUsecase)(nil).DeleteTag), userId, tagId)
+}
+
+// GetAllTags mocks base method.
+func (m *MockITagUsecase) GetAllTags(userId uint) ([]model.TagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTags", userId)
+	ret0, _ := ret[0].([]model.TagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTags indicates an expected call of GetAllTags.
+func (mr *MockITagUsecaseMockRecorder) GetAllTags(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTags", reflect.TypeOf((*MockITagUsecase)(nil).GetAllTags), userId)
+}
+
+// UpdateTag mocks base method.
+func (m *MockITagUsecase) UpdateTag(tag model.Tag, userId, tagId uint) (model.TagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", tag, userId, tagId)
+	ret0, _ := ret[0].(model.TagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockITagUsecaseMockRecorder) UpdateTag(tag, userId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockITagUsecase)(nil).UpdateTag), tag, userId, tagId)
+}
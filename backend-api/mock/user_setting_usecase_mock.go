@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_setting_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "go-rest-api/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserSettingUsecase is a mock of IUserSettingUsecase interface.
+type MockIUserSettingUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserSettingUsecaseMockRecorder
+}
+
+// MockIUserSettingUsecaseMockRecorder is the mock recorder for MockIUserSettingUsecase.
+type MockIUserSettingUsecaseMockRecorder struct {
+	mock *MockIUserSettingUsecase
+}
+
+// NewMockIUserSettingUsecase creates a new mock instance.
+func NewMockIUserSettingUsecase(ctrl *gomock.Controller) *MockIUserSettingUsecase {
+	mock := &MockIUserSettingUsecase{ctrl: ctrl}
+	mock.recorder = &MockIUserSettingUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserSettingUsecase) EXPECT() *MockIUserSettingUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetUserSetting mocks base method.
+func (m *MockIUserSettingUsecase) GetUserSetting(userId uint) (model.UserSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSetting", userId)
+	ret0, _ := ret[0].(model.UserSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSetting indicates an expected call of GetUserSetting.
+func (mr *MockIUserSettingUsecaseMockRecorder) GetUserSetting(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSetting", reflect.TypeOf((*MockIUserSettingUsecase)(nil).GetUserSetting), userId)
+}
+
+// UpdateUserSetting mocks base method.
+func (m *MockIUserSettingUsecase) UpdateUserSetting(setting model.UserSetting, userId uint) (model.UserSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSetting", setting, userId)
+	ret0, _ := ret[0].(model.UserSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSetting indicates an expected call of UpdateUserSetting.
+func (mr *MockIUserSettingUsecaseMockRecorder) UpdateUserSetting(setting, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSetting", reflect.TypeOf((*MockIUserSettingUsecase)(nil).UpdateUserSetting), setting, userId)
+}
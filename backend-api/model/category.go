@@ -0,0 +1,147 @@
+package model
+
+import "time"
+
+// Category は食材の分類。親子関係で階層を表す（例：野菜 > 葉物野菜）。
+// カテゴリはシステム共通で、マイグレーション時に DefaultCategories から作成される。
+type Category struct {
+	ID       uint      `json:"id" gorm:"primaryKey"`
+	Code     string    `json:"code" gorm:"unique;not null"` // "vegetables.leafy" のような不変の識別子
+	Name     string    `json:"name" gorm:"not null"`
+	Parent   *Category `json:"-" gorm:"foreignKey:ParentId; constraint:OnDelete:CASCADE"`
+	ParentId *uint     `json:"parent_id" gorm:"index"`
+	// OpenedShelfLifeDays は開封後に食べきるまでの目安の日数。食材に日数がない場合に使う
+	OpenedShelfLifeDays *int `json:"opened_shelf_life_days"`
+	// 冷凍・解凍・調理した後の日持ちの日数。未設定の場合は親カテゴリ、親にもなければ DefaultStateShelfLifeDays を使う
+	FrozenShelfLifeDays *int      `json:"frozen_shelf_life_days"`
+	ThawedShelfLifeDays *int      `json:"thawed_shelf_life_days"`
+	CookedShelfLifeDays *int      `json:"cooked_shelf_life_days"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// OpenedShelfLife は開封後の日数を返す。設定されていない場合は親カテゴリの日数を使う。
+// Parent が読み込まれている必要がある。
+func (c Category) OpenedShelfLife() *int {
+	if c.OpenedShelfLifeDays == nil && c.Parent != nil {
+		return c.Parent.OpenedShelfLife()
+	}
+	return c.OpenedShelfLifeDays
+}
+
+// StateShelfLife は保存状態を state に変更した後の日持ちの日数を返す。
+// 設定されていない場合は親カテゴリ、親にもなければ DefaultStateShelfLifeDays の日数を使う。
+// Parent が読み込まれている必要がある。
+func (c Category) StateShelfLife(state FoodState) int {
+	for category := &c; category != nil; category = category.Parent {
+		var days *int
+		switch state {
+		case StateFrozen:
+			days = category.FrozenShelfLifeDays
+		case StateThawed:
+			days = category.ThawedShelfLifeDays
+		case StateCooked:
+			days = category.CookedShelfLifeDays
+		}
+		if days != nil {
+			return *days
+		}
+	}
+	return DefaultStateShelfLifeDays[state]
+}
+
+// Path は "野菜 > 葉物野菜" のような親を含む表示名を返す。Parent が読み込まれている必要がある。
+func (c Category) Path() string {
+	if c.Parent == nil {
+		return c.Name
+	}
+	return c.Parent.Path() + " > " + c.Name
+}
+
+type CategoryResponse struct {
+	ID       uint   `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	ParentId *uint  `json:"parent_id"`
+	// OpenedShelfLifeDays は開封後に食べきるまでの目安の日数
+	OpenedShelfLifeDays *int               `json:"opened_shelf_life_days"`
+	FrozenShelfLifeDays *int               `json:"frozen_shelf_life_days"`
+	ThawedShelfLifeDays *int               `json:"thawed_shelf_life_days"`
+	CookedShelfLifeDays *int               `json:"cooked_shelf_life_days"`
+	Children            []CategoryResponse `json:"children,omitempty"`
+}
+
+// DefaultCategory は初期カテゴリの定義
+type DefaultCategory struct {
+	Code string
+	Name string
+	// 開封後・冷凍後・解凍後・調理後の日持ちの目安の日数。0 は未設定
+	OpenedShelfLifeDays int
+	FrozenShelfLifeDays int
+	ThawedShelfLifeDays int
+	CookedShelfLifeDays int
+	Children            []DefaultCategory
+}
+
+// DefaultCategories は日本の家庭向けの初期カテゴリ
+var DefaultCategories = []DefaultCategory{
+	{Code: "vegetables", Name: "野菜", FrozenShelfLifeDays: 30, CookedShelfLifeDays: 3, Children: []DefaultCategory{
+		{Code: "vegetables.leafy", Name: "葉物野菜"},
+		{Code: "vegetables.root", Name: "根菜"},
+		{Code: "vegetables.fruit", Name: "果菜"},
+		{Code: "vegetables.mushroom", Name: "きのこ"},
+	}},
+	{Code: "fruits", Name: "果物"},
+	{Code: "meat", Name: "肉", FrozenShelfLifeDays: 90, ThawedShelfLifeDays: 1, CookedShelfLifeDays: 3, Children: []DefaultCategory{
+		{Code: "meat.beef", Name: "牛肉"},
+		{Code: "meat.pork", Name: "豚肉"},
+		{Code: "meat.chicken", Name: "鶏肉", FrozenShelfLifeDays: 60},
+		{Code: "meat.processed", Name: "ハム・ソーセージ", OpenedShelfLifeDays: 3, FrozenShelfLifeDays: 30, ThawedShelfLifeDays: 2},
+	}},
+	{Code: "seafood", Name: "魚介", FrozenShelfLifeDays: 60, ThawedShelfLifeDays: 1, CookedShelfLifeDays: 2, Children: []DefaultCategory{
+		{Code: "seafood.fish", Name: "魚"},
+		{Code: "seafood.shellfish", Name: "貝・えび・いか"},
+		{Code: "seafood.processed", Name: "練り物・干物", OpenedShelfLifeDays: 3},
+	}},
+	{Code: "dairy", Name: "卵・乳製品", Children: []DefaultCategory{
+		{Code: "dairy.egg", Name: "卵"},
+		{Code: "dairy.milk", Name: "牛乳", OpenedShelfLifeDays: 3},
+		{Code: "dairy.cheese", Name: "チーズ", OpenedShelfLifeDays: 14},
+		{Code: "dairy.yogurt", Name: "ヨーグルト", OpenedShelfLifeDays: 3},
+	}},
+	{Code: "soy", Name: "大豆製品", Children: []DefaultCategory{
+		{Code: "soy.tofu", Name: "豆腐", OpenedShelfLifeDays: 2},
+		{Code: "soy.natto", Name: "納豆"},
+		{Code: "soy.aburaage", Name: "油揚げ・厚揚げ"},
+	}},
+	{Code: "grains", Name: "米・パン・麺", Children: []DefaultCategory{
+		{Code: "grains.rice", Name: "米", FrozenShelfLifeDays: 30, ThawedShelfLifeDays: 1, CookedShelfLifeDays: 1},
+		{Code: "grains.bread", Name: "パン", FrozenShelfLifeDays: 30, ThawedShelfLifeDays: 2},
+		{Code: "grains.noodles", Name: "麺類"},
+	}},
+	{Code: "condiments", Name: "調味料", OpenedShelfLifeDays: 30, Children: []DefaultCategory{
+		{Code: "condiments.basic", Name: "基本調味料"},
+		{Code: "condiments.sauce", Name: "ソース・たれ"},
+		{Code: "condiments.oil", Name: "油", OpenedShelfLifeDays: 60},
+		{Code: "condiments.spice", Name: "スパイス", OpenedShelfLifeDays: 180},
+	}},
+	{Code: "beverages", Name: "飲料", OpenedShelfLifeDays: 3},
+	{Code: "frozen", Name: "冷凍食品"},
+	{Code: "snacks", Name: "お菓子"},
+	{Code: "other", Name: "その他"},
+}
+
+// Tag はユーザーが自由に付けられる食材のラベル
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+}
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
@@ -1,25 +1,141 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // FoodItem represents a food item with its details.
 type FoodItem struct {
 	ID         uint      `json:"id" gorm:"primaryKey"`
-	Title      string    `json:"title" gorm:"not null"`       // Reusing the Title field from Task
-	Quantity   int       `json:"quantity" gorm:"not null"`    // New field for quantity
+	Title      string    `json:"title" gorm:"not null"`                       // Reusing the Title field from Task
+	Quantity   float64   `json:"quantity" gorm:"type:numeric(12,3);not null"` // Amount in Unit
+	Unit       Unit      `json:"unit" gorm:"type:varchar(16);not null;default:'piece'"`
 	ExpiryDate time.Time `json:"expiry_date" gorm:"not null"` // New field for expiry date
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
-	User       User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
-	UserId     uint      `json:"user_id" gorm:"not null"`
+	// Version は更新のたびに増える。同時に編集した変更の上書きを防ぐため、更新時に一致を確認する
+	Version uint `json:"version" gorm:"not null;default:1"`
+	// DeletedAt はごみ箱に移動した日時。設定されている食材は通常の取得から除外される
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	User      User           `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId    uint           `json:"user_id" gorm:"not null;index:idx_food_items_user_effective_expiry,priority:1"`
+	// 食材を共有する世帯。UserId は登録したユーザーを表す
+	Household   Household `json:"-" gorm:"foreignKey:HouseholdId; constraint:OnDelete:CASCADE"`
+	HouseholdId uint      `json:"household_id" gorm:"not null;index:idx_food_items_household_effective_expiry,priority:1"`
+	// 保管場所。場所の変更は移動履歴を残すため move エンドポイント経由でのみ行う
+	StorageLocation   *StorageLocation `json:"-" gorm:"foreignKey:StorageLocationId; constraint:OnDelete:SET NULL"`
+	StorageLocationId *uint            `json:"storage_location_id" gorm:"index"`
+	Category          *Category        `json:"-" gorm:"foreignKey:CategoryId; constraint:OnDelete:SET NULL"`
+	CategoryId        *uint            `json:"category_id" gorm:"index"`
+	Tags              []Tag            `json:"-" gorm:"many2many:food_item_tags; constraint:OnDelete:CASCADE"`
+	// TagNames はリクエストで受け取るタグ名。nil の場合はタグを変更しない
+	TagNames []string `json:"tags" gorm:"-"`
+	// PurchasedAt は登録時に受け取る購入日時（省略時は現在時刻）。最初のロットの購入日時と、賞味期限を省略した場合の見積もりに使う
+	PurchasedAt *time.Time `json:"purchased_at" gorm:"-"`
+	// Price と Currency は登録時に受け取る購入金額と通貨（省略時は JPY）。最初のロットに記録する
+	Price    *float64 `json:"price" gorm:"-"`
+	Currency string   `json:"currency" gorm:"-"`
+	// 購入ごとのロット。Quantity と ExpiryDate はロットの合計と最も早い賞味期限
+	Lots []FoodLot `json:"-" gorm:"foreignKey:FoodItemId"`
+	// OpenedAt は開封した日時。開封の操作（open エンドポイント）でのみ設定する
+	OpenedAt *time.Time `json:"opened_at"`
+	// OpenedShelfLifeDays は開封後に食べきるまでの日数。未設定の場合は開封時にカテゴリの日数を使う
+	OpenedShelfLifeDays *int `json:"opened_shelf_life_days"`
+	// State は保存状態。状態の変更は freeze・thaw・cook の操作と、冷凍庫への登録・移動でのみ行う
+	State FoodState `json:"state" gorm:"type:varchar(16);not null;default:'fresh'"`
+	// StateExpiryDate は保存状態の変更時に計算し直した期限。生の食材では nil
+	StateExpiryDate *time.Time `json:"state_expiry_date"`
+	// StateTransitions は登録時に作成する保存状態の変更履歴（冷凍庫に登録した食材の冷凍）
+	StateTransitions []FoodStateTransition `json:"-" gorm:"foreignKey:FoodItemId"`
+	// Nutrition は栄養成分。登録時に省略した場合は同梱の成分表から補い、変更は nutrition エンドポイントで行う
+	Nutrition Nutrition `json:"nutrition" gorm:"embedded;embeddedPrefix:nutrition_"`
+	// EffectiveExpiry は EffectiveExpiryDate を保存した値。期限での絞り込み・並び替えに索引を使うため、
+	// 賞味期限・開封・保存状態を変更するたびに設定し直す
+	EffectiveExpiry time.Time `json:"-" gorm:"index:idx_food_items_user_effective_expiry,priority:2;index:idx_food_items_household_effective_expiry,priority:2"`
+}
+
+// BeforeCreate は登録時の期限を EffectiveExpiry に保存する
+func (f *FoodItem) BeforeCreate(tx *gorm.DB) error {
+	f.EffectiveExpiry = f.EffectiveExpiryDate()
+	return nil
+}
+
+// OpenedExpiryDate は開封後の期限（開封日時 + 開封後の日数）を返す。
+// 開封していない、または開封後の日数が分からない場合は false を返す。
+func (f FoodItem) OpenedExpiryDate() (time.Time, bool) {
+	if f.OpenedAt == nil || f.OpenedShelfLifeDays == nil {
+		return time.Time{}, false
+	}
+	return f.OpenedAt.AddDate(0, 0, *f.OpenedShelfLifeDays), true
+}
+
+// EffectiveExpiryDate は賞味期限と開封後の期限の早いほうを返す。
+// 冷凍・解凍・調理で保存状態を変更した食材は、変更時に計算し直した期限を返す。
+// 期限切れ・期限間近の判定や並び替えにはこの値を使う。
+func (f FoodItem) EffectiveExpiryDate() time.Time {
+	if f.StateExpiryDate != nil {
+		return *f.StateExpiryDate
+	}
+	if opened, ok := f.OpenedExpiryDate(); ok && opened.Before(f.ExpiryDate) {
+		return opened
+	}
+	return f.ExpiryDate
+}
+
+// Amount returns the quantity of the item together with its unit.
+func (f FoodItem) Amount() Quantity {
+	return Quantity{Amount: f.Quantity, Unit: f.Unit}
+}
+
+// FoodItemFilter holds the conditions for listing food items. Nil fields are ignored.
+type FoodItemFilter struct {
+	StorageLocationId *uint
+	CategoryId        *uint // 子カテゴリも含めて絞り込む
+	TagId             *uint
+	Title             string     // 名前の部分一致（大文字・小文字を区別しない）
+	ExpiresBefore     *time.Time // この日時より前に期限（開封後の期限を含む）を迎える
+	ExpiresAfter      *time.Time // この日時より後に期限（開封後の期限を含む）を迎える
+	Expired           *bool      // 現在時刻の時点で期限（開封後の期限を含む）切れかどうか
+	MinQuantity       *float64
+	MaxQuantity       *float64
+	InStock           bool // 在庫のある（数量が 0 より多い）食材のみ
+	Sort              FoodItemSort
+	Limit             int             // 0 の場合は全件
+	Cursor            *FoodItemCursor // 前のページの最後の食材の次から取得する
 }
 
 // FoodItemResponse is the response structure for food items
 type FoodItemResponse struct {
-	ID         uint      `json:"id"`
-	Title      string    `json:"title"`
-	Quantity   int       `json:"quantity"`
-	ExpiryDate time.Time `json:"expiry_date"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint       `json:"id"`
+	Title      string     `json:"title"`
+	Quantity   float64    `json:"quantity"`
+	Unit       Unit       `json:"unit"`
+	ExpiryDate time.Time  `json:"expiry_date"`
+	OpenedAt   *time.Time `json:"opened_at"`
+	// OpenedShelfLifeDays は開封後に食べきるまでの日数
+	OpenedShelfLifeDays *int       `json:"opened_shelf_life_days"`
+	State               FoodState  `json:"state"`
+	StateExpiryDate     *time.Time `json:"state_expiry_date"`
+	// Nutrition は栄養成分。登録されていない場合は nil
+	Nutrition *Nutrition `json:"nutrition"`
+	// EffectiveExpiryDate は賞味期限と開封後の期限の早いほう。保存状態を変更した食材は計算し直した期限
+	EffectiveExpiryDate time.Time  `json:"effective_expiry_date"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	Version             uint       `json:"version"`
+	HouseholdId         uint       `json:"household_id"`
+	StorageLocationId   *uint      `json:"storage_location_id"`
+	CategoryId          *uint      `json:"category_id"`
+	Tags                []string   `json:"tags"`
+	Lots                []FoodLot  `json:"lots,omitempty"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+}
+
+// FoodItemGroup is a list of food items sharing the same category.
+// Category is nil for uncategorized items.
+type FoodItemGroup struct {
+	Category *CategoryResponse  `json:"category"`
+	Items    []FoodItemResponse `json:"items"`
 }
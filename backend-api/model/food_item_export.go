@@ -0,0 +1,183 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat は食材一覧の書き出し形式
+type ExportFormat string
+
+const (
+	ExportCSV      ExportFormat = "csv"
+	ExportJSON     ExportFormat = "json"
+	ExportMarkdown ExportFormat = "md"
+)
+
+// IsValid は対応している書き出し形式かどうかを返す
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportCSV, ExportJSON, ExportMarkdown:
+		return true
+	}
+	return false
+}
+
+// ExportDateLayout は書き出す賞味期限の形式。取り込みでもそのまま読める。
+const ExportDateLayout = "2006-01-02"
+
+// ExportTagSeparator は CSV でタグを 1 列にまとめる区切り文字
+const ExportTagSeparator = "、"
+
+// FoodLotExport は書き出すロット 1 件分。価格を入力しなかったロットでは Price と Currency を省略する
+type FoodLotExport struct {
+	Quantity    float64   `json:"quantity"`
+	ExpiryDate  string    `json:"expiry_date"`
+	PurchasedAt time.Time `json:"purchased_at"`
+	Price       *float64  `json:"price,omitempty"`
+	Currency    string    `json:"currency,omitempty"`
+}
+
+// FoodItemExport は書き出す食材 1 件分。
+// CSV の列名と JSON のキーは同じで、title から lots までは取り込みの項目名と一致する。
+type FoodItemExport struct {
+	ID         uint    `json:"id"`
+	Title      string  `json:"title"`
+	Quantity   float64 `json:"quantity"`
+	Unit       Unit    `json:"unit"`
+	ExpiryDate string  `json:"expiry_date"`
+	Category   string  `json:"category"` // カテゴリのコード
+	// CategoryName は表示用のカテゴリ名。Markdown でのみ使う
+	CategoryName string     `json:"-"`
+	Tags         []string   `json:"tags"`
+	Location     string     `json:"location"` // 保管場所の名前
+	OpenedAt     *time.Time `json:"opened_at"`
+	// OpenedShelfLifeDays は開封後に食べきるまでの日数
+	OpenedShelfLifeDays *int       `json:"opened_shelf_life_days"`
+	State               FoodState  `json:"state"`
+	StateExpiryDate     *time.Time `json:"state_expiry_date"`
+	// 栄養成分。登録されていない食材では NutritionBasis が空になる
+	NutritionBasis        NutritionBasis  `json:"nutrition_basis"`
+	NutritionSource       NutritionSource `json:"nutrition_source"`
+	NutritionKcal         float64         `json:"nutrition_kcal"`
+	NutritionProtein      float64         `json:"nutrition_protein"`
+	NutritionFat          float64         `json:"nutrition_fat"`
+	NutritionCarbohydrate float64         `json:"nutrition_carbohydrate"`
+	NutritionSalt         float64         `json:"nutrition_salt"`
+	// Lots は在庫のあるロット。CSV では JSON の配列を 1 列に入れる
+	Lots      []FoodLotExport `json:"lots"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ExportColumns は CSV のヘッダー
+var ExportColumns = []string{
+	"id", "title", "quantity", "unit", "expiry_date", "category", "tags", "location",
+	"opened_at", "opened_shelf_life_days", "state", "state_expiry_date",
+	"nutrition_basis", "nutrition_source", "nutrition_kcal", "nutrition_protein", "nutrition_fat", "nutrition_carbohydrate", "nutrition_salt",
+	"lots", "created_at", "updated_at",
+}
+
+// NewFoodItemExport は食材を書き出し用に変換する。Category・Tags・Lots が読み込まれている必要がある。
+func NewFoodItemExport(foodItem FoodItem, locationName string) FoodItemExport {
+	e := FoodItemExport{
+		ID:                  foodItem.ID,
+		Title:               foodItem.Title,
+		Quantity:            foodItem.Quantity,
+		Unit:                foodItem.Unit,
+		ExpiryDate:          foodItem.ExpiryDate.Format(ExportDateLayout),
+		Tags:                []string{},
+		Location:            locationName,
+		OpenedAt:            foodItem.OpenedAt,
+		OpenedShelfLifeDays: foodItem.OpenedShelfLifeDays,
+		State:               foodItem.State.OrDefault(),
+		StateExpiryDate:     foodItem.StateExpiryDate,
+		Lots:                []FoodLotExport{},
+		CreatedAt:           foodItem.CreatedAt,
+		UpdatedAt:           foodItem.UpdatedAt,
+	}
+	if foodItem.Nutrition.IsSet() {
+		n := foodItem.Nutrition
+		e.NutritionBasis, e.NutritionSource = n.Basis, n.Source
+		e.NutritionKcal, e.NutritionProtein, e.NutritionFat, e.NutritionCarbohydrate, e.NutritionSalt = n.Kcal, n.Protein, n.Fat, n.Carbohydrate, n.Salt
+	}
+	for _, lot := range foodItem.Lots {
+		if lot.Quantity <= 0 {
+			continue
+		}
+		l := FoodLotExport{Quantity: lot.Quantity, ExpiryDate: lot.ExpiryDate.Format(ExportDateLayout), PurchasedAt: lot.PurchasedAt}
+		if lot.Price != nil {
+			l.Price, l.Currency = lot.Price, lot.Currency
+		}
+		e.Lots = append(e.Lots, l)
+	}
+	if foodItem.Category != nil {
+		e.Category = foodItem.Category.Code
+		e.CategoryName = foodItem.Category.Path()
+	}
+	for _, tag := range foodItem.Tags {
+		e.Tags = append(e.Tags, tag.Name)
+	}
+	return e
+}
+
+// Record は ExportColumns の順に並べた CSV の 1 行を返す
+func (e FoodItemExport) Record() []string {
+	return []string{
+		strconv.FormatUint(uint64(e.ID), 10),
+		e.Title,
+		strconv.FormatFloat(e.Quantity, 'f', -1, 64),
+		string(e.Unit),
+		e.ExpiryDate,
+		e.Category,
+		strings.Join(e.Tags, ExportTagSeparator),
+		e.Location,
+		formatExportTime(e.OpenedAt),
+		formatExportInt(e.OpenedShelfLifeDays),
+		string(e.State),
+		formatExportTime(e.StateExpiryDate),
+		string(e.NutritionBasis),
+		string(e.NutritionSource),
+		formatExportNutrition(e.NutritionBasis, e.NutritionKcal),
+		formatExportNutrition(e.NutritionBasis, e.NutritionProtein),
+		formatExportNutrition(e.NutritionBasis, e.NutritionFat),
+		formatExportNutrition(e.NutritionBasis, e.NutritionCarbohydrate),
+		formatExportNutrition(e.NutritionBasis, e.NutritionSalt),
+		formatExportLots(e.Lots),
+		e.CreatedAt.Format(time.RFC3339),
+		e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatExportInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// formatExportNutrition は栄養成分のない食材では空にする
+func formatExportNutrition(basis NutritionBasis, v float64) string {
+	if basis == "" {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatExportLots はロットを JSON の配列にする。ロットがない場合は空にする
+func formatExportLots(lots []FoodLotExport) string {
+	if len(lots) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(lots)
+	return string(b)
+}
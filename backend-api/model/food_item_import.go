@@ -0,0 +1,135 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// ImportField は CSV の列に対応付ける食材の項目
+type ImportField string
+
+const (
+	ImportFieldTitle      ImportField = "title"
+	ImportFieldQuantity   ImportField = "quantity"
+	ImportFieldUnit       ImportField = "unit"
+	ImportFieldExpiryDate ImportField = "expiry_date"
+	ImportFieldCategory   ImportField = "category" // カテゴリのコードまたは名前
+	ImportFieldTags       ImportField = "tags"     // 「、」「;」「|」区切りのタグ名
+	ImportFieldLocation   ImportField = "location" // 保管場所の名前
+	// 以下は書き出した食材を元に戻すための項目
+	ImportFieldOpenedAt              ImportField = "opened_at"
+	ImportFieldOpenedShelfLifeDays   ImportField = "opened_shelf_life_days"
+	ImportFieldState                 ImportField = "state"
+	ImportFieldStateExpiryDate       ImportField = "state_expiry_date"
+	ImportFieldNutritionBasis        ImportField = "nutrition_basis"
+	ImportFieldNutritionSource       ImportField = "nutrition_source"
+	ImportFieldNutritionKcal         ImportField = "nutrition_kcal"
+	ImportFieldNutritionProtein      ImportField = "nutrition_protein"
+	ImportFieldNutritionFat          ImportField = "nutrition_fat"
+	ImportFieldNutritionCarbohydrate ImportField = "nutrition_carbohydrate"
+	ImportFieldNutritionSalt         ImportField = "nutrition_salt"
+	ImportFieldLots                  ImportField = "lots" // 書き出した形式の JSON の配列
+)
+
+// ImportNutritionFields は栄養成分の値の項目。kcal・たんぱく質・脂質・炭水化物・食塩相当量の順に並べる
+var ImportNutritionFields = []ImportField{
+	ImportFieldNutritionKcal, ImportFieldNutritionProtein, ImportFieldNutritionFat, ImportFieldNutritionCarbohydrate, ImportFieldNutritionSalt,
+}
+
+// RequiredImportFields は CSV に必ず含まれていなければならない項目
+var RequiredImportFields = []ImportField{ImportFieldTitle, ImportFieldQuantity, ImportFieldExpiryDate}
+
+// importHeaderAliases はヘッダー名から項目への既定の対応。
+// 表計算ソフトで作った一覧をそのまま取り込めるよう、日本語の見出しも受け付ける。
+var importHeaderAliases = map[string]ImportField{
+	"title":            ImportFieldTitle,
+	"name":             ImportFieldTitle,
+	"名前":               ImportFieldTitle,
+	"食材":               ImportFieldTitle,
+	"食材名":              ImportFieldTitle,
+	"品名":               ImportFieldTitle,
+	"quantity":         ImportFieldQuantity,
+	"数量":               ImportFieldQuantity,
+	"個数":               ImportFieldQuantity,
+	"unit":             ImportFieldUnit,
+	"単位":               ImportFieldUnit,
+	"expiry_date":      ImportFieldExpiryDate,
+	"expiry":           ImportFieldExpiryDate,
+	"賞味期限":             ImportFieldExpiryDate,
+	"消費期限":             ImportFieldExpiryDate,
+	"期限":               ImportFieldExpiryDate,
+	"category":         ImportFieldCategory,
+	"カテゴリ":             ImportFieldCategory,
+	"カテゴリー":            ImportFieldCategory,
+	"分類":               ImportFieldCategory,
+	"tags":             ImportFieldTags,
+	"tag":              ImportFieldTags,
+	"タグ":               ImportFieldTags,
+	"location":         ImportFieldLocation,
+	"storage_location": ImportFieldLocation,
+	"保管場所":             ImportFieldLocation,
+	"場所":               ImportFieldLocation,
+	"開封日時":             ImportFieldOpenedAt,
+	"保存状態":             ImportFieldState,
+}
+
+// IsValid は取り込みに対応した項目かどうかを返す
+func (f ImportField) IsValid() bool {
+	switch f {
+	case ImportFieldTitle, ImportFieldQuantity, ImportFieldUnit, ImportFieldExpiryDate,
+		ImportFieldCategory, ImportFieldTags, ImportFieldLocation,
+		ImportFieldOpenedAt, ImportFieldOpenedShelfLifeDays, ImportFieldState, ImportFieldStateExpiryDate,
+		ImportFieldNutritionBasis, ImportFieldNutritionSource, ImportFieldLots:
+		return true
+	}
+	return slices.Contains(ImportNutritionFields, f)
+}
+
+// ImportFieldForHeader は既定の対応からヘッダー名に対応する項目を返す。
+// 項目名そのもののヘッダー（書き出した CSV の列名）も受け付ける。
+func ImportFieldForHeader(header string) (ImportField, bool) {
+	name := strings.ToLower(strings.TrimSpace(header))
+	if f, ok := importHeaderAliases[name]; ok {
+		return f, true
+	}
+	if f := ImportField(name); f.IsValid() {
+		return f, true
+	}
+	return "", false
+}
+
+// ImportEncoding は CSV の文字コード
+type ImportEncoding string
+
+const (
+	EncodingAuto     ImportEncoding = "" // UTF-8 として読めなければ Shift_JIS とみなす
+	EncodingUTF8     ImportEncoding = "utf-8"
+	EncodingShiftJIS ImportEncoding = "shift_jis" // Excel で保存した日本語の CSV
+)
+
+// ImportOptions は CSV 取り込みの設定
+type ImportOptions struct {
+	DryRun bool
+	// Format は csv（既定）または json。JSON は書き出しと同じ FoodItemExport の配列
+	Format   ExportFormat
+	Encoding ImportEncoding
+	// Mapping はヘッダー名から項目への対応。既定の対応より優先する
+	Mapping map[string]ImportField
+}
+
+// ImportRowError は取り込めなかった行と理由。Row はヘッダーを 1 行目とした行番号
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResult は CSV 取り込みの結果。
+// エラーが 1 件でもあれば何も登録しない。DryRun の場合は登録せずに登録予定の食材を返す。
+type ImportResult struct {
+	DryRun   bool               `json:"dry_run"`
+	Total    int                `json:"total"`
+	Imported int                `json:"imported"`
+	Items    []FoodItemResponse `json:"items"`
+	Errors   []ImportRowError   `json:"errors"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "encoding/json"
+
+// FoodItemEditableFields は PUT と PATCH で変更できる食材の項目（JSON のキー）。
+// 数量・単位・賞味期限はロット、保管場所は移動の操作で変更する。
+var FoodItemEditableFields = []string{"title", "category_id", "tags"}
+
+// FoodItemStockFields はロットで管理する食材の項目（JSON のキー）。
+// PUT と PATCH では変更できないため、指定された場合は無視せずに誤りにする。
+var FoodItemStockFields = []string{"quantity", "unit", "expiry_date"}
+
+// PatchField は JSON Merge Patch の 1 項目。キーが含まれていた場合のみ Set が true になる。
+// null が指定された場合は Set が true で Value がゼロ値になる。
+type PatchField[T any] struct {
+	Set   bool
+	Value T
+}
+
+// UnmarshalJSON はキーが存在したことを記録して値を読み込む
+func (f *PatchField[T]) UnmarshalJSON(b []byte) error {
+	f.Set = true
+	return json.Unmarshal(b, &f.Value)
+}
+
+// FoodItemPatch は PATCH で受け取る食材の変更内容
+type FoodItemPatch struct {
+	Title      PatchField[string]   `json:"title"`
+	CategoryId PatchField[*uint]    `json:"category_id"` // null で未分類にする
+	Tags       PatchField[[]string] `json:"tags"`        // null ですべてのタグを外す
+}
+
+// ApplyTo は指定された項目だけを foodItem に反映する。
+// タグが指定されていない場合は TagNames を nil にし、タグを変更しない。
+func (p FoodItemPatch) ApplyTo(foodItem *FoodItem) {
+	if p.Title.Set {
+		foodItem.Title = p.Title.Value
+	}
+	if p.CategoryId.Set {
+		foodItem.CategoryId = p.CategoryId.Value
+	}
+	foodItem.TagNames = nil
+	if p.Tags.Set {
+		foodItem.TagNames = p.Tags.Value
+		if foodItem.TagNames == nil {
+			foodItem.TagNames = []string{}
+		}
+	}
+}
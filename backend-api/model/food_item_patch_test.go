@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoodItemPatch_ApplyTo(t *testing.T) {
+	categoryId := uint(2)
+	current := FoodItem{Title: "牛乳", CategoryId: &categoryId, UserId: 1}
+
+	tests := []struct {
+		name     string
+		patch    string
+		title    string
+		category *uint
+		tags     []string
+	}{
+		{name: "指定した項目だけを変更", patch: `{"title":"低脂肪乳"}`, title: "低脂肪乳", category: &categoryId, tags: nil},
+		{name: "null でカテゴリを外す", patch: `{"category_id":null}`, title: "牛乳", category: nil, tags: nil},
+		{name: "null ですべてのタグを外す", patch: `{"tags":null}`, title: "牛乳", category: &categoryId, tags: []string{}},
+		{name: "タグを置き換える", patch: `{"tags":["朝食"]}`, title: "牛乳", category: &categoryId, tags: []string{"朝食"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := FoodItemPatch{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.patch), &patch))
+
+			foodItem := current
+			patch.ApplyTo(&foodItem)
+
+			assert.Equal(t, tt.title, foodItem.Title)
+			assert.Equal(t, tt.category, foodItem.CategoryId)
+			assert.Equal(t, tt.tags, foodItem.TagNames)
+			assert.Equal(t, uint(1), foodItem.UserId)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// FoodItemSortField は食材一覧の並び替えの項目
+type FoodItemSortField string
+
+const (
+	SortByExpiryDate FoodItemSortField = "expiry_date"
+	SortByTitle      FoodItemSortField = "title"
+	SortByQuantity   FoodItemSortField = "quantity"
+	SortByCreatedAt  FoodItemSortField = "created_at"
+)
+
+// IsValid は並び替えに使える項目かどうかを返す
+func (f FoodItemSortField) IsValid() bool {
+	switch f {
+	case SortByExpiryDate, SortByTitle, SortByQuantity, SortByCreatedAt:
+		return true
+	}
+	return false
+}
+
+// FoodItemSort は食材一覧の並び順。ゼロ値は賞味期限（開封後の期限を含む）の昇順。
+// 同じ値の食材は ID 順に並べ、ページをまたいでも順序が変わらないようにする。
+type FoodItemSort struct {
+	Field FoodItemSortField
+	Desc  bool
+}
+
+// OrDefault は Field が未指定の場合に賞味期限順を返す
+func (s FoodItemSort) OrDefault() FoodItemSort {
+	if s.Field == "" {
+		s.Field = SortByExpiryDate
+	}
+	return s
+}
+
+// ErrInvalidCursor はカーソルが壊れているか、並び順と一致しない場合のエラー
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// FoodItemCursor はカーソル方式のページングの位置。
+// 前のページの最後の食材の並び替え項目の値と ID を保持する。
+type FoodItemCursor struct {
+	Field FoodItemSortField `json:"f"`
+	Desc  bool              `json:"d"`
+	Value string            `json:"v"`
+	ID    uint              `json:"id"`
+}
+
+// NewFoodItemCursor は foodItem の次から取得するためのカーソルを作成する
+func NewFoodItemCursor(foodItem FoodItem, sort FoodItemSort) FoodItemCursor {
+	sort = sort.OrDefault()
+	c := FoodItemCursor{Field: sort.Field, Desc: sort.Desc, ID: foodItem.ID}
+	switch sort.Field {
+	case SortByExpiryDate:
+		c.Value = foodItem.EffectiveExpiryDate().Format(time.RFC3339Nano)
+	case SortByTitle:
+		c.Value = foodItem.Title
+	case SortByQuantity:
+		c.Value = strconv.FormatFloat(foodItem.Quantity, 'f', -1, 64)
+	case SortByCreatedAt:
+		c.Value = foodItem.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return c
+}
+
+// Encode はカーソルを URL に含められる文字列にする
+func (c FoodItemCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// SortValue はカーソルの値を並び替え項目の型に変換する
+func (c FoodItemCursor) SortValue() (interface{}, error) {
+	switch c.Field {
+	case SortByExpiryDate, SortByCreatedAt:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return t, nil
+	case SortByQuantity:
+		v, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return v, nil
+	case SortByTitle:
+		return c.Value, nil
+	}
+	return nil, ErrInvalidCursor
+}
+
+// DecodeFoodItemCursor は Encode した文字列をカーソルに戻す。
+// 並び順 sort と一致しないカーソルは ErrInvalidCursor になる。
+func DecodeFoodItemCursor(s string, sort FoodItemSort) (FoodItemCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return FoodItemCursor{}, ErrInvalidCursor
+	}
+	c := FoodItemCursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return FoodItemCursor{}, ErrInvalidCursor
+	}
+	sort = sort.OrDefault()
+	if c.Field != sort.Field || c.Desc != sort.Desc {
+		return FoodItemCursor{}, ErrInvalidCursor
+	}
+	if _, err := c.SortValue(); err != nil {
+		return FoodItemCursor{}, err
+	}
+	return c, nil
+}
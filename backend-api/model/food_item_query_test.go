@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoodItemCursor_RoundTrip(t *testing.T) {
+	expiry := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	item := FoodItem{ID: 7, Title: "牛乳", Quantity: 1.5, ExpiryDate: expiry}
+
+	tests := []struct {
+		name  string
+		sort  FoodItemSort
+		value interface{}
+	}{
+		{name: "既定は賞味期限順", sort: FoodItemSort{}, value: expiry},
+		{name: "名前の降順", sort: FoodItemSort{Field: SortByTitle, Desc: true}, value: "牛乳"},
+		{name: "数量順", sort: FoodItemSort{Field: SortByQuantity}, value: 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := NewFoodItemCursor(item, tt.sort).Encode()
+
+			cursor, err := DecodeFoodItemCursor(encoded, tt.sort)
+			assert.NoError(t, err)
+			assert.Equal(t, uint(7), cursor.ID)
+			value, err := cursor.SortValue()
+			assert.NoError(t, err)
+			if v, ok := value.(time.Time); ok {
+				assert.True(t, v.Equal(expiry))
+			} else {
+				assert.Equal(t, tt.value, value)
+			}
+		})
+	}
+}
+
+func TestDecodeFoodItemCursor_Invalid(t *testing.T) {
+	encoded := NewFoodItemCursor(FoodItem{ID: 1, Title: "卵"}, FoodItemSort{Field: SortByTitle}).Encode()
+
+	// 並び順が異なるカーソルは使えない
+	_, err := DecodeFoodItemCursor(encoded, FoodItemSort{Field: SortByTitle, Desc: true})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = DecodeFoodItemCursor(encoded, FoodItemSort{})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = DecodeFoodItemCursor("not-a-cursor", FoodItemSort{})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoodItem_EffectiveExpiryDate(t *testing.T) {
+	expiry := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	openedAt := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+	stateExpiry := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		foodItem FoodItem
+		want     time.Time
+	}{
+		{name: "未開封は賞味期限", foodItem: FoodItem{ExpiryDate: expiry, OpenedShelfLifeDays: days(7)}, want: expiry},
+		{name: "開封後の期限が早い", foodItem: FoodItem{ExpiryDate: expiry, OpenedAt: &openedAt, OpenedShelfLifeDays: days(7)}, want: openedAt.AddDate(0, 0, 7)},
+		{name: "賞味期限が早い", foodItem: FoodItem{ExpiryDate: expiry, OpenedAt: &openedAt, OpenedShelfLifeDays: days(365)}, want: expiry},
+		{name: "開封後の日数がない", foodItem: FoodItem{ExpiryDate: expiry, OpenedAt: &openedAt}, want: expiry},
+		{name: "保存状態の期限を優先", foodItem: FoodItem{ExpiryDate: expiry, OpenedAt: &openedAt, OpenedShelfLifeDays: days(7), State: StateFrozen, StateExpiryDate: &stateExpiry}, want: stateExpiry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.foodItem.EffectiveExpiryDate())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInsufficientStock は消費量が在庫の合計を超えた場合のエラー
+var ErrInsufficientStock = errors.New("在庫が不足しています")
+
+// FoodLot は食材の購入ごとのロット。
+// 数量は食材（FoodItem）の単位で保持し、食材の数量と賞味期限はロットから集計する。
+type FoodLot struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	FoodItemId  uint      `json:"food_item_id" gorm:"not null;index"`
+	FoodItem    FoodItem  `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:CASCADE"`
+	Quantity    float64   `json:"quantity" gorm:"type:numeric(12,3);not null"`
+	ExpiryDate  time.Time `json:"expiry_date" gorm:"not null"`
+	PurchasedAt time.Time `json:"purchased_at" gorm:"not null"`
+	// Price は購入時に支払った金額、UnitPrice は購入時の数量 1 あたりの金額。価格を入力しなかったロットでは nil
+	Price     *float64  `json:"price" gorm:"type:numeric(12,2)"`
+	UnitPrice *float64  `json:"unit_price" gorm:"type:numeric(14,4)"`
+	Currency  string    `json:"currency" gorm:"type:varchar(3);not null;default:''"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// PurchaseRecorded は購入をすでに記録したロット（書き出したロットを取り込む場合など）で true。
+	// 作成時に購入を重ねて記録しない
+	PurchaseRecorded bool `json:"-" gorm:"-"`
+}
+
+// SetPrice は購入時の金額を設定し、数量 1 あたりの金額を求める。数量が 0 の場合は単価を設定しない
+func (l *FoodLot) SetPrice(price Money) {
+	amount := roundMoney(price.Amount)
+	l.Price, l.UnitPrice, l.Currency = &amount, nil, price.Currency
+	if l.Quantity > 0 {
+		unitPrice := math.Round(amount/l.Quantity*10000) / 10000
+		l.UnitPrice = &unitPrice
+	}
+}
+
+// WithdrawnCost は lots の先頭から amount を差し引いた分の金額を、ロットの単価から求める。
+// ConsumeFIFO の前に呼ぶこと。差し引く分に単価のないロットが含まれる場合や、通貨が混在する場合は false を返す。
+func WithdrawnCost(lots []FoodLot, amount float64) (Money, bool) {
+	cost := Money{}
+	remaining := amount
+	for _, lot := range lots {
+		if remaining <= 0 {
+			break
+		}
+		used := math.Min(lot.Quantity, remaining)
+		if used <= 0 {
+			continue
+		}
+		if lot.UnitPrice == nil || (cost.Currency != "" && cost.Currency != lot.Currency) {
+			return Money{}, false
+		}
+		cost.Amount += used * *lot.UnitPrice
+		cost.Currency = lot.Currency
+		remaining = roundQuantity(remaining - used)
+	}
+	if cost.Currency == "" {
+		return Money{}, false
+	}
+	cost.Amount = roundMoney(cost.Amount)
+	return cost, true
+}
+
+// ConsumeFIFO は lots の先頭（賞味期限の早いロット）から順に amount を差し引く。
+// lots は賞味期限の昇順に並んでいること。在庫が足りない場合は lots を変更せずに
+// ErrInsufficientStock を返す。数量が 0 になったロットはそのまま残るので、呼び出し側で削除する。
+func ConsumeFIFO(lots []FoodLot, amount float64) error {
+	total := 0.0
+	for _, lot := range lots {
+		total += lot.Quantity
+	}
+	if roundQuantity(total-amount) < 0 {
+		return ErrInsufficientStock
+	}
+	remaining := amount
+	for i := range lots {
+		if remaining <= 0 {
+			break
+		}
+		used := math.Min(lots[i].Quantity, remaining)
+		lots[i].Quantity = roundQuantity(lots[i].Quantity - used)
+		remaining = roundQuantity(remaining - used)
+	}
+	return nil
+}
+
+// SummarizeLots は在庫のあるロットの数量の合計と、最も早い賞味期限を返す。
+// 在庫のあるロットがない場合、ok は false になる。
+func SummarizeLots(lots []FoodLot) (total float64, earliest time.Time, ok bool) {
+	for _, lot := range lots {
+		if lot.Quantity <= 0 {
+			continue
+		}
+		total += lot.Quantity
+		if !ok || lot.ExpiryDate.Before(earliest) {
+			earliest = lot.ExpiryDate
+			ok = true
+		}
+	}
+	return roundQuantity(total), earliest, ok
+}
+
+// roundQuantity は DB の numeric(12,3) に合わせて小数第3位に丸める
+func roundQuantity(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumeFIFO(t *testing.T) {
+	soon := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 7)
+
+	t.Run("期限の早いロットから消費する", func(t *testing.T) {
+		lots := []FoodLot{
+			{ID: 1, Quantity: 0.5, ExpiryDate: soon},
+			{ID: 2, Quantity: 1, ExpiryDate: later},
+		}
+
+		err := ConsumeFIFO(lots, 0.7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, lots[0].Quantity)
+		assert.Equal(t, 0.8, lots[1].Quantity)
+	})
+
+	t.Run("在庫不足の場合は変更しない", func(t *testing.T) {
+		lots := []FoodLot{
+			{ID: 1, Quantity: 0.5, ExpiryDate: soon},
+			{ID: 2, Quantity: 1, ExpiryDate: later},
+		}
+
+		err := ConsumeFIFO(lots, 2)
+
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+		assert.Equal(t, 0.5, lots[0].Quantity)
+		assert.Equal(t, 1.0, lots[1].Quantity)
+	})
+}
+
+func TestSummarizeLots(t *testing.T) {
+	soon := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 0, 7)
+
+	total, earliest, ok := SummarizeLots([]FoodLot{
+		{Quantity: 0, ExpiryDate: soon.AddDate(0, 0, -1)}, // 使い切ったロットは期限の計算に含めない
+		{Quantity: 1.2, ExpiryDate: later},
+		{Quantity: 0.3, ExpiryDate: soon},
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, total)
+	assert.Equal(t, soon, earliest)
+
+	_, _, ok = SummarizeLots(nil)
+	assert.False(t, ok)
+}
+
+func TestWithdrawnCost(t *testing.T) {
+	price := func(v float64) *float64 { return &v }
+
+	t.Run("期限の早いロットの単価から求める", func(t *testing.T) {
+		lots := []FoodLot{
+			{ID: 1, Quantity: 0.5, UnitPrice: price(200), Currency: "JPY"},
+			{ID: 2, Quantity: 1, UnitPrice: price(240), Currency: "JPY"},
+		}
+
+		cost, ok := WithdrawnCost(lots, 0.7)
+
+		assert.True(t, ok)
+		assert.Equal(t, Money{Amount: 148, Currency: "JPY"}, cost)
+	})
+
+	t.Run("単価のないロットを含む場合は求めない", func(t *testing.T) {
+		lots := []FoodLot{
+			{ID: 1, Quantity: 0.5, UnitPrice: price(200), Currency: "JPY"},
+			{ID: 2, Quantity: 1},
+		}
+
+		_, ok := WithdrawnCost(lots, 0.7)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("通貨が混在する場合は求めない", func(t *testing.T) {
+		lots := []FoodLot{
+			{ID: 1, Quantity: 0.5, UnitPrice: price(200), Currency: "JPY"},
+			{ID: 2, Quantity: 1, UnitPrice: price(2), Currency: "USD"},
+		}
+
+		_, ok := WithdrawnCost(lots, 0.7)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestFoodLot_SetPrice(t *testing.T) {
+	lot := FoodLot{Quantity: 3}
+
+	lot.SetPrice(Money{Amount: 100, Currency: "JPY"})
+
+	assert.Equal(t, 100.0, *lot.Price)
+	assert.Equal(t, 33.3333, *lot.UnitPrice)
+	assert.Equal(t, "JPY", lot.Currency)
+}
+
+func TestParseCurrency(t *testing.T) {
+	currency, err := ParseCurrency(" usd ")
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", currency)
+
+	currency, err = ParseCurrency("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCurrency, currency)
+
+	_, err = ParseCurrency("円")
+	assert.Error(t, err)
+}
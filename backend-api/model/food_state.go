@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// FoodState は食材の保存状態を表す
+type FoodState string
+
+const (
+	StateFresh  FoodState = "fresh"  // 生（購入したまま）。期限は賞味期限と開封後の期限
+	StateFrozen FoodState = "frozen" // 冷凍
+	StateThawed FoodState = "thawed" // 解凍済み
+	StateCooked FoodState = "cooked" // 調理済み
+)
+
+// foodStateTransitions は状態ごとに変更できる次の状態。
+// 解凍した食材は品質と衛生のため再冷凍できず、調理してから冷凍する。
+var foodStateTransitions = map[FoodState][]FoodState{
+	StateFresh:  {StateFrozen, StateCooked},
+	StateFrozen: {StateThawed},
+	StateThawed: {StateCooked},
+	StateCooked: {StateFrozen},
+}
+
+// DefaultStateShelfLifeDays は状態を変更した後の日持ちの既定の日数。カテゴリに日数がない場合に使う
+var DefaultStateShelfLifeDays = map[FoodState]int{
+	StateFrozen: 30,
+	StateThawed: 1,
+	StateCooked: 3,
+}
+
+// ErrInvalidStateTransition は現在の保存状態から変更できない状態を指定した場合に返す
+var ErrInvalidStateTransition = errors.New("invalid food state transition")
+
+// IsValid は定義済みの状態かどうかを返す
+func (s FoodState) IsValid() bool {
+	_, ok := foodStateTransitions[s]
+	return ok
+}
+
+// OrDefault は状態が未設定の場合に生を返す
+func (s FoodState) OrDefault() FoodState {
+	if s == "" {
+		return StateFresh
+	}
+	return s
+}
+
+// CanTransitionTo は to の状態に変更できるかどうかを返す
+func (s FoodState) CanTransitionTo(to FoodState) bool {
+	for _, next := range foodStateTransitions[s.OrDefault()] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AfterMove は保管場所を from から to へ移動した後の状態を返す。状態が変わらない場合は false を返す。
+// 冷凍庫に入れた食材は冷凍、冷凍庫から出した冷凍の食材は解凍にする。
+// 解凍済みの食材は再冷凍できないため、冷凍庫に入れても状態を変えない。
+func (s FoodState) AfterMove(from, to LocationType) (FoodState, bool) {
+	switch {
+	case from != LocationFreezer && to == LocationFreezer && s.CanTransitionTo(StateFrozen):
+		return StateFrozen, true
+	case from == LocationFreezer && to != LocationFreezer && s.OrDefault() == StateFrozen:
+		return StateThawed, true
+	}
+	return s, false
+}
+
+// Label は通知やレシピ提案で使う状態の表示名を返す
+func (s FoodState) Label() string {
+	switch s.OrDefault() {
+	case StateFrozen:
+		return "冷凍"
+	case StateThawed:
+		return "解凍済み"
+	case StateCooked:
+		return "調理済み"
+	}
+	return "生"
+}
+
+// FoodStateTransition は食材の保存状態の変更履歴。変更前後の期限と適用した日数も記録する。
+type FoodStateTransition struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	FoodItemId     uint      `json:"food_item_id" gorm:"not null;index"`
+	FoodItem       FoodItem  `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:CASCADE"`
+	FromState      FoodState `json:"from_state" gorm:"type:varchar(16);not null"`
+	ToState        FoodState `json:"to_state" gorm:"type:varchar(16);not null"`
+	ShelfLifeDays  int       `json:"shelf_life_days" gorm:"not null"`
+	ExpiryBefore   time.Time `json:"expiry_before" gorm:"not null"`
+	ExpiryAfter    time.Time `json:"expiry_after" gorm:"not null"`
+	TransitionedAt time.Time `json:"transitioned_at" gorm:"not null"`
+	UserId         uint      `json:"user_id" gorm:"not null"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoodState_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from FoodState
+		to   FoodState
+		want bool
+	}{
+		{from: StateFresh, to: StateFrozen, want: true},
+		{from: StateFresh, to: StateCooked, want: true},
+		{from: StateFresh, to: StateThawed, want: false},
+		{from: StateFrozen, to: StateThawed, want: true},
+		{from: StateFrozen, to: StateCooked, want: false},
+		{from: StateThawed, to: StateCooked, want: true},
+		{from: StateThawed, to: StateFrozen, want: false},
+		{from: StateCooked, to: StateFrozen, want: true},
+		{from: StateCooked, to: StateFresh, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestFoodState_AfterMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   FoodState
+		from    LocationType
+		to      LocationType
+		want    FoodState
+		changed bool
+	}{
+		{name: "冷蔵庫から冷凍庫へ移すと冷凍", state: StateFresh, from: LocationFridge, to: LocationFreezer, want: StateFrozen, changed: true},
+		{name: "保管場所なしから冷凍庫へ移すと冷凍", state: "", from: "", to: LocationFreezer, want: StateFrozen, changed: true},
+		{name: "調理済みを冷凍庫へ移すと冷凍", state: StateCooked, from: LocationFridge, to: LocationFreezer, want: StateFrozen, changed: true},
+		{name: "解凍済みは冷凍庫へ移しても再冷凍しない", state: StateThawed, from: LocationFridge, to: LocationFreezer, want: StateThawed},
+		{name: "冷凍庫から冷蔵庫へ移すと解凍", state: StateFrozen, from: LocationFreezer, to: LocationFridge, want: StateThawed, changed: true},
+		{name: "冷凍庫から保管場所なしにすると解凍", state: StateFrozen, from: LocationFreezer, to: "", want: StateThawed, changed: true},
+		{name: "冷凍庫どうしの移動は変えない", state: StateFrozen, from: LocationFreezer, to: LocationFreezer, want: StateFrozen},
+		{name: "冷凍庫の生の食材を取り出しても変えない", state: StateFresh, from: LocationFreezer, to: LocationPantry, want: StateFresh},
+		{name: "冷蔵庫から常温へは変えない", state: StateFresh, from: LocationFridge, to: LocationPantry, want: StateFresh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := tt.state.AfterMove(tt.from, tt.to)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.changed, changed)
+		})
+	}
+}
+
+func TestCategory_StateShelfLife(t *testing.T) {
+	days := func(n int) *int { return &n }
+	meat := Category{Name: "肉", FrozenShelfLifeDays: days(90), ThawedShelfLifeDays: days(1)}
+	chicken := Category{Name: "鶏肉", FrozenShelfLifeDays: days(60), Parent: &meat}
+
+	assert.Equal(t, 60, chicken.StateShelfLife(StateFrozen))
+	// 子カテゴリにない日数は親カテゴリから引き継ぐ
+	assert.Equal(t, 1, chicken.StateShelfLife(StateThawed))
+	// どのカテゴリにもない日数は既定値
+	assert.Equal(t, DefaultStateShelfLifeDays[StateCooked], chicken.StateShelfLife(StateCooked))
+}
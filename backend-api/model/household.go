@@ -0,0 +1,145 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// PersonalHouseholdName はユーザー登録時に作成する個人の世帯の名前
+const PersonalHouseholdName = "マイ世帯"
+
+// HouseholdRole は世帯でのメンバーの権限を表す
+type HouseholdRole string
+
+const (
+	RoleOwner  HouseholdRole = "owner"  // 所有者。メンバーの招待・削除・権限の変更ができる。世帯に 1 人
+	RoleEditor HouseholdRole = "editor" // 編集者。世帯のデータを閲覧・編集できる
+	RoleViewer HouseholdRole = "viewer" // 閲覧者。世帯のデータの閲覧とレシピの提案のみ
+)
+
+var roleRanks = map[HouseholdRole]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// IsValid は定義済みのロールかどうかを返す
+func (r HouseholdRole) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows はロールが required 以上の権限を持つかどうかを返す
+func (r HouseholdRole) Allows(required HouseholdRole) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[required]
+}
+
+// ErrHouseholdReadOnly は閲覧者として所属する世帯にデータを作成しようとした場合に返す
+var ErrHouseholdReadOnly = errors.New("household is read-only for the user")
+
+// ErrAlreadyHouseholdMember は所属している世帯の招待を承諾しようとした場合に返す
+var ErrAlreadyHouseholdMember = errors.New("user is already a member of the household")
+
+// HouseholdResource は世帯に属するデータの種類。値はテーブル名
+type HouseholdResource string
+
+const (
+	ResourceHousehold       HouseholdResource = "households"
+	ResourceFoodItem        HouseholdResource = "food_items"
+	ResourceStorageLocation HouseholdResource = "storage_locations"
+	ResourceTask            HouseholdResource = "tasks"
+)
+
+// IsValid は定義済みのデータの種類かどうかを返す
+func (r HouseholdResource) IsValid() bool {
+	switch r {
+	case ResourceHousehold, ResourceFoodItem, ResourceStorageLocation, ResourceTask:
+		return true
+	}
+	return false
+}
+
+// Household は食材・保管場所・タスクを共有する世帯。
+// メンバーはロールに応じて世帯のデータを閲覧・編集できる。
+type Household struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	Name      string            `json:"name" gorm:"not null"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Members   []HouseholdMember `json:"-" gorm:"foreignKey:HouseholdId"`
+}
+
+// HouseholdMember は世帯への所属。ユーザーは複数の世帯に所属できる。
+type HouseholdMember struct {
+	HouseholdId uint          `json:"household_id" gorm:"primaryKey;autoIncrement:false"`
+	Household   Household     `json:"-" gorm:"foreignKey:HouseholdId; constraint:OnDelete:CASCADE"`
+	UserId      uint          `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+	User        User          `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	Role        HouseholdRole `json:"role" gorm:"type:varchar(16);not null"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type HouseholdResponse struct {
+	ID        uint                      `json:"id"`
+	Name      string                    `json:"name"`
+	Members   []HouseholdMemberResponse `json:"members"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+type HouseholdMemberResponse struct {
+	UserId   uint          `json:"user_id"`
+	Email    string        `json:"email"`
+	Role     HouseholdRole `json:"role"`
+	JoinedAt time.Time     `json:"joined_at"`
+}
+
+// HouseholdMemberRole はメンバーの権限の変更のリクエスト
+type HouseholdMemberRole struct {
+	Role HouseholdRole `json:"role"`
+}
+
+// HouseholdTransfer は所有権の移転のリクエスト
+type HouseholdTransfer struct {
+	UserId uint `json:"user_id"`
+}
+
+// InvitationStatus は世帯への招待の状態を表す
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"  // 未回答
+	InvitationAccepted InvitationStatus = "accepted" // 承諾済み
+	InvitationDeclined InvitationStatus = "declined" // 辞退済み
+)
+
+// HouseholdInvitation は世帯への招待。トークンは 1 回だけ使え、ExpiresAt を過ぎると使えない。
+// トークンそのものは保存せず、SHA-256 のハッシュで照合する。
+type HouseholdInvitation struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	HouseholdId uint             `json:"household_id" gorm:"not null;index"`
+	Household   Household        `json:"-" gorm:"foreignKey:HouseholdId; constraint:OnDelete:CASCADE"`
+	TokenHash   string           `json:"-" gorm:"not null;uniqueIndex"`
+	Role        HouseholdRole    `json:"role" gorm:"type:varchar(16);not null"`
+	Status      InvitationStatus `json:"status" gorm:"type:varchar(16);not null"`
+	ExpiresAt   time.Time        `json:"expires_at" gorm:"not null"`
+	InvitedBy   uint             `json:"invited_by" gorm:"not null"`
+	Inviter     User             `json:"-" gorm:"foreignKey:InvitedBy; constraint:OnDelete:CASCADE"`
+	RespondedBy *uint            `json:"responded_by"`
+	Responder   *User            `json:"-" gorm:"foreignKey:RespondedBy; constraint:OnDelete:SET NULL"`
+	RespondedAt *time.Time       `json:"responded_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// HouseholdInvitationRequest は招待の作成のリクエスト。ExpiresInHours が 0 の場合は既定の有効期限にする
+type HouseholdInvitationRequest struct {
+	Role           HouseholdRole `json:"role"`
+	ExpiresInHours int           `json:"expires_in_hours"`
+}
+
+type HouseholdInvitationResponse struct {
+	ID          uint             `json:"id"`
+	HouseholdId uint             `json:"household_id"`
+	Role        HouseholdRole    `json:"role"`
+	Status      InvitationStatus `json:"status"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+	// Token は招待の作成時にのみ返す。招待されたユーザーに渡して承諾・辞退に使う
+	Token string `json:"token,omitempty"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// MovementReason は在庫の増減の理由を表す
+type MovementReason string
+
+const (
+	MovementConsume MovementReason = "consume" // 消費
+	MovementRestock MovementReason = "restock" // 補充（購入）
+	MovementDiscard MovementReason = "discard" // 廃棄
+)
+
+// InventoryMovement は在庫の増減の記録。作成後は変更しない。
+// 食材が削除されても履歴を残すため、食材名を記録し、FoodItemId は NULL にする。
+type InventoryMovement struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	FoodItemId *uint          `json:"food_item_id" gorm:"index"`
+	FoodItem   *FoodItem      `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:SET NULL"`
+	Title      string         `json:"title" gorm:"not null"`
+	Delta      float64        `json:"delta" gorm:"type:numeric(12,3);not null"` // 消費は負の値
+	Unit       Unit           `json:"unit" gorm:"type:varchar(16);not null"`
+	Reason     MovementReason `json:"reason" gorm:"type:varchar(16);not null"`
+	Note       string         `json:"note"`
+	User       User           `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId     uint           `json:"user_id" gorm:"not null;index"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// StockChange は消費・補充の結果。
+// 使い切った食材が設定により削除された場合、Deleted が true になる。
+type StockChange struct {
+	FoodItem FoodItemResponse  `json:"food_item"`
+	Movement InventoryMovement `json:"movement"`
+	Deleted  bool              `json:"deleted"`
+	Waste    *WasteRecord      `json:"waste,omitempty"`
+}
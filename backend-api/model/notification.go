@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// NotificationType は通知の種類を表す
+type NotificationType string
+
+const (
+	NotificationExpiring NotificationType = "expiring" // 賞味期限が近い
+	NotificationExpired  NotificationType = "expired"  // 賞味期限切れ
+)
+
+// Notification はユーザーへの通知。
+// 同じユーザー・食材・種類・賞味期限の通知は一度だけ作成する（ロットの追加で賞味期限が変われば再度通知する）。
+type Notification struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	User       User             `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId     uint             `json:"user_id" gorm:"not null;index;uniqueIndex:idx_notifications_user_alert"`
+	FoodItem   *FoodItem        `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:CASCADE"`
+	FoodItemId *uint            `json:"food_item_id" gorm:"uniqueIndex:idx_notifications_user_alert"`
+	Type       NotificationType `json:"type" gorm:"type:varchar(16);not null;uniqueIndex:idx_notifications_user_alert"`
+	ExpiryDate *time.Time       `json:"expiry_date" gorm:"uniqueIndex:idx_notifications_user_alert"`
+	Message    string           `json:"message" gorm:"not null"`
+	ReadAt     *time.Time       `json:"read_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ExpiryAlertTarget は期限の通知の対象となる食材と、通知を受け取る世帯のメンバー
+type ExpiryAlertTarget struct {
+	FoodItem FoodItem
+	UserId   uint
+}
+
+// NewExpiryNotification は食材の賞味期限の通知を userId のユーザーに作成する。
+// 期限は開封後の期限と保存状態を考慮した EffectiveExpiryDate を使い、賞味期限でない場合はそう分かる文言にする。
+// now の時点で期限を過ぎていれば期限切れ、そうでなければ期限間近の通知になる。
+func NewExpiryNotification(foodItem FoodItem, userId uint, now time.Time) Notification {
+	expiry := foodItem.EffectiveExpiryDate()
+	n := Notification{
+		UserId:     userId,
+		FoodItemId: &foodItem.ID,
+		Type:       NotificationExpiring,
+		ExpiryDate: &expiry,
+	}
+	label := "賞味期限"
+	if foodItem.StateExpiryDate != nil {
+		label = foodItem.State.Label() + "の期限"
+	} else if !expiry.Equal(foodItem.ExpiryDate) {
+		label = "開封後の期限"
+	}
+	date := expiry.In(time.Local).Format("2006/01/02")
+	if expiry.Before(now) {
+		n.Type = NotificationExpired
+		n.Message = foodItem.Title + "の" + label + "が切れています（" + date + "）"
+	} else {
+		n.Message = foodItem.Title + "の" + label + "が近づいています（" + date + "）"
+	}
+	return n
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExpiryNotification_Opened(t *testing.T) {
+	now := time.Now()
+	openedAt := now.AddDate(0, 0, -5)
+	days := 3
+	foodItem := FoodItem{ID: 1, Title: "牛乳", ExpiryDate: now.AddDate(0, 0, 10), OpenedAt: &openedAt, OpenedShelfLifeDays: &days}
+
+	n := NewExpiryNotification(foodItem, 2, now)
+
+	assert.Equal(t, uint(2), n.UserId)
+	assert.Equal(t, NotificationExpired, n.Type)
+	assert.Equal(t, openedAt.AddDate(0, 0, 3), *n.ExpiryDate)
+	assert.Contains(t, n.Message, "牛乳の開封後の期限が切れています")
+}
@@ -0,0 +1,174 @@
+package model
+
+import "math"
+
+// NutritionBasis は栄養成分の値の基準量
+type NutritionBasis string
+
+const (
+	NutritionPer100g NutritionBasis = "100g" // 100 g あたり（ml の食材は 1 ml = 1 g とみなす）
+	NutritionPerUnit NutritionBasis = "unit" // 食材の単位 1 つ（1 個・1 パックなど）あたり
+)
+
+// IsValid は定義済みの基準量かどうかを返す
+func (b NutritionBasis) IsValid() bool {
+	return b == NutritionPer100g || b == NutritionPerUnit
+}
+
+// NutritionSource は栄養成分の出どころ
+type NutritionSource string
+
+const (
+	NutritionSourceReference NutritionSource = "reference" // 同梱の成分表（NutritionReferences）から補った値
+	NutritionSourceUser      NutritionSource = "user"      // ユーザーが入力した値
+)
+
+// NutritionValues は栄養成分の量
+type NutritionValues struct {
+	Kcal         float64 `json:"kcal" gorm:"type:numeric(10,2);not null;default:0"`         // エネルギー（kcal）
+	Protein      float64 `json:"protein" gorm:"type:numeric(10,2);not null;default:0"`      // たんぱく質（g）
+	Fat          float64 `json:"fat" gorm:"type:numeric(10,2);not null;default:0"`          // 脂質（g）
+	Carbohydrate float64 `json:"carbohydrate" gorm:"type:numeric(10,2);not null;default:0"` // 炭水化物（g）
+	Salt         float64 `json:"salt" gorm:"type:numeric(10,2);not null;default:0"`         // 食塩相当量（g）
+}
+
+// IsZero はすべての成分が 0 かどうかを返す
+func (v NutritionValues) IsZero() bool {
+	return v == NutritionValues{}
+}
+
+// Scale は各成分を factor 倍し、小数第2位に丸めた値を返す
+func (v NutritionValues) Scale(factor float64) NutritionValues {
+	return NutritionValues{
+		Kcal:         roundNutrition(v.Kcal * factor),
+		Protein:      roundNutrition(v.Protein * factor),
+		Fat:          roundNutrition(v.Fat * factor),
+		Carbohydrate: roundNutrition(v.Carbohydrate * factor),
+		Salt:         roundNutrition(v.Salt * factor),
+	}
+}
+
+// Add は o を加えた値を返す
+func (v NutritionValues) Add(o NutritionValues) NutritionValues {
+	return NutritionValues{
+		Kcal:         roundNutrition(v.Kcal + o.Kcal),
+		Protein:      roundNutrition(v.Protein + o.Protein),
+		Fat:          roundNutrition(v.Fat + o.Fat),
+		Carbohydrate: roundNutrition(v.Carbohydrate + o.Carbohydrate),
+		Salt:         roundNutrition(v.Salt + o.Salt),
+	}
+}
+
+// roundNutrition は DB の numeric(10,2) に合わせて小数第2位に丸める
+func roundNutrition(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Nutrition は食材の栄養成分。Basis が空の場合は栄養成分が登録されていない
+type Nutrition struct {
+	Basis           NutritionBasis  `json:"basis" gorm:"type:varchar(8);not null;default:''"`
+	Source          NutritionSource `json:"source" gorm:"type:varchar(16);not null;default:''"`
+	NutritionValues `gorm:"embedded"`
+}
+
+// IsSet は栄養成分が登録されているかどうかを返す
+func (n Nutrition) IsSet() bool {
+	return n.Basis != ""
+}
+
+// For は数量 q に含まれる栄養成分を返す。100 g あたりの値で数量を重さに換算できない場合
+// （個数などの単位）は false を返す。
+func (n Nutrition) For(q Quantity) (NutritionValues, bool) {
+	switch n.Basis {
+	case NutritionPerUnit:
+		return n.Scale(q.Amount), true
+	case NutritionPer100g:
+		for _, base := range []Unit{UnitGram, UnitMilliliter} {
+			if grams, err := q.ConvertTo(base); err == nil {
+				return n.Scale(grams.Amount / 100), true
+			}
+		}
+	}
+	return NutritionValues{}, false
+}
+
+// NutritionReference は食材の 100 g あたりの栄養成分の目安。
+// UnitWeight は数えられる単位（個・パックなど）1 つあたりの可食部の重さ（g）で、分からない場合は 0。
+type NutritionReference struct {
+	Per100g    NutritionValues
+	UnitWeight float64
+}
+
+// NutritionReferences は日持ちの目安（DefaultShelfLives）と同じ食材の識別子ごとの栄養成分の目安。
+// 日本食品標準成分表（八訂）の代表的な食品の値を基にした概数。
+var NutritionReferences = map[string]NutritionReference{
+	"chicken":      {Per100g: NutritionValues{Kcal: 190, Protein: 16.6, Fat: 14.2, Carbohydrate: 0, Salt: 0.2}},                   // 若どり もも 皮つき 生
+	"pork":         {Per100g: NutritionValues{Kcal: 248, Protein: 19.3, Fat: 19.2, Carbohydrate: 0.2, Salt: 0.1}},                 // ぶた ロース 脂身つき 生
+	"beef":         {Per100g: NutritionValues{Kcal: 196, Protein: 19.5, Fat: 13.3, Carbohydrate: 0.4, Salt: 0.1}},                 // 乳用肥育牛肉 もも 脂身つき 生
+	"minced_meat":  {Per100g: NutritionValues{Kcal: 209, Protein: 17.7, Fat: 17.2, Carbohydrate: 0.1, Salt: 0.1}},                 // ぶた ひき肉 生
+	"fish":         {Per100g: NutritionValues{Kcal: 124, Protein: 22.3, Fat: 4.1, Carbohydrate: 0.1, Salt: 0.2}},                  // しろさけ 生
+	"egg":          {Per100g: NutritionValues{Kcal: 142, Protein: 12.2, Fat: 10.2, Carbohydrate: 0.4, Salt: 0.4}, UnitWeight: 50}, // 鶏卵 全卵 生
+	"milk":         {Per100g: NutritionValues{Kcal: 61, Protein: 3.3, Fat: 3.8, Carbohydrate: 4.8, Salt: 0.1}, UnitWeight: 1000},  // 普通牛乳（1 本 1 L）
+	"yogurt":       {Per100g: NutritionValues{Kcal: 56, Protein: 3.6, Fat: 3.0, Carbohydrate: 4.9, Salt: 0.1}, UnitWeight: 400},   // ヨーグルト 全脂無糖
+	"tofu":         {Per100g: NutritionValues{Kcal: 73, Protein: 7.0, Fat: 4.9, Carbohydrate: 1.5, Salt: 0}, UnitWeight: 300},     // 木綿豆腐（1 丁）
+	"natto":        {Per100g: NutritionValues{Kcal: 190, Protein: 16.5, Fat: 10.0, Carbohydrate: 12.1, Salt: 0}, UnitWeight: 45},  // 糸引き納豆（1 パック）
+	"cabbage":      {Per100g: NutritionValues{Kcal: 21, Protein: 1.3, Fat: 0.2, Carbohydrate: 5.2, Salt: 0}, UnitWeight: 1000},    // キャベツ 結球葉 生
+	"lettuce":      {Per100g: NutritionValues{Kcal: 11, Protein: 0.6, Fat: 0.1, Carbohydrate: 2.8, Salt: 0}, UnitWeight: 300},     // レタス 土耕栽培 結球葉 生
+	"spinach":      {Per100g: NutritionValues{Kcal: 18, Protein: 2.2, Fat: 0.4, Carbohydrate: 3.1, Salt: 0}, UnitWeight: 200},     // ほうれんそう 葉 通年平均 生（1 束）
+	"bean_sprouts": {Per100g: NutritionValues{Kcal: 15, Protein: 1.7, Fat: 0.1, Carbohydrate: 2.6, Salt: 0}, UnitWeight: 200},     // りょくとうもやし 生（1 袋）
+	"cucumber":     {Per100g: NutritionValues{Kcal: 13, Protein: 1.0, Fat: 0.1, Carbohydrate: 3.0, Salt: 0}, UnitWeight: 100},     // きゅうり 果実 生
+	"tomato":       {Per100g: NutritionValues{Kcal: 20, Protein: 0.7, Fat: 0.1, Carbohydrate: 4.7, Salt: 0}, UnitWeight: 150},     // トマト 果実 生
+	"green_onion":  {Per100g: NutritionValues{Kcal: 35, Protein: 1.4, Fat: 0.1, Carbohydrate: 8.3, Salt: 0}, UnitWeight: 100},     // 根深ねぎ 葉 軟白 生
+	"mushroom":     {Per100g: NutritionValues{Kcal: 22, Protein: 2.7, Fat: 0.5, Carbohydrate: 4.8, Salt: 0}, UnitWeight: 100},     // ぶなしめじ 生（1 パック）
+	"carrot":       {Per100g: NutritionValues{Kcal: 35, Protein: 0.7, Fat: 0.2, Carbohydrate: 9.3, Salt: 0.1}, UnitWeight: 150},   // にんじん 根 皮なし 生
+	"onion":        {Per100g: NutritionValues{Kcal: 33, Protein: 1.0, Fat: 0.1, Carbohydrate: 8.4, Salt: 0}, UnitWeight: 200},     // たまねぎ りん茎 生
+	"potato":       {Per100g: NutritionValues{Kcal: 59, Protein: 1.8, Fat: 0.1, Carbohydrate: 17.3, Salt: 0}, UnitWeight: 135},    // じゃがいも 塊茎 皮なし 生
+	"banana":       {Per100g: NutritionValues{Kcal: 93, Protein: 1.1, Fat: 0.2, Carbohydrate: 22.5, Salt: 0}, UnitWeight: 100},    // バナナ 生
+	"apple":        {Per100g: NutritionValues{Kcal: 53, Protein: 0.1, Fat: 0.2, Carbohydrate: 15.5, Salt: 0}, UnitWeight: 250},    // りんご 皮なし 生
+	"bread":        {Per100g: NutritionValues{Kcal: 248, Protein: 8.9, Fat: 4.1, Carbohydrate: 46.4, Salt: 1.2}, UnitWeight: 60},  // 角形食パン 食パン（6 枚切り 1 枚）
+	"cooked_rice":  {Per100g: NutritionValues{Kcal: 156, Protein: 2.5, Fat: 0.3, Carbohydrate: 37.1, Salt: 0}, UnitWeight: 150},   // こめ うるち米 めし 精白米（茶碗 1 杯）
+}
+
+// ReferenceNutrition は食材名と単位から同梱の成分表の栄養成分を返す。
+// 重さ・容量の単位の食材は 100 g あたり、数えられる単位の食材は 1 つあたりの重さが分かれば 1 つあたりの値にする。
+// 成分表にない食材の場合は false を返す。
+func ReferenceNutrition(title string, unit Unit) (Nutrition, bool) {
+	entry, ok := FindShelfLifeEntry(title)
+	if !ok {
+		return Nutrition{}, false
+	}
+	ref, ok := NutritionReferences[entry.Ingredient]
+	if !ok {
+		return Nutrition{}, false
+	}
+	nutrition := Nutrition{Basis: NutritionPer100g, Source: NutritionSourceReference, NutritionValues: ref.Per100g}
+	if !unit.CompatibleWith(UnitGram) && !unit.CompatibleWith(UnitMilliliter) && ref.UnitWeight > 0 {
+		nutrition.Basis = NutritionPerUnit
+		nutrition.NutritionValues = ref.Per100g.Scale(ref.UnitWeight / 100)
+	}
+	return nutrition, true
+}
+
+// NutritionSummaryItem は栄養成分の集計の食材ごとの内訳。
+// Nutrition は在庫の数量に含まれる栄養成分で、集計に含められなかった食材では nil になり、Reason に理由を設定する。
+type NutritionSummaryItem struct {
+	FoodItemId uint             `json:"food_item_id"`
+	Title      string           `json:"title"`
+	Quantity   float64          `json:"quantity"`
+	Unit       Unit             `json:"unit"`
+	Nutrition  *NutritionValues `json:"nutrition,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+}
+
+// 栄養成分の集計に含められなかった理由
+const (
+	NutritionMissing       = "no_nutrition"   // 栄養成分が登録されておらず、成分表にもない
+	NutritionUnknownWeight = "unknown_weight" // 100 g あたりの値で、数量を重さに換算できない
+)
+
+// NutritionSummary は在庫の栄養成分の合計。Items は集計に含めた食材、Missing は含められなかった食材
+type NutritionSummary struct {
+	Totals  NutritionValues        `json:"totals"`
+	Items   []NutritionSummaryItem `json:"items"`
+	Missing []NutritionSummaryItem `json:"missing"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceNutrition(t *testing.T) {
+	t.Run("数えられる単位の食材は1つあたりの値にする", func(t *testing.T) {
+		nutrition, ok := ReferenceNutrition("卵", UnitPiece)
+
+		assert.True(t, ok)
+		assert.Equal(t, NutritionPerUnit, nutrition.Basis)
+		assert.Equal(t, NutritionSourceReference, nutrition.Source)
+		assert.Equal(t, 71.0, nutrition.Kcal)
+		assert.Equal(t, 6.1, nutrition.Protein)
+	})
+
+	t.Run("重さ・容量の単位の食材は100gあたりの値にする", func(t *testing.T) {
+		nutrition, ok := ReferenceNutrition("牛乳", UnitMilliliter)
+
+		assert.True(t, ok)
+		assert.Equal(t, NutritionPer100g, nutrition.Basis)
+		assert.Equal(t, 61.0, nutrition.Kcal)
+	})
+
+	t.Run("成分表にない食材はfalse", func(t *testing.T) {
+		_, ok := ReferenceNutrition("謎の食材", UnitPiece)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestNutrition_For(t *testing.T) {
+	per100g := Nutrition{Basis: NutritionPer100g, NutritionValues: NutritionValues{Kcal: 190, Protein: 16.6}}
+
+	t.Run("100gあたりの値を重さに換算して掛ける", func(t *testing.T) {
+		values, ok := per100g.For(Quantity{Amount: 0.3, Unit: UnitKilogram})
+
+		assert.True(t, ok)
+		assert.Equal(t, NutritionValues{Kcal: 570, Protein: 49.8}, values)
+	})
+
+	t.Run("容量は1mlを1gとして換算する", func(t *testing.T) {
+		values, ok := per100g.For(Quantity{Amount: 50, Unit: UnitMilliliter})
+
+		assert.True(t, ok)
+		assert.Equal(t, 95.0, values.Kcal)
+	})
+
+	t.Run("100gあたりの値で個数は換算できない", func(t *testing.T) {
+		_, ok := per100g.For(Quantity{Amount: 2, Unit: UnitPiece})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("1つあたりの値は数量を掛ける", func(t *testing.T) {
+		perUnit := Nutrition{Basis: NutritionPerUnit, NutritionValues: NutritionValues{Kcal: 71}}
+
+		values, ok := perUnit.For(Quantity{Amount: 3, Unit: UnitPiece})
+
+		assert.True(t, ok)
+		assert.Equal(t, 213.0, values.Kcal)
+	})
+
+	t.Run("栄養成分がない場合はfalse", func(t *testing.T) {
+		_, ok := Nutrition{}.For(Quantity{Amount: 100, Unit: UnitGram})
+
+		assert.False(t, ok)
+	})
+}
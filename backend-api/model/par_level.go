@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// ParLevel は常備品（米・卵・牛乳など）の最低在庫。
+// 同じ名前の食材の合計数量が MinQuantity を下回ると、不足分を買い物リストに追加する。
+type ParLevel struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex:idx_par_levels_user_name,priority:2"`
+	MinQuantity float64   `json:"min_quantity" gorm:"type:numeric(12,3);not null"`
+	Unit        Unit      `json:"unit" gorm:"type:varchar(16);not null;default:'piece'"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	User        User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_par_levels_user_name,priority:1"`
+}
+
+type ParLevelResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	MinQuantity float64   `json:"min_quantity"`
+	Unit        Unit      `json:"unit"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RestockSuggestion は最低在庫を下回っている常備品
+type RestockSuggestion struct {
+	ParLevelId      uint    `json:"par_level_id"`
+	Name            string  `json:"name"`
+	Unit            Unit    `json:"unit"`
+	MinQuantity     float64 `json:"min_quantity"`
+	CurrentQuantity float64 `json:"current_quantity"`
+	Shortfall       float64 `json:"shortfall"`
+	// ShoppingListItemId は買い物リストに同じ名前の項目がある場合の ID
+	ShoppingListItemId *uint `json:"shopping_list_item_id"`
+}
+
+// Stock は foodItems のうち名前が一致する食材の数量を、最低在庫の単位に換算して合計する。
+// 換算できない単位の食材は数えない。
+func (p ParLevel) Stock(foodItems []FoodItem) float64 {
+	total := 0.0
+	for _, foodItem := range foodItems {
+		if foodItem.Title != p.Name {
+			continue
+		}
+		if converted, err := foodItem.Amount().ConvertTo(p.Unit); err == nil {
+			total += converted.Amount
+		}
+	}
+	return roundQuantity(total)
+}
+
+// Suggest は在庫が最低在庫を下回っている場合に補充の提案を返す
+func (p ParLevel) Suggest(foodItems []FoodItem) (RestockSuggestion, bool) {
+	current := p.Stock(foodItems)
+	if current >= p.MinQuantity {
+		return RestockSuggestion{}, false
+	}
+	return RestockSuggestion{
+		ParLevelId:      p.ID,
+		Name:            p.Name,
+		Unit:            p.Unit,
+		MinQuantity:     p.MinQuantity,
+		CurrentQuantity: current,
+		Shortfall:       roundQuantity(p.MinQuantity - current),
+	}, true
+}
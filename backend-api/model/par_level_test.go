@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParLevel_Suggest(t *testing.T) {
+	rice := ParLevel{ID: 1, Name: "米", MinQuantity: 2, Unit: UnitKilogram}
+
+	tests := []struct {
+		name          string
+		foodItems     []FoodItem
+		wantSuggest   bool
+		wantCurrent   float64
+		wantShortfall float64
+	}{
+		{
+			name:          "在庫なし",
+			wantSuggest:   true,
+			wantShortfall: 2,
+		},
+		{
+			name: "単位を換算して合計する",
+			foodItems: []FoodItem{
+				{Title: "米", Quantity: 1, Unit: UnitKilogram},
+				{Title: "米", Quantity: 500, Unit: UnitGram},
+				{Title: "麦", Quantity: 5, Unit: UnitKilogram},
+			},
+			wantSuggest:   true,
+			wantCurrent:   1.5,
+			wantShortfall: 0.5,
+		},
+		{
+			name: "換算できない単位は数えない",
+			foodItems: []FoodItem{
+				{Title: "米", Quantity: 3, Unit: UnitFukuro},
+			},
+			wantSuggest:   true,
+			wantShortfall: 2,
+		},
+		{
+			name: "最低在庫ちょうどは補充しない",
+			foodItems: []FoodItem{
+				{Title: "米", Quantity: 2000, Unit: UnitGram},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, ok := rice.Suggest(tt.foodItems)
+
+			assert.Equal(t, tt.wantSuggest, ok)
+			if ok {
+				assert.Equal(t, tt.wantCurrent, suggestion.CurrentQuantity)
+				assert.Equal(t, tt.wantShortfall, suggestion.Shortfall)
+				assert.Equal(t, UnitKilogram, suggestion.Unit)
+			}
+		})
+	}
+}
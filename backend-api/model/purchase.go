@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultCurrency は通貨を省略した場合の通貨コード
+const DefaultCurrency = "JPY"
+
+// ParseCurrency は通貨コード（ISO 4217 の英字 3 文字）を大文字にそろえる。
+// 空文字列は DefaultCurrency として扱う。
+func ParseCurrency(s string) (string, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return DefaultCurrency, nil
+	}
+	if len(s) != 3 || strings.Trim(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("通貨は 3 文字の通貨コード（JPY など）で指定してください: %s", s)
+	}
+	return s, nil
+}
+
+// Money は通貨コードつきの金額
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// MoneyTotals は通貨ごとの金額の合計。異なる通貨は換算せずに分けて合計する
+type MoneyTotals map[string]float64
+
+// Add は m を同じ通貨の合計に加える
+func (t MoneyTotals) Add(m Money) {
+	t[m.Currency] = roundMoney(t[m.Currency] + m.Amount)
+}
+
+// roundMoney は DB の numeric(12,2) に合わせて小数第2位に丸める
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Purchase は価格を入力した購入の記録。作成後は変更しない。
+// ロットは使い切ると削除されるため、支出の集計にはこの記録を使う。
+// 食材が削除されても集計できるよう、食材名・カテゴリ・単位は購入時点のものを記録する。
+type Purchase struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	FoodItemId  *uint     `json:"food_item_id" gorm:"index"`
+	FoodItem    *FoodItem `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:SET NULL"`
+	Title       string    `json:"title" gorm:"not null"`
+	CategoryId  *uint     `json:"category_id"`
+	Category    *Category `json:"-" gorm:"foreignKey:CategoryId; constraint:OnDelete:SET NULL"`
+	Quantity    float64   `json:"quantity" gorm:"type:numeric(12,3);not null"`
+	Unit        Unit      `json:"unit" gorm:"type:varchar(16);not null"`
+	Price       float64   `json:"price" gorm:"type:numeric(12,2);not null"`
+	Currency    string    `json:"currency" gorm:"type:varchar(3);not null"`
+	User        User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId      uint      `json:"user_id" gorm:"not null;index"`
+	PurchasedAt time.Time `json:"purchased_at" gorm:"not null;index"`
+}
+
+// SpendingSummary は支出の集計の1行。Key は週（その週の月曜日 "2006-01-02"）、月（"2006-01"）、またはカテゴリ名。
+// WasteCost は同じ期間・カテゴリで廃棄した食材の金額。
+type SpendingSummary struct {
+	Key       string      `json:"key"`
+	Count     int         `json:"count"`
+	Amounts   MoneyTotals `json:"amounts"`
+	WasteCost MoneyTotals `json:"waste_cost"`
+}
+
+// SpendingReport は期間内の購入金額と廃棄した食材の金額を週・月・カテゴリごとに集計したもの
+type SpendingReport struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Count      int               `json:"count"`
+	Amounts    MoneyTotals       `json:"amounts"`
+	WasteCost  MoneyTotals       `json:"waste_cost"`
+	ByWeek     []SpendingSummary `json:"by_week"`
+	ByMonth    []SpendingSummary `json:"by_month"`
+	ByCategory []SpendingSummary `json:"by_category"`
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Unit は食材の数量の単位を表す
+type Unit string
+
+const (
+	UnitPiece      Unit = "piece"  // 個
+	UnitGram       Unit = "g"      // グラム
+	UnitKilogram   Unit = "kg"     // キログラム
+	UnitMilliliter Unit = "ml"     // ミリリットル
+	UnitLiter      Unit = "L"      // リットル
+	UnitPack       Unit = "pack"   // パック
+	UnitHon        Unit = "hon"    // 本
+	UnitMai        Unit = "mai"    // 枚
+	UnitFukuro     Unit = "fukuro" // 袋
+)
+
+// unitDef は単位ごとの表示名と換算情報
+type unitDef struct {
+	label     string
+	dimension string  // 同じ dimension の単位同士のみ換算できる
+	factor    float64 // dimension の基準単位に対する倍率
+}
+
+var unitDefs = map[Unit]unitDef{
+	UnitPiece:      {"個", "piece", 1},
+	UnitGram:       {"g", "mass", 1},
+	UnitKilogram:   {"kg", "mass", 1000},
+	UnitMilliliter: {"ml", "volume", 1},
+	UnitLiter:      {"L", "volume", 1000},
+	UnitPack:       {"パック", "pack", 1},
+	UnitHon:        {"本", "hon", 1},
+	UnitMai:        {"枚", "mai", 1},
+	UnitFukuro:     {"袋", "fukuro", 1},
+}
+
+// ParseUnit は単位コードまたは日本語の表示名（"個", "本" など）を Unit に変換する。
+// 空文字列は個数として扱う。
+func ParseUnit(s string) (Unit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return UnitPiece, nil
+	}
+	for u, def := range unitDefs {
+		if strings.EqualFold(s, string(u)) || s == def.label {
+			return u, nil
+		}
+	}
+	return "", fmt.Errorf("対応していない単位です: %s", s)
+}
+
+// IsValid は定義済みの単位かどうかを返す
+func (u Unit) IsValid() bool {
+	_, ok := unitDefs[u]
+	return ok
+}
+
+// Label は表示用の単位名を返す
+func (u Unit) Label() string {
+	if def, ok := unitDefs[u]; ok {
+		return def.label
+	}
+	return string(u)
+}
+
+// CompatibleWith は v と相互に換算できるかどうかを返す
+func (u Unit) CompatibleWith(v Unit) bool {
+	a, okA := unitDefs[u]
+	b, okB := unitDefs[v]
+	return okA && okB && a.dimension == b.dimension
+}
+
+// BaseUnit は換算できる単位の中で基準となる単位を返す（kg なら g、L なら ml）
+func (u Unit) BaseUnit() Unit {
+	def, ok := unitDefs[u]
+	if !ok {
+		return u
+	}
+	for base, d := range unitDefs {
+		if d.dimension == def.dimension && d.factor == 1 {
+			return base
+		}
+	}
+	return u
+}
+
+// Quantity は単位付きの数量
+type Quantity struct {
+	Amount float64 `json:"amount"`
+	Unit   Unit    `json:"unit"`
+}
+
+// ErrInvalidQuantity は数量が負の値、または数値でない（NaN・無限大）場合に返す
+var ErrInvalidQuantity = errors.New("数量は 0 以上の数値で指定してください")
+
+// Validate は数量が 0 以上の有限の数値であることを確認する
+func (q Quantity) Validate() error {
+	if math.IsNaN(q.Amount) || math.IsInf(q.Amount, 0) || q.Amount < 0 {
+		return ErrInvalidQuantity
+	}
+	return nil
+}
+
+// ConvertTo は数量を指定した単位に換算する
+func (q Quantity) ConvertTo(u Unit) (Quantity, error) {
+	if !q.Unit.CompatibleWith(u) {
+		return Quantity{}, fmt.Errorf("%s を %s に換算できません", q.Unit.Label(), u.Label())
+	}
+	return Quantity{
+		Amount: q.Amount * unitDefs[q.Unit].factor / unitDefs[u].factor,
+		Unit:   u,
+	}, nil
+}
+
+// Add は o を q の単位に換算して合計する
+func (q Quantity) Add(o Quantity) (Quantity, error) {
+	converted, err := o.ConvertTo(q.Unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Amount: q.Amount + converted.Amount, Unit: q.Unit}, nil
+}
+
+// String は "2個" や "1.5L" のような表示用の文字列を返す
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Amount, 'f', -1, 64) + q.Unit.Label()
+}
+
+// QuantityTotals は単位の異なる数量を、換算できるもの同士で基準単位にまとめて合計する
+type QuantityTotals map[Unit]float64
+
+// Add は q を基準単位に換算して合計に加える
+func (t QuantityTotals) Add(q Quantity) {
+	base := q.Unit.BaseUnit()
+	if converted, err := q.ConvertTo(base); err == nil {
+		q = converted
+	}
+	t[base] = roundQuantity(t[base] + q.Amount)
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Unit
+		wantErr bool
+	}{
+		{input: "", want: UnitPiece},
+		{input: "個", want: UnitPiece},
+		{input: "kg", want: UnitKilogram},
+		{input: "l", want: UnitLiter},
+		{input: "本", want: UnitHon},
+		{input: "袋", want: UnitFukuro},
+		{input: "ダース", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseUnit(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuantity_ConvertTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    Quantity
+		to      Unit
+		want    float64
+		wantErr bool
+	}{
+		{name: "kgからg", from: Quantity{1.5, UnitKilogram}, to: UnitGram, want: 1500},
+		{name: "mlからL", from: Quantity{250, UnitMilliliter}, to: UnitLiter, want: 0.25},
+		{name: "同じ単位", from: Quantity{3, UnitHon}, to: UnitHon, want: 3},
+		{name: "重さと容量は換算不可", from: Quantity{1, UnitKilogram}, to: UnitLiter, wantErr: true},
+		{name: "異なる助数詞は換算不可", from: Quantity{2, UnitMai}, to: UnitFukuro, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.from.ConvertTo(tt.to)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got.Amount, 1e-9)
+			assert.Equal(t, tt.to, got.Unit)
+		})
+	}
+}
+
+func TestQuantity_Validate(t *testing.T) {
+	assert.NoError(t, Quantity{0, UnitPiece}.Validate())
+	assert.NoError(t, Quantity{1.5, UnitLiter}.Validate())
+	assert.ErrorIs(t, Quantity{-0.5, UnitLiter}.Validate(), ErrInvalidQuantity)
+	assert.ErrorIs(t, Quantity{math.NaN(), UnitGram}.Validate(), ErrInvalidQuantity)
+	assert.ErrorIs(t, Quantity{math.Inf(1), UnitGram}.Validate(), ErrInvalidQuantity)
+}
+
+func TestQuantity_Add(t *testing.T) {
+	sum, err := Quantity{1, UnitLiter}.Add(Quantity{500, UnitMilliliter})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{1.5, UnitLiter}, sum)
+	assert.Equal(t, "1.5L", sum.String())
+
+	_, err = Quantity{2, UnitPiece}.Add(Quantity{100, UnitGram})
+	assert.Error(t, err)
+}
+
+func TestQuantityTotals_Add(t *testing.T) {
+	totals := QuantityTotals{}
+	totals.Add(Quantity{Amount: 300, Unit: UnitGram})
+	totals.Add(Quantity{Amount: 0.2, Unit: UnitKilogram})
+	totals.Add(Quantity{Amount: 1, Unit: UnitLiter})
+	totals.Add(Quantity{Amount: 2, Unit: UnitHon})
+
+	assert.Equal(t, QuantityTotals{UnitGram: 500, UnitMilliliter: 1000, UnitHon: 2}, totals)
+}
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// ReceiptDraft は貼り付けたレシートの文字列から読み取った、登録前に確認するための下書き。
+// 金額は Currency（日本のレシートのため JPY）の整数。
+type ReceiptDraft struct {
+	// PurchasedAt はレシートに印字された日時。読み取れない場合は解析した時刻
+	PurchasedAt time.Time          `json:"purchased_at"`
+	Currency    string             `json:"currency"`
+	Items       []ReceiptDraftItem `json:"items"`
+	Subtotal    *int               `json:"subtotal"`
+	Tax         *int               `json:"tax"` // 消費税の行の合計（内税・外税を含む）
+	Total       *int               `json:"total"`
+	// SkippedLines は商品・値引き・合計などとして読み取らなかった行（店名や住所など）
+	SkippedLines []string `json:"skipped_lines"`
+}
+
+// ReceiptDraftItem はレシートの 1 商品。Quantity と Unit は登録する食材の数量で、
+// 品名に内容量（"1000ML" など）があればその単位、なければ個数になる。
+type ReceiptDraftItem struct {
+	Line           int     `json:"line"` // レシートの 1 始まりの行番号
+	Text           string  `json:"text"` // レシートの品名の表記（半角カナは全角に直す）
+	Title          string  `json:"title"`
+	Ingredient     string  `json:"ingredient"`      // 同梱の日持ちのデータの食材の識別子。対応しない場合は空
+	IngredientName string  `json:"ingredient_name"` // 同上の食材名
+	Count          int     `json:"count"`           // 買った個数（"2コ X 単価 119" の 2）
+	Quantity       float64 `json:"quantity"`
+	Unit           Unit    `json:"unit"`
+	UnitPrice      int     `json:"unit_price"`
+	Discount       int     `json:"discount"` // 値引きの金額（正の値）
+	Price          int     `json:"price"`    // 値引き後の金額
+	// ExpiryDate は購入日時と日持ちの目安から見積もった賞味期限。目安がない場合は nil
+	ExpiryDate *time.Time `json:"expiry_date"`
+	// ExpiryEstimated は ExpiryDate が見積もりの場合に true。見積もりのまま登録すると、
+	// 登録する保管場所で見積もり直し、日持ちの学習には使わない
+	ExpiryEstimated bool `json:"expiry_estimated"`
+}
+
+// ReceiptCommit はレシートの下書きを確認・修正した内容。Items は下書きの items をそのまま送ってもよい。
+type ReceiptCommit struct {
+	HouseholdId *uint      `json:"household_id"`
+	PurchasedAt *time.Time `json:"purchased_at"`
+	// Currency は品目ごとに指定しなかった場合の通貨（省略時は JPY）
+	Currency string `json:"currency"`
+	// StorageLocationId は品目ごとに指定しなかった場合の保管場所
+	StorageLocationId *uint               `json:"storage_location_id"`
+	Items             []ReceiptCommitItem `json:"items"`
+}
+
+// ReceiptCommitItem は食材として登録する 1 品目。expiry_date を省略した場合は日持ちの目安から求める。
+// price は値引き後の金額で、最初のロットの価格として記録する
+type ReceiptCommitItem struct {
+	Title             string     `json:"title"`
+	Quantity          float64    `json:"quantity"`
+	Unit              Unit       `json:"unit"`
+	ExpiryDate        *time.Time `json:"expiry_date"`
+	Price             *float64   `json:"price"`
+	Currency          string     `json:"currency"`
+	StorageLocationId *uint      `json:"storage_location_id"`
+	CategoryId        *uint      `json:"category_id"`
+	// ExpiryEstimated は ExpiryDate が下書きの見積もりのままの場合に true。
+	// 省略した場合と同じく登録する保管場所で見積もり直し、日持ちの学習には使わない
+	ExpiryEstimated bool `json:"expiry_estimated"`
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ShelfLifeSource は日持ちの目安の出どころ
+type ShelfLifeSource string
+
+const (
+	ShelfLifeSourceDefault ShelfLifeSource = "default" // 同梱のデータ（DefaultShelfLives）
+	ShelfLifeSourceUser    ShelfLifeSource = "user"    // ユーザーが入力した賞味期限から学習した日数
+)
+
+// ShelfLifeEntry は食材ごとの日持ちの目安。
+// Aliases は正規化した（ひらがな・小文字の）名前の一部で、食材名に含まれるもののうち最も長いものでこの食材と判定する。
+// レシートの品名はカタカナの読みで印字されることが多いため、読みも含める。
+type ShelfLifeEntry struct {
+	Ingredient string // "chicken" のような不変の識別子
+	Name       string
+	Aliases    []string
+	// Days は保管場所の種類ごとの日持ちの日数。その場所での保存に向かない食材は含めない
+	Days map[LocationType]int
+	// DefaultLocation は保管場所が分からない場合に使う種類
+	DefaultLocation LocationType
+}
+
+// DefaultShelfLives は賞味期限が印字されていないことの多い食材の日持ちの目安（購入日からの日数）
+var DefaultShelfLives = []ShelfLifeEntry{
+	{Ingredient: "chicken", Name: "鶏肉", Aliases: []string{"鶏", "とりにく", "とりもも", "とりむね", "てば", "ちきん", "ささみ"}, Days: map[LocationType]int{LocationFridge: 2, LocationFreezer: 60}, DefaultLocation: LocationFridge},
+	{Ingredient: "pork", Name: "豚肉", Aliases: []string{"豚", "ぶた", "ぽーく"}, Days: map[LocationType]int{LocationFridge: 3, LocationFreezer: 60}, DefaultLocation: LocationFridge},
+	{Ingredient: "beef", Name: "牛肉", Aliases: []string{"牛", "ぎゅう", "びーふ"}, Days: map[LocationType]int{LocationFridge: 3, LocationFreezer: 90}, DefaultLocation: LocationFridge},
+	{Ingredient: "minced_meat", Name: "ひき肉", Aliases: []string{"ひき肉", "挽肉", "挽き肉", "ひきにく", "合挽", "あいびき", "みんち"}, Days: map[LocationType]int{LocationFridge: 1, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+	{Ingredient: "fish", Name: "鮮魚", Aliases: []string{"魚", "さかな", "鮭", "さけ", "鯖", "さば", "鯵", "あじ", "刺身", "さしみ"}, Days: map[LocationType]int{LocationFridge: 2, LocationFreezer: 60}, DefaultLocation: LocationFridge},
+	{Ingredient: "egg", Name: "卵", Aliases: []string{"卵", "玉子", "たまご"}, Days: map[LocationType]int{LocationFridge: 14}, DefaultLocation: LocationFridge},
+	{Ingredient: "milk", Name: "牛乳", Aliases: []string{"牛乳", "ぎゅうにゅう", "みるく"}, Days: map[LocationType]int{LocationFridge: 7}, DefaultLocation: LocationFridge},
+	{Ingredient: "yogurt", Name: "ヨーグルト", Aliases: []string{"よーぐると"}, Days: map[LocationType]int{LocationFridge: 10}, DefaultLocation: LocationFridge},
+	{Ingredient: "tofu", Name: "豆腐", Aliases: []string{"豆腐", "とうふ"}, Days: map[LocationType]int{LocationFridge: 5}, DefaultLocation: LocationFridge},
+	{Ingredient: "natto", Name: "納豆", Aliases: []string{"納豆", "なっとう"}, Days: map[LocationType]int{LocationFridge: 10, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+	{Ingredient: "cabbage", Name: "キャベツ", Aliases: []string{"きゃべつ"}, Days: map[LocationType]int{LocationFridge: 14, LocationPantry: 3}, DefaultLocation: LocationFridge},
+	{Ingredient: "lettuce", Name: "レタス", Aliases: []string{"れたす"}, Days: map[LocationType]int{LocationFridge: 7}, DefaultLocation: LocationFridge},
+	{Ingredient: "spinach", Name: "ほうれん草", Aliases: []string{"ほうれん草", "ほうれんそう", "小松菜", "こまつな"}, Days: map[LocationType]int{LocationFridge: 3, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+	{Ingredient: "bean_sprouts", Name: "もやし", Aliases: []string{"もやし"}, Days: map[LocationType]int{LocationFridge: 2}, DefaultLocation: LocationFridge},
+	{Ingredient: "cucumber", Name: "きゅうり", Aliases: []string{"きゅうり", "胡瓜"}, Days: map[LocationType]int{LocationFridge: 5}, DefaultLocation: LocationFridge},
+	{Ingredient: "tomato", Name: "トマト", Aliases: []string{"とまと"}, Days: map[LocationType]int{LocationFridge: 7, LocationPantry: 3}, DefaultLocation: LocationFridge},
+	{Ingredient: "green_onion", Name: "ねぎ", Aliases: []string{"ねぎ", "葱"}, Days: map[LocationType]int{LocationFridge: 7, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+	{Ingredient: "mushroom", Name: "きのこ", Aliases: []string{"きのこ", "しめじ", "えのき", "しいたけ", "椎茸", "まいたけ", "舞茸", "えりんぎ"}, Days: map[LocationType]int{LocationFridge: 5, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+	{Ingredient: "carrot", Name: "にんじん", Aliases: []string{"人参", "にんじん"}, Days: map[LocationType]int{LocationFridge: 21, LocationPantry: 7}, DefaultLocation: LocationFridge},
+	{Ingredient: "onion", Name: "玉ねぎ", Aliases: []string{"玉ねぎ", "玉葱", "たまねぎ"}, Days: map[LocationType]int{LocationPantry: 30, LocationFridge: 60}, DefaultLocation: LocationPantry},
+	{Ingredient: "potato", Name: "じゃがいも", Aliases: []string{"じゃがいも", "馬鈴薯"}, Days: map[LocationType]int{LocationPantry: 30}, DefaultLocation: LocationPantry},
+	{Ingredient: "banana", Name: "バナナ", Aliases: []string{"ばなな"}, Days: map[LocationType]int{LocationPantry: 4}, DefaultLocation: LocationPantry},
+	{Ingredient: "apple", Name: "りんご", Aliases: []string{"りんご", "林檎"}, Days: map[LocationType]int{LocationFridge: 30, LocationPantry: 7}, DefaultLocation: LocationFridge},
+	{Ingredient: "bread", Name: "パン", Aliases: []string{"ぱん", "ばげっと"}, Days: map[LocationType]int{LocationPantry: 3, LocationFreezer: 30}, DefaultLocation: LocationPantry},
+	{Ingredient: "cooked_rice", Name: "ご飯", Aliases: []string{"ご飯", "ごはん"}, Days: map[LocationType]int{LocationFridge: 2, LocationFreezer: 30}, DefaultLocation: LocationFridge},
+}
+
+// NormalizeIngredientName は食材名を照合用に正規化する（NFKC による全角英数・半角カナの統一、小文字化・カタカナのひらがな化・空白の除去）
+func NormalizeIngredientName(title string) string {
+	s := strings.ToLower(norm.NFKC.String(title))
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == ' ' || r == '\t' || r == '　':
+			continue
+		case r >= 'ァ' && r <= 'ヶ':
+			r -= 'ァ' - 'ぁ'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FindShelfLifeEntry は食材名に含まれる最も長い別名の食材を DefaultShelfLives から探す
+func FindShelfLifeEntry(title string) (ShelfLifeEntry, bool) {
+	name := NormalizeIngredientName(title)
+	found, length := ShelfLifeEntry{}, 0
+	for _, entry := range DefaultShelfLives {
+		for _, alias := range entry.Aliases {
+			if n := utf8.RuneCountInString(alias); n > length && strings.Contains(name, alias) {
+				found, length = entry, n
+			}
+		}
+	}
+	return found, length > 0
+}
+
+// CanonicalIngredient は日持ちを記録・照合するときの食材のキーを返す。
+// DefaultShelfLives にある食材はその識別子、ない食材は正規化した食材名をキーにする。
+func CanonicalIngredient(title string) string {
+	if entry, ok := FindShelfLifeEntry(title); ok {
+		return entry.Ingredient
+	}
+	return NormalizeIngredientName(title)
+}
+
+// ShelfLifeOverride はユーザーが入力した賞味期限から学習した、食材と保管場所の種類ごとの日持ちの日数。
+// 同梱のデータより優先する。
+type ShelfLifeOverride struct {
+	ID           uint         `json:"id" gorm:"primaryKey"`
+	UserId       uint         `json:"-" gorm:"not null;uniqueIndex:idx_shelf_life_overrides_key,priority:1"`
+	User         User         `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	Ingredient   string       `json:"ingredient" gorm:"not null;uniqueIndex:idx_shelf_life_overrides_key,priority:2"`
+	LocationType LocationType `json:"location_type" gorm:"type:varchar(16);not null;uniqueIndex:idx_shelf_life_overrides_key,priority:3"`
+	Days         int          `json:"days" gorm:"not null"`
+	// SampleCount は学習に使った入力の数。新しい入力ほど重く扱うため ShelfLifeMaxSamples で打ち切る
+	SampleCount int       `json:"sample_count" gorm:"not null;default:1"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ShelfLifeMaxSamples は学習した日数の平均に使う入力の数の上限
+const ShelfLifeMaxSamples = 5
+
+// Learn は入力された日数 days を平均に加える
+func (o *ShelfLifeOverride) Learn(days int) {
+	if o.SampleCount <= 0 {
+		o.Days, o.SampleCount = days, 1
+		return
+	}
+	n := min(o.SampleCount, ShelfLifeMaxSamples-1)
+	o.Days = (o.Days*n + days + (n+1)/2) / (n + 1)
+	o.SampleCount++
+}
+
+// ShelfLifeEstimate は食材の日持ちの目安と、購入日から求めた賞味期限
+type ShelfLifeEstimate struct {
+	Ingredient   string          `json:"ingredient"`
+	Name         string          `json:"name"`
+	LocationType LocationType    `json:"location_type"`
+	Days         int             `json:"days"`
+	Source       ShelfLifeSource `json:"source"`
+	PurchasedAt  time.Time       `json:"purchased_at"`
+	ExpiryDate   time.Time       `json:"expiry_date"`
+}
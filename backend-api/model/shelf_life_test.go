@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindShelfLifeEntry(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "キャベツ", want: "cabbage"},
+		{title: "ｷｬﾍﾞﾂ 1/2", want: "cabbage"},
+		{title: "鶏もも肉", want: "chicken"},
+		// 別名の長いほうを優先する（「牛」ではなく「牛乳」）
+		{title: "低脂肪牛乳", want: "milk"},
+		{title: "豚ひき肉", want: "minced_meat"},
+		{title: "食パン", want: "bread"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			entry, ok := FindShelfLifeEntry(tt.title)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, entry.Ingredient)
+		})
+	}
+
+	_, ok := FindShelfLifeEntry("ケチャップ")
+	assert.False(t, ok)
+	assert.Equal(t, "けちゃっぷ", CanonicalIngredient("ケチャップ"))
+}
+
+func TestShelfLifeOverride_Learn(t *testing.T) {
+	override := ShelfLifeOverride{}
+	override.Learn(4)
+	assert.Equal(t, 4, override.Days)
+	assert.Equal(t, 1, override.SampleCount)
+
+	override.Learn(6)
+	assert.Equal(t, 5, override.Days)
+
+	// 入力が増えても、新しい入力が平均に反映されるよう重みを打ち切る
+	override = ShelfLifeOverride{Days: 10, SampleCount: 20}
+	override.Learn(5)
+	assert.Equal(t, 9, override.Days)
+	assert.Equal(t, 21, override.SampleCount)
+}
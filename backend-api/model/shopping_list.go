@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// ShoppingListItem はユーザーの買い物リストの項目。
+// Position の小さい順に表示し、購入（purchase）でチェック済みの項目を食材として登録する。
+type ShoppingListItem struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Quantity  float64   `json:"quantity" gorm:"type:numeric(12,3);not null"`
+	Unit      Unit      `json:"unit" gorm:"type:varchar(16);not null;default:'piece'"`
+	Note      string    `json:"note"`
+	Checked   bool      `json:"checked" gorm:"not null;default:false"`
+	Position  int       `json:"position" gorm:"not null;index:idx_shopping_list_items_user_position,priority:2"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId    uint      `json:"user_id" gorm:"not null;index:idx_shopping_list_items_user_position,priority:1"`
+}
+
+type ShoppingListItemResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Quantity  float64   `json:"quantity"`
+	Unit      Unit      `json:"unit"`
+	Note      string    `json:"note"`
+	Checked   bool      `json:"checked"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShoppingListOrder は並び替え後の項目 ID。リストのすべての項目を新しい順序で指定する
+type ShoppingListOrder struct {
+	ItemIds []uint `json:"item_ids"`
+}
+
+// ShoppingListPurchase は購入時の入力。
+// Items で賞味期限や保管場所を指定しなかったチェック済みの項目は、賞味期限を推定して登録する。
+type ShoppingListPurchase struct {
+	Items []ShoppingListPurchaseEntry `json:"items"`
+}
+
+// ShoppingListPurchaseEntry は購入する項目ごとの入力
+type ShoppingListPurchaseEntry struct {
+	ItemId            uint       `json:"item_id"`
+	ExpiryDate        *time.Time `json:"expiry_date"`
+	StorageLocationId *uint      `json:"storage_location_id"`
+}
+
+// PurchasedItem は購入によって登録された食材
+type PurchasedItem struct {
+	ShoppingListItemId uint             `json:"shopping_list_item_id"`
+	FoodItem           FoodItemResponse `json:"food_item"`
+	// ExpiryEstimated は賞味期限が入力ではなく推定による場合に true
+	ExpiryEstimated bool `json:"expiry_estimated"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrLocationHouseholdMismatch は食材と異なる世帯の保管場所を指定した場合に返す
+var ErrLocationHouseholdMismatch = errors.New("storage location belongs to another household")
+
+// LocationType は保管場所の種類を表す
+type LocationType string
+
+const (
+	LocationFridge  LocationType = "fridge"  // 冷蔵
+	LocationFreezer LocationType = "freezer" // 冷凍
+	LocationPantry  LocationType = "pantry"  // 常温（棚・パントリー）
+)
+
+// StorageLocation は世帯ごとの食材の保管場所
+type StorageLocation struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	Name      string       `json:"name" gorm:"not null"`
+	Type      LocationType `json:"type" gorm:"type:varchar(16);not null"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	User      User         `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId    uint         `json:"user_id" gorm:"not null"`
+	// 保管場所を共有する世帯。UserId は登録したユーザーを表す
+	Household   Household `json:"-" gorm:"foreignKey:HouseholdId; constraint:OnDelete:CASCADE"`
+	HouseholdId uint      `json:"household_id" gorm:"not null;index"`
+}
+
+type StorageLocationResponse struct {
+	ID          uint         `json:"id"`
+	Name        string       `json:"name"`
+	Type        LocationType `json:"type"`
+	HouseholdId uint         `json:"household_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// LocationMove は食材の保管場所の移動履歴。
+// 冷凍庫への移動などに応じて賞味期限のルールを適用できるよう、移動元と移動先の種類も記録する。
+type LocationMove struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	FoodItemId     uint         `json:"food_item_id" gorm:"not null;index"`
+	FoodItem       FoodItem     `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:CASCADE"`
+	FromLocationId *uint        `json:"from_location_id"`
+	FromType       LocationType `json:"from_type" gorm:"type:varchar(16)"`
+	ToLocationId   *uint        `json:"to_location_id"`
+	ToType         LocationType `json:"to_type" gorm:"type:varchar(16)"`
+	MovedAt        time.Time    `json:"moved_at" gorm:"not null"`
+	UserId         uint         `json:"user_id" gorm:"not null"`
+	// StateTransition は移動に伴って冷凍・解凍した場合の保存状態の変更履歴。状態が変わらない場合は nil
+	StateTransition *FoodStateTransition `json:"state_transition,omitempty" gorm:"-"`
+}
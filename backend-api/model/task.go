@@ -9,6 +9,9 @@ type Task struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	User      User      `json:"user" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
 	UserId    uint      `json:"user_id" gorm:"not null"`
+	// タスクを共有する世帯。UserId は作成したユーザーを表す
+	Household   Household `json:"-" gorm:"foreignKey:HouseholdId; constraint:OnDelete:CASCADE"`
+	HouseholdId uint      `json:"household_id" gorm:"not null;index"`
 }
 
 type TaskResponse struct {
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// DefaultExpiryWarningDays は賞味期限の通知を始める日数の初期値
+const DefaultExpiryWarningDays = 7
+
+// UserSetting はユーザーごとの設定。未保存のユーザーには DefaultUserSetting の値を使う。
+type UserSetting struct {
+	UserId uint `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	User   User `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	// DeleteEmptyItems が true の場合、使い切った食材を削除する。false の場合は数量 0 で残す
+	DeleteEmptyItems bool `json:"delete_empty_items" gorm:"not null;default:false"`
+	// ExpiryWarningDays は賞味期限の何日前から通知するか
+	ExpiryWarningDays int       `json:"expiry_warning_days" gorm:"not null;default:7"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// DefaultUserSetting はユーザー設定の初期値を返す
+func DefaultUserSetting(userId uint) UserSetting {
+	return UserSetting{UserId: userId, ExpiryWarningDays: DefaultExpiryWarningDays}
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// WasteReason は食材を廃棄した理由を表す
+type WasteReason string
+
+const (
+	WasteExpired  WasteReason = "expired"  // 期限切れ
+	WasteSpoiled  WasteReason = "spoiled"  // 傷んだ
+	WasteLeftover WasteReason = "leftover" // 食べ残し
+	WasteOther    WasteReason = "other"    // その他
+)
+
+// IsValid は定義済みの廃棄理由かどうかを返す
+func (r WasteReason) IsValid() bool {
+	switch r {
+	case WasteExpired, WasteSpoiled, WasteLeftover, WasteOther:
+		return true
+	}
+	return false
+}
+
+// WasteRecord は食材の廃棄の記録。通常の消費とは分けて保存する。
+// 食材が削除されても集計できるよう、食材名・カテゴリ・単位は廃棄時点のものを記録する。
+type WasteRecord struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	FoodItemId *uint       `json:"food_item_id" gorm:"index"`
+	FoodItem   *FoodItem   `json:"-" gorm:"foreignKey:FoodItemId; constraint:OnDelete:SET NULL"`
+	Title      string      `json:"title" gorm:"not null"`
+	CategoryId *uint       `json:"category_id"`
+	Category   *Category   `json:"-" gorm:"foreignKey:CategoryId; constraint:OnDelete:SET NULL"`
+	Quantity   float64     `json:"quantity" gorm:"type:numeric(12,3);not null"`
+	Unit       Unit        `json:"unit" gorm:"type:varchar(16);not null"`
+	Reason     WasteReason `json:"reason" gorm:"type:varchar(16);not null"`
+	// EstimatedCost は廃棄した食材の金額。省略した場合は廃棄した分のロットの単価から求める
+	EstimatedCost *float64  `json:"estimated_cost" gorm:"type:numeric(12,2)"`
+	Currency      string    `json:"currency" gorm:"type:varchar(3);not null;default:'JPY'"`
+	Note          string    `json:"note"`
+	User          User      `json:"-" gorm:"foreignKey:UserId; constraint:OnDelete:CASCADE"`
+	UserId        uint      `json:"user_id" gorm:"not null;index"`
+	DiscardedAt   time.Time `json:"discarded_at" gorm:"not null;index"`
+}
+
+// WasteSummary は廃棄の集計の1行。Key は月（"2006-01"）、カテゴリ名、または廃棄理由。
+type WasteSummary struct {
+	Key           string         `json:"key"`
+	Count         int            `json:"count"`
+	Quantities    QuantityTotals `json:"quantities"`
+	EstimatedCost float64        `json:"estimated_cost"`
+}
+
+// WasteReport は期間内の廃棄を月・カテゴリ・理由ごとに集計したもの
+type WasteReport struct {
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+	Count         int            `json:"count"`
+	EstimatedCost float64        `json:"estimated_cost"`
+	ByMonth       []WasteSummary `json:"by_month"`
+	ByCategory    []WasteSummary `json:"by_category"`
+	ByReason      []WasteSummary `json:"by_reason"`
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+)
+
+type ICategoryRepository interface {
+	GetAllCategories(categories *[]model.Category) error
+	GetCategoryById(category *model.Category, categoryId uint) error
+	SeedCategories(defaults []model.DefaultCategory) error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) ICategoryRepository {
+	return &categoryRepository{db}
+}
+
+func (cr *categoryRepository) GetAllCategories(categories *[]model.Category) error {
+	if err := cr.db.Order("id").Find(categories).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cr *categoryRepository) GetCategoryById(category *model.Category, categoryId uint) error {
+	if err := cr.db.Preload("Parent").First(category, categoryId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SeedCategories は初期カテゴリを code をキーに作成・更新する。何度実行してもよい。
+func (cr *categoryRepository) SeedCategories(defaults []model.DefaultCategory) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		return seedCategories(tx, defaults, nil)
+	})
+}
+
+func seedCategories(tx *gorm.DB, defaults []model.DefaultCategory, parentId *uint) error {
+	for _, d := range defaults {
+		category := model.Category{}
+		attrs := model.Category{
+			Name:                d.Name,
+			ParentId:            parentId,
+			OpenedShelfLifeDays: seedDays(d.OpenedShelfLifeDays),
+			FrozenShelfLifeDays: seedDays(d.FrozenShelfLifeDays),
+			ThawedShelfLifeDays: seedDays(d.ThawedShelfLifeDays),
+			CookedShelfLifeDays: seedDays(d.CookedShelfLifeDays),
+		}
+		if err := tx.Where("code=?", d.Code).
+			Assign(attrs).
+			FirstOrCreate(&category, model.Category{Code: d.Code}).Error; err != nil {
+			return err
+		}
+		if err := seedCategories(tx, d.Children, &category.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedDays は初期カテゴリの日数を保存する値にする。0 は未設定（nil）
+func seedDays(days int) *int {
+	if days <= 0 {
+		return nil
+	}
+	return &days
+}
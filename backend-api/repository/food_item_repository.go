@@ -1,17 +1,44 @@
 package repository
 
 import (
+	"fmt"
 	"go-rest-api/model"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// effectiveExpiry は食材の期限（model.FoodItem.EffectiveExpiryDate と同じ値）の列。
+// 開封後の期限（timestamptz + interval）は式の索引に使えないため、期限に関わる列を更新するたびに
+// 同じ値を保存し、(user_id|household_id, effective_expiry) の索引で絞り込み・並び替えを行う。
+const effectiveExpiry = "food_items.effective_expiry"
+
 type IFoodItemRepository interface {
-	GetAllFoodItems(foodItems *[]model.FoodItem) error
-	GetFoodItemById(foodItem *model.FoodItem, id uint) error
+	GetAllFoodItems(foodItems *[]model.FoodItem, userId uint, filter model.FoodItemFilter) error
+	GetFoodItemById(foodItem *model.FoodItem, userId uint, foodItemId uint) error
+	ResolveHousehold(householdId *uint, userId uint) error
+	GetHouseholdMemberIds(userIds *[]uint, foodItemId uint) error
 	CreateFoodItem(foodItem *model.FoodItem) error
-	UpdateFoodItem(foodItem *model.FoodItem) error
-	DeleteFoodItem(id uint) error
+	CreateFoodItems(foodItems []model.FoodItem, userId uint) error
+	UpdateFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error
+	DeleteFoodItem(userId uint, foodItemId uint) error
+	GetTrashedFoodItems(foodItems *[]model.FoodItem, userId uint) error
+	RestoreFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error
+	PurgeFoodItems(deletedBefore time.Time) (int64, error)
+	GetFoodItemsByTitles(foodItems *[]model.FoodItem, userId uint, titles []string) error
+	MoveFoodItem(move *model.LocationMove, userId uint, foodItemId uint) error
+	OpenFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error
+	UpdateFoodItemNutrition(foodItem *model.FoodItem, userId uint, foodItemId uint) error
+	ChangeFoodState(foodItem *model.FoodItem, transition *model.FoodStateTransition, userId uint, foodItemId uint) error
+	GetFoodStateTransitions(transitions *[]model.FoodStateTransition, userId uint, foodItemId uint) error
+	GetFoodLots(lots *[]model.FoodLot, userId uint, foodItemId uint) error
+	RestockFoodItem(foodItem *model.FoodItem, lot *model.FoodLot, movement *model.InventoryMovement, userId uint, foodItemId uint) error
+	ConsumeFoodItem(foodItem *model.FoodItem, movement *model.InventoryMovement, deleteWhenEmpty bool, userId uint, foodItemId uint) error
+	DiscardFoodItem(foodItem *model.FoodItem, movement *model.InventoryMovement, waste *model.WasteRecord, deleteWhenEmpty bool, userId uint, foodItemId uint) error
+	GetInventoryMovements(movements *[]model.InventoryMovement, userId uint, foodItemId uint) error
 }
 
 type foodItemRepository struct {
@@ -22,37 +49,637 @@ func NewFoodItemRepository(db *gorm.DB) IFoodItemRepository {
 	return &foodItemRepository{db}
 }
 
-func (fr *foodItemRepository) GetAllFoodItems(foodItems *[]model.FoodItem) error {
-	if err := fr.db.Find(foodItems).Error; err != nil {
+func (fr *foodItemRepository) GetAllFoodItems(foodItems *[]model.FoodItem, userId uint, filter model.FoodItemFilter) error {
+	query := fr.db.Preload("Tags").Preload("Category.Parent").Preload("Lots", orderLots).Where(memberHouseholds, userId)
+	if filter.StorageLocationId != nil {
+		query = query.Where("storage_location_id=?", *filter.StorageLocationId)
+	}
+	if filter.CategoryId != nil {
+		query = query.Where(`category_id IN (
+			WITH RECURSIVE sub AS (
+				SELECT id FROM categories WHERE id = ?
+				UNION ALL
+				SELECT c.id FROM categories c JOIN sub ON c.parent_id = sub.id
+			) SELECT id FROM sub)`, *filter.CategoryId)
+	}
+	if filter.TagId != nil {
+		query = query.Where("id IN (SELECT food_item_id FROM food_item_tags WHERE tag_id=?)", *filter.TagId)
+	}
+	if filter.Title != "" {
+		query = query.Where("title ILIKE ?", "%"+escapeLike(filter.Title)+"%")
+	}
+	if filter.ExpiresBefore != nil {
+		query = query.Where(effectiveExpiry+" < ?", *filter.ExpiresBefore)
+	}
+	if filter.ExpiresAfter != nil {
+		query = query.Where(effectiveExpiry+" > ?", *filter.ExpiresAfter)
+	}
+	if filter.Expired != nil {
+		if *filter.Expired {
+			query = query.Where(effectiveExpiry + " < CURRENT_TIMESTAMP")
+		} else {
+			query = query.Where(effectiveExpiry + " >= CURRENT_TIMESTAMP")
+		}
+	}
+	if filter.MinQuantity != nil {
+		query = query.Where("quantity >= ?", *filter.MinQuantity)
+	}
+	if filter.MaxQuantity != nil {
+		query = query.Where("quantity <= ?", *filter.MaxQuantity)
+	}
+	if filter.InStock {
+		query = query.Where("quantity > 0")
+	}
+	query, err := orderFoodItems(query, filter.Sort, filter.Cursor)
+	if err != nil {
+		return err
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Find(foodItems).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fr *foodItemRepository) GetFoodItemById(foodItem *model.FoodItem, id uint) error {
-	if err := fr.db.First(foodItem, id).Error; err != nil {
+// orderFoodItems は並び順を指定し、cursor があればその次の行から取得する条件を加える。
+// 同じ値の行は ID 順に並べる。賞味期限順は開封後の期限を考慮した期限で並べる。
+func orderFoodItems(query *gorm.DB, sort model.FoodItemSort, cursor *model.FoodItemCursor) (*gorm.DB, error) {
+	sort = sort.OrDefault()
+	if !sort.Field.IsValid() {
+		return nil, fmt.Errorf("invalid sort field: %s", sort.Field)
+	}
+	column := string(sort.Field)
+	orderColumn := clause.Column{Name: column}
+	if sort.Field == model.SortByExpiryDate {
+		column = effectiveExpiry
+		orderColumn = clause.Column{Name: effectiveExpiry, Raw: true}
+	}
+	if cursor != nil {
+		value, err := cursor.SortValue()
+		if err != nil {
+			return nil, err
+		}
+		op := ">"
+		if sort.Desc {
+			op = "<"
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, op), value, cursor.ID)
+	}
+	return query.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: orderColumn, Desc: sort.Desc},
+		{Column: clause.Column{Name: "id"}, Desc: sort.Desc},
+	}}), nil
+}
+
+// escapeLike は LIKE のワイルドカードを文字として扱うようにエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (fr *foodItemRepository) GetFoodItemById(foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	if err := fr.db.Preload("Tags").Preload("Category.Parent").Preload("Lots", orderLots).Where(memberHouseholds, userId).First(foodItem, foodItemId).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// ResolveHousehold は食材を登録する世帯を求める。householdId が 0 の場合は CreateFoodItem と同じく
+// 個人の世帯を設定し、所属していない世帯を指定した場合は gorm.ErrRecordNotFound を返す。
+func (fr *foodItemRepository) ResolveHousehold(householdId *uint, userId uint) error {
+	return resolveHousehold(fr.db, householdId, userId)
+}
+
+// GetHouseholdMemberIds は食材の世帯のメンバーのユーザー ID を取得する。
+// 削除した直後の食材でも世帯を調べられるよう、ごみ箱にある食材も対象にする。
+func (fr *foodItemRepository) GetHouseholdMemberIds(userIds *[]uint, foodItemId uint) error {
+	if err := fr.db.Model(&model.HouseholdMember{}).
+		Where("household_id = (SELECT household_id FROM food_items WHERE id=?)", foodItemId).
+		Order("user_id").
+		Pluck("user_id", userIds).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateFoodItem は食材を作成する。foodItem.Tags は既存のタグであること。
+// foodItem.Lots に設定したロットも同時に作成される。
+// foodItem.HouseholdId が 0 の場合は登録したユーザーの個人の世帯に作成し、
+// 所属していない世帯を指定した場合は gorm.ErrRecordNotFound を、他の世帯の保管場所を指定した場合は
+// model.ErrLocationHouseholdMismatch を返す。価格を設定したロットは購入の記録も同じトランザクションで作成する。
 func (fr *foodItemRepository) CreateFoodItem(foodItem *model.FoodItem) error {
-	if err := fr.db.Create(foodItem).Error; err != nil {
+	if err := resolveHousehold(fr.db, &foodItem.HouseholdId, foodItem.UserId); err != nil {
+		return err
+	}
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkLocationHousehold(tx, foodItem.StorageLocationId, foodItem.HouseholdId); err != nil {
+			return err
+		}
+		if err := tx.Create(foodItem).Error; err != nil {
+			return err
+		}
+		return recordPurchases(tx, foodItem, foodItem.Lots, foodItem.UserId)
+	})
+}
+
+// CreateFoodItems は複数の食材をまとめて作成する。1 件でも失敗した場合は何も作成しない。
+// foodItems[i].Tags は名前のみでよく、存在しないタグは同じトランザクションで作成する。
+func (fr *foodItemRepository) CreateFoodItems(foodItems []model.FoodItem, userId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		for i := range foodItems {
+			foodItem := &foodItems[i]
+			names := make([]string, 0, len(foodItem.Tags))
+			for _, tag := range foodItem.Tags {
+				names = append(names, tag.Name)
+			}
+			tags := []model.Tag{}
+			if err := getOrCreateTags(tx, &tags, userId, names); err != nil {
+				return err
+			}
+			foodItem.UserId = userId
+			foodItem.Tags = tags
+			if err := resolveHousehold(tx, &foodItem.HouseholdId, userId); err != nil {
+				return err
+			}
+			if err := checkLocationHousehold(tx, foodItem.StorageLocationId, foodItem.HouseholdId); err != nil {
+				return err
+			}
+			if err := tx.Create(foodItem).Error; err != nil {
+				return err
+			}
+			if err := recordPurchases(tx, foodItem, foodItem.Lots, userId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateFoodItem は所属する世帯の食材のうちバージョン（foodItem.Version）が一致する行のみを更新し、バージョンを進める。
+// 数量・単位・賞味期限はロットから集計するため更新しない。
+// foodItem.Tags が nil でない場合は userId のユーザーのタグを置き換える。タグはユーザーごとのため、
+// 世帯の他のメンバーが付けたタグは残し、同じ名前のタグがすでに付いている場合は重ねて付けない。
+// 更新後は foodItem.Tags にメンバー全員のタグを読み込む。
+// 他の世帯の食材や存在しない食材、バージョンが一致しない場合は gorm.ErrRecordNotFound を返す。
+func (fr *foodItemRepository) UpdateFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	if foodItem.Tags == nil {
+		if err := updateFoodItemColumns(fr.db, foodItem, userId, foodItemId); err != nil {
+			return err
+		}
+		return fr.db.Model(foodItem).Association("Tags").Find(&foodItem.Tags)
+	}
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateFoodItemColumns(tx, foodItem, userId, foodItemId); err != nil {
+			return err
+		}
+		if err := replaceOwnTags(tx, foodItem, userId); err != nil {
+			return err
+		}
+		return tx.Model(foodItem).Association("Tags").Find(&foodItem.Tags)
+	})
+}
+
+// replaceOwnTags は食材に付いている userId のユーザーのタグを foodItem.Tags に置き換える。
+// 他のメンバーのタグと同じ名前のタグは付けない
+func replaceOwnTags(tx *gorm.DB, foodItem *model.FoodItem, userId uint) error {
+	if err := tx.Exec("DELETE FROM food_item_tags WHERE food_item_id = ? AND tag_id IN (SELECT id FROM tags WHERE user_id = ?)", foodItem.ID, userId).Error; err != nil {
+		return err
+	}
+	others := []string{}
+	if err := tx.Model(&model.Tag{}).
+		Joins("JOIN food_item_tags ON food_item_tags.tag_id = tags.id").
+		Where("food_item_tags.food_item_id = ?", foodItem.ID).
+		Pluck("tags.name", &others).Error; err != nil {
+		return err
+	}
+	tags := []model.Tag{}
+	for _, tag := range foodItem.Tags {
+		if !slices.Contains(others, tag.Name) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tx.Model(foodItem).Association("Tags").Append(tags)
+}
+
+func updateFoodItemColumns(db *gorm.DB, foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	result := db.Model(foodItem).Clauses(clause.Returning{}).Where("id=? AND version=? AND "+memberHouseholds, foodItemId, foodItem.Version, userId).Updates(map[string]interface{}{
+		"title":       foodItem.Title,
+		"category_id": foodItem.CategoryId,
+		"version":     nextVersion,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteFoodItem は所属する世帯の食材をごみ箱に移動する（deleted_at を設定する）。
+// ロットや履歴は残り、RestoreFoodItem で元に戻せる。
+func (fr *foodItemRepository) DeleteFoodItem(userId uint, foodItemId uint) error {
+	result := fr.db.Where("id=? AND "+memberHouseholds, foodItemId, userId).Delete(&model.FoodItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetTrashedFoodItems はごみ箱にある食材を削除日時の新しい順に取得する
+func (fr *foodItemRepository) GetTrashedFoodItems(foodItems *[]model.FoodItem, userId uint) error {
+	if err := fr.db.Unscoped().Preload("Tags").Preload("Category.Parent").Preload("Lots", orderLots).
+		Where(memberHouseholds+" AND deleted_at IS NOT NULL", userId).
+		Order("deleted_at DESC, id DESC").
+		Find(foodItems).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreFoodItem はごみ箱にある食材を元に戻し、戻した食材を foodItem に読み込む。
+// ごみ箱にない食材や他の世帯の食材の場合は gorm.ErrRecordNotFound を返す。
+func (fr *foodItemRepository) RestoreFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	result := fr.db.Unscoped().Model(&model.FoodItem{}).
+		Where("id=? AND deleted_at IS NOT NULL AND "+memberHouseholds, foodItemId, userId).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return fr.GetFoodItemById(foodItem, userId, foodItemId)
+}
+
+// PurgeFoodItems は deletedBefore より前にごみ箱に移動した食材を完全に削除し、削除した件数を返す。
+// ロット・タグの関連・通知は外部キーにより一緒に削除され、在庫の履歴と廃棄の記録は残る。
+func (fr *foodItemRepository) PurgeFoodItems(deletedBefore time.Time) (int64, error) {
+	result := fr.db.Unscoped().Where("deleted_at < ?", deletedBefore).Delete(&model.FoodItem{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// GetFoodItemsByTitles は名前が一致する食材の名前・数量・単位を取得する。在庫の集計に使う。
+func (fr *foodItemRepository) GetFoodItemsByTitles(foodItems *[]model.FoodItem, userId uint, titles []string) error {
+	if err := fr.db.Select("id", "title", "quantity", "unit").
+		Where(memberHouseholds+" AND title IN ?", userId, titles).
+		Find(foodItems).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// MoveFoodItem は食材の保管場所を変更し、移動履歴を同じトランザクションで記録する。
+// move.ToLocationId と move.ToType は呼び出し側で設定しておくこと。
+// move.StateTransition がある場合は保存状態と期限も変更し、変更履歴を記録する。
+// 行ロックを取得した時点の状態が move.StateTransition.FromState と異なる場合は model.ErrInvalidStateTransition を返す。
+// 食材と異なる世帯の保管場所を指定した場合は model.ErrLocationHouseholdMismatch を返す。
+func (fr *foodItemRepository) MoveFoodItem(move *model.LocationMove, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		foodItem := model.FoodItem{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("StorageLocation").Where(memberHouseholds, userId).First(&foodItem, foodItemId).Error; err != nil {
+			return err
+		}
+		if err := checkLocationHousehold(tx, move.ToLocationId, foodItem.HouseholdId); err != nil {
+			return err
+		}
+		move.FoodItemId = foodItem.ID
+		move.FromLocationId = foodItem.StorageLocationId
+		if foodItem.StorageLocation != nil {
+			move.FromType = foodItem.StorageLocation.Type
+		}
+		move.UserId = userId
+		move.MovedAt = time.Now()
+		updates := map[string]interface{}{
+			"storage_location_id": move.ToLocationId,
+			"version":             nextVersion,
+		}
+		if transition := move.StateTransition; transition != nil {
+			if foodItem.State.OrDefault() != transition.FromState {
+				return model.ErrInvalidStateTransition
+			}
+			foodItem.State = transition.ToState
+			foodItem.StateExpiryDate = &transition.ExpiryAfter
+			updates["state"] = foodItem.State
+			updates["state_expiry_date"] = foodItem.StateExpiryDate
+			updates["effective_expiry"] = foodItem.EffectiveExpiryDate()
+		}
+		if err := tx.Model(&foodItem).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(move).Error; err != nil {
+			return err
+		}
+		if transition := move.StateTransition; transition != nil {
+			transition.FoodItemId = foodItem.ID
+			transition.UserId = userId
+			return tx.Create(transition).Error
+		}
+		return nil
+	})
+}
+
+// OpenFoodItem は食材の開封日時と開封後の日数（foodItem.OpenedAt と foodItem.OpenedShelfLifeDays）を保存し、
+// バージョンを進める。他の世帯の食材や存在しない食材の場合は gorm.ErrRecordNotFound を返す。
+func (fr *foodItemRepository) OpenFoodItem(foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		return openFoodItem(tx, foodItem, userId, foodItemId)
+	})
+}
+
+// openFoodItem は行ロックを取得してから開封を保存する。
+// 読み込んだ後に冷凍・調理などで変わった賞味期限と保存状態を foodItem に反映し、実質の期限を計算し直す。
+func openFoodItem(tx *gorm.DB, foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	current := model.FoodItem{}
+	if err := lockFoodItem(tx, &current, userId, foodItemId); err != nil {
 		return err
 	}
+	foodItem.ExpiryDate = current.ExpiryDate
+	foodItem.State = current.State
+	foodItem.StateExpiryDate = current.StateExpiryDate
+	return tx.Model(foodItem).Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}, {Name: "updated_at"}}}).
+		Updates(map[string]interface{}{
+			"opened_at":              foodItem.OpenedAt,
+			"opened_shelf_life_days": foodItem.OpenedShelfLifeDays,
+			"effective_expiry":       foodItem.EffectiveExpiryDate(),
+			"version":                nextVersion,
+		}).Error
+}
+
+// UpdateFoodItemNutrition は所属する世帯の食材の栄養成分を foodItem.Nutrition に置き換え、バージョンを進める。
+// 他の世帯の食材や存在しない食材の場合は gorm.ErrRecordNotFound を返す。
+func (fr *foodItemRepository) UpdateFoodItemNutrition(foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	nutrition := foodItem.Nutrition
+	result := fr.db.Model(foodItem).Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}, {Name: "updated_at"}}}).
+		Where("id=? AND "+memberHouseholds, foodItemId, userId).
+		Updates(map[string]interface{}{
+			"nutrition_basis":        nutrition.Basis,
+			"nutrition_source":       nutrition.Source,
+			"nutrition_kcal":         nutrition.Kcal,
+			"nutrition_protein":      nutrition.Protein,
+			"nutrition_fat":          nutrition.Fat,
+			"nutrition_carbohydrate": nutrition.Carbohydrate,
+			"nutrition_salt":         nutrition.Salt,
+			"version":                nextVersion,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
 	return nil
 }
 
-func (fr *foodItemRepository) UpdateFoodItem(foodItem *model.FoodItem) error {
-	if err := fr.db.Save(foodItem).Error; err != nil {
+// ChangeFoodState は食材の保存状態と期限（foodItem.State と foodItem.StateExpiryDate）を保存し、
+// 変更履歴 transition を同じトランザクションで記録する。
+// 行ロックを取得した時点の状態が transition.FromState と異なる場合は model.ErrInvalidStateTransition を返す。
+func (fr *foodItemRepository) ChangeFoodState(foodItem *model.FoodItem, transition *model.FoodStateTransition, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		current := model.FoodItem{}
+		if err := lockFoodItem(tx, &current, userId, foodItemId); err != nil {
+			return err
+		}
+		if current.State.OrDefault() != transition.FromState {
+			return model.ErrInvalidStateTransition
+		}
+		if err := tx.Model(foodItem).Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}, {Name: "updated_at"}}}).
+			Updates(map[string]interface{}{
+				"state":             foodItem.State,
+				"state_expiry_date": foodItem.StateExpiryDate,
+				"effective_expiry":  foodItem.EffectiveExpiryDate(),
+				"version":           nextVersion,
+			}).Error; err != nil {
+			return err
+		}
+		transition.FoodItemId = current.ID
+		transition.UserId = userId
+		return tx.Create(transition).Error
+	})
+}
+
+// GetFoodStateTransitions は食材の保存状態の変更履歴を新しい順に取得する。
+func (fr *foodItemRepository) GetFoodStateTransitions(transitions *[]model.FoodStateTransition, userId uint, foodItemId uint) error {
+	foodItem := model.FoodItem{}
+	if err := fr.db.Select("id").Where(memberHouseholds, userId).First(&foodItem, foodItemId).Error; err != nil {
+		return err
+	}
+	if err := fr.db.Where("food_item_id=?", foodItem.ID).Order("transitioned_at DESC, id DESC").Find(transitions).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fr *foodItemRepository) DeleteFoodItem(id uint) error {
-	if err := fr.db.Delete(&model.FoodItem{}, id).Error; err != nil {
+// GetFoodLots は食材のロットを賞味期限の早い順に取得する。
+func (fr *foodItemRepository) GetFoodLots(lots *[]model.FoodLot, userId uint, foodItemId uint) error {
+	foodItem := model.FoodItem{}
+	if err := fr.db.Select("id").Where(memberHouseholds, userId).First(&foodItem, foodItemId).Error; err != nil {
+		return err
+	}
+	if err := orderLots(fr.db).Where("food_item_id=?", foodItem.ID).Find(lots).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// RestockFoodItem は食材にロットを追加し、食材の数量と賞味期限を集計し直す。
+// lot.Quantity は食材の単位に換算済みであること。増減の記録 movement も同じトランザクションで作成する。
+// 更新後の食材を foodItem に格納する。価格を設定したロットは購入の記録も作成する。
+func (fr *foodItemRepository) RestockFoodItem(foodItem *model.FoodItem, lot *model.FoodLot, movement *model.InventoryMovement, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockFoodItem(tx, foodItem, userId, foodItemId); err != nil {
+			return err
+		}
+		lot.FoodItemId = foodItem.ID
+		if err := tx.Create(lot).Error; err != nil {
+			return err
+		}
+		if err := syncFoodItemStock(tx, foodItem); err != nil {
+			return err
+		}
+		if err := recordPurchases(tx, foodItem, []model.FoodLot{*lot}, userId); err != nil {
+			return err
+		}
+		return recordMovement(tx, movement, foodItem, userId)
+	})
+}
+
+// ConsumeFoodItem は賞味期限の早いロットから -movement.Delta を消費する（先入れ先出し）。
+// movement.Delta は食材の単位に換算済みの負の値であること。使い切ったロットは削除し、
+// deleteWhenEmpty が true で在庫がなくなった場合は食材も削除する。
+// 在庫が足りない場合は model.ErrInsufficientStock を返す。
+func (fr *foodItemRepository) ConsumeFoodItem(foodItem *model.FoodItem, movement *model.InventoryMovement, deleteWhenEmpty bool, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := withdrawStock(tx, foodItem, movement, nil, userId, foodItemId); err != nil {
+			return err
+		}
+		return deleteIfEmpty(tx, foodItem, deleteWhenEmpty)
+	})
+}
+
+// DiscardFoodItem は ConsumeFoodItem と同じくロットから差し引き、廃棄の記録 waste を作成する。
+// waste.Quantity は食材の単位に換算済みであること。waste.EstimatedCost が nil の場合は、
+// 差し引いたロットの単価から金額を求める（単価のないロットを含む場合は nil のまま）。
+func (fr *foodItemRepository) DiscardFoodItem(foodItem *model.FoodItem, movement *model.InventoryMovement, waste *model.WasteRecord, deleteWhenEmpty bool, userId uint, foodItemId uint) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := withdrawStock(tx, foodItem, movement, waste, userId, foodItemId); err != nil {
+			return err
+		}
+		waste.FoodItemId = &foodItem.ID
+		waste.Title = foodItem.Title
+		waste.CategoryId = foodItem.CategoryId
+		waste.Unit = foodItem.Unit
+		waste.UserId = userId
+		if err := tx.Create(waste).Error; err != nil {
+			return err
+		}
+		return deleteIfEmpty(tx, foodItem, deleteWhenEmpty)
+	})
+}
+
+// withdrawStock は行ロックを取得したうえで賞味期限の早いロットから -movement.Delta を差し引き、
+// 食材の在庫を集計し直して増減を記録する。waste を指定した場合は金額の未設定を差し引いた分の金額で補う
+func withdrawStock(tx *gorm.DB, foodItem *model.FoodItem, movement *model.InventoryMovement, waste *model.WasteRecord, userId uint, foodItemId uint) error {
+	if err := lockFoodItem(tx, foodItem, userId, foodItemId); err != nil {
+		return err
+	}
+	lots := []model.FoodLot{}
+	if err := orderLots(tx.Clauses(clause.Locking{Strength: "UPDATE"})).Where("food_item_id=?", foodItem.ID).Find(&lots).Error; err != nil {
+		return err
+	}
+	if waste != nil && waste.EstimatedCost == nil {
+		if cost, ok := model.WithdrawnCost(lots, -movement.Delta); ok {
+			waste.EstimatedCost, waste.Currency = &cost.Amount, cost.Currency
+		}
+	}
+	if err := model.ConsumeFIFO(lots, -movement.Delta); err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		if lot.Quantity > 0 {
+			if err := tx.Model(&lot).Update("quantity", lot.Quantity).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Delete(&lot).Error; err != nil {
+			return err
+		}
+	}
+	if err := syncFoodItemStock(tx, foodItem); err != nil {
+		return err
+	}
+	return recordMovement(tx, movement, foodItem, userId)
+}
+
+// deleteIfEmpty は deleteWhenEmpty が true で在庫がなくなった食材を削除する
+func deleteIfEmpty(tx *gorm.DB, foodItem *model.FoodItem, deleteWhenEmpty bool) error {
+	if deleteWhenEmpty && foodItem.Quantity <= 0 {
+		return tx.Delete(foodItem).Error
+	}
+	return nil
+}
+
+// GetInventoryMovements は食材の在庫の増減の記録を新しい順に取得する。
+func (fr *foodItemRepository) GetInventoryMovements(movements *[]model.InventoryMovement, userId uint, foodItemId uint) error {
+	foodItem := model.FoodItem{}
+	if err := fr.db.Select("id").Where(memberHouseholds, userId).First(&foodItem, foodItemId).Error; err != nil {
+		return err
+	}
+	if err := fr.db.Where("food_item_id=?", foodItem.ID).Order("created_at DESC, id DESC").Find(movements).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// recordMovement は在庫の増減を記録する。食材名と単位は記録時点のものを残す。
+func recordMovement(tx *gorm.DB, movement *model.InventoryMovement, foodItem *model.FoodItem, userId uint) error {
+	movement.FoodItemId = &foodItem.ID
+	movement.Title = foodItem.Title
+	movement.Unit = foodItem.Unit
+	movement.UserId = userId
+	return tx.Create(movement).Error
+}
+
+// recordPurchases は価格を設定したロットの購入を記録する。食材名・カテゴリ・単位は記録時点のものを残す。
+// 購入を記録済みのロット（lot.PurchaseRecorded）は記録しない。
+func recordPurchases(tx *gorm.DB, foodItem *model.FoodItem, lots []model.FoodLot, userId uint) error {
+	for _, lot := range lots {
+		if lot.Price == nil || lot.PurchaseRecorded {
+			continue
+		}
+		purchase := model.Purchase{
+			FoodItemId:  &foodItem.ID,
+			Title:       foodItem.Title,
+			CategoryId:  foodItem.CategoryId,
+			Quantity:    lot.Quantity,
+			Unit:        foodItem.Unit,
+			Price:       *lot.Price,
+			Currency:    lot.Currency,
+			UserId:      userId,
+			PurchasedAt: lot.PurchasedAt,
+		}
+		if err := tx.Create(&purchase).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLocationHousehold は保管場所が食材の世帯（householdId）のものであることを確認する。
+// 保管場所なし（locationId が nil）の場合は確認しない。
+func checkLocationHousehold(db *gorm.DB, locationId *uint, householdId uint) error {
+	if locationId == nil {
+		return nil
+	}
+	var count int64
+	if err := db.Model(&model.StorageLocation{}).Where("id=? AND household_id=?", *locationId, householdId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.ErrLocationHouseholdMismatch
+	}
+	return nil
+}
+
+// lockFoodItem は所属する世帯の食材を行ロックを取得して読み込む
+func lockFoodItem(tx *gorm.DB, foodItem *model.FoodItem, userId uint, foodItemId uint) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(memberHouseholds, userId).First(foodItem, foodItemId).Error
+}
+
+// syncFoodItemStock はロットから食材の数量と賞味期限を集計して保存する。
+// 在庫のあるロットがない場合、賞味期限は最後の値のまま残す。
+func syncFoodItemStock(tx *gorm.DB, foodItem *model.FoodItem) error {
+	if err := orderLots(tx).Where("food_item_id=?", foodItem.ID).Find(&foodItem.Lots).Error; err != nil {
+		return err
+	}
+	total, earliest, ok := model.SummarizeLots(foodItem.Lots)
+	foodItem.Quantity = total
+	if ok {
+		foodItem.ExpiryDate = earliest
+	}
+	return tx.Model(foodItem).Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}}}).Updates(map[string]interface{}{
+		"quantity":         foodItem.Quantity,
+		"expiry_date":      foodItem.ExpiryDate,
+		"effective_expiry": foodItem.EffectiveExpiryDate(),
+		"version":          nextVersion,
+	}).Error
+}
+
+// nextVersion は食材を変更するたびにバージョンを 1 つ進める
+var nextVersion = gorm.Expr("version + 1")
+
+// orderLots はロットを賞味期限の早い順に並べる
+func orderLots(db *gorm.DB) *gorm.DB {
+	return db.Order("expiry_date, id")
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB は SQL を実行せずに生成だけを行う DB を返す。
+// 実行された SQL は sqls に記録される。
+func newDryRunDB(t *testing.T, sqls *[]string) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	if err != nil {
+		t.Fatalf("DryRun用DBの作成に失敗: %v", err)
+	}
+	record := func(tx *gorm.DB) {
+		*sqls = append(*sqls, tx.Statement.SQL.String())
+	}
+	db.Callback().Query().After("gorm:query").Register("test:record_query", record)
+	db.Callback().Update().After("gorm:update").Register("test:record_update", record)
+	db.Callback().Delete().After("gorm:delete").Register("test:record_delete", record)
+	db.Callback().Raw().After("gorm:raw").Register("test:record_raw", record)
+	return db
+}
+
+func TestFoodItemRepository_ScopedToHousehold(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(fr IFoodItemRepository) error
+	}{
+		{
+			name: "一覧取得",
+			call: func(fr IFoodItemRepository) error {
+				return fr.GetAllFoodItems(&[]model.FoodItem{}, 1, model.FoodItemFilter{})
+			},
+		},
+		{
+			name: "ID指定の取得",
+			call: func(fr IFoodItemRepository) error {
+				return fr.GetFoodItemById(&model.FoodItem{}, 1, 2)
+			},
+		},
+		{
+			name: "更新",
+			call: func(fr IFoodItemRepository) error {
+				return fr.UpdateFoodItem(&model.FoodItem{Title: "りんご"}, 1, 2)
+			},
+		},
+		{
+			name: "削除",
+			call: func(fr IFoodItemRepository) error {
+				return fr.DeleteFoodItem(1, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sqls []string
+			fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+			_ = tt.call(fr)
+
+			// 認証済みユーザーが所属する世帯の食材に絞り込む
+			assert.Len(t, sqls, 1)
+			assert.Contains(t, sqls[0], "household_id IN (SELECT household_id FROM household_members WHERE user_id=")
+		})
+	}
+}
+
+func TestFoodItemRepository_NoRowsIsNotFound(t *testing.T) {
+	var sqls []string
+	fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+	// 所属していない世帯の食材は行が更新・削除されず、存在しない場合と同じエラーになる
+	assert.ErrorIs(t, fr.UpdateFoodItem(&model.FoodItem{Title: "りんご"}, 1, 2), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, fr.DeleteFoodItem(1, 2), gorm.ErrRecordNotFound)
+}
+
+func TestFoodItemRepository_GetAllFoodItems_SortAndCursor(t *testing.T) {
+	var sqls []string
+	fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+	sort := model.FoodItemSort{Field: model.SortByTitle, Desc: true}
+	cursor := model.NewFoodItemCursor(model.FoodItem{ID: 3, Title: "なす"}, sort)
+	_ = fr.GetAllFoodItems(&[]model.FoodItem{}, 1, model.FoodItemFilter{
+		Title:  "50%_off",
+		Sort:   sort,
+		Limit:  11,
+		Cursor: &cursor,
+	})
+
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "title ILIKE")
+	assert.Contains(t, sqls[0], "(title, id) < (")
+	assert.Contains(t, sqls[0], `ORDER BY "title" DESC,"id" DESC`)
+	assert.Contains(t, sqls[0], "LIMIT")
+}
+
+func TestFoodItemRepository_GetAllFoodItems_EffectiveExpiry(t *testing.T) {
+	var sqls []string
+	fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+	before := time.Now().AddDate(0, 0, 7)
+	expired := false
+	sort := model.FoodItemSort{}
+	cursor := model.NewFoodItemCursor(model.FoodItem{ID: 3, ExpiryDate: time.Now()}, sort)
+	_ = fr.GetAllFoodItems(&[]model.FoodItem{}, 1, model.FoodItemFilter{
+		ExpiresBefore: &before,
+		Expired:       &expired,
+		Cursor:        &cursor,
+	})
+
+	// 期限の絞り込みと並び替えは開封後の期限と保存状態の期限を考慮する
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "food_items.effective_expiry")
+	assert.Contains(t, sqls[0], effectiveExpiry+" < ")
+	assert.Contains(t, sqls[0], effectiveExpiry+" >= CURRENT_TIMESTAMP")
+	assert.Contains(t, sqls[0], "("+effectiveExpiry+", id) > (")
+	assert.Contains(t, sqls[0], "ORDER BY "+effectiveExpiry+`,"id"`)
+}
+
+func TestFoodItemRepository_GetAllFoodItems_InStock(t *testing.T) {
+	var sqls []string
+	fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+	_ = fr.GetAllFoodItems(&[]model.FoodItem{}, 1, model.FoodItemFilter{InStock: true})
+
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "quantity > 0")
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `50\%\_off\\`, escapeLike(`50%_off\`))
+}
+
+func TestFoodItemRepository_SoftDelete(t *testing.T) {
+	t.Run("削除はごみ箱への移動", func(t *testing.T) {
+		var sqls []string
+		fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+		_ = fr.DeleteFoodItem(1, 2)
+
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], `UPDATE "food_items" SET "deleted_at"`)
+	})
+
+	t.Run("一覧からごみ箱の食材を除外", func(t *testing.T) {
+		var sqls []string
+		fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+		_ = fr.GetAllFoodItems(&[]model.FoodItem{}, 1, model.FoodItemFilter{})
+
+		assert.Contains(t, sqls[0], `"food_items"."deleted_at" IS NULL`)
+	})
+
+	t.Run("ごみ箱の一覧", func(t *testing.T) {
+		var sqls []string
+		fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+		_ = fr.GetTrashedFoodItems(&[]model.FoodItem{}, 1)
+
+		assert.Contains(t, sqls[0], "user_id=")
+		assert.Contains(t, sqls[0], "deleted_at IS NOT NULL")
+		assert.NotContains(t, sqls[0], `"food_items"."deleted_at" IS NULL`)
+	})
+
+	t.Run("保持期間を過ぎた食材の完全削除", func(t *testing.T) {
+		var sqls []string
+		fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+		_, _ = fr.PurgeFoodItems(time.Now())
+
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], `DELETE FROM "food_items" WHERE deleted_at <`)
+	})
+}
+
+func TestFoodItemRepository_UpdateChecksVersion(t *testing.T) {
+	var sqls []string
+	fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+	err := fr.UpdateFoodItem(&model.FoodItem{Title: "りんご", Version: 4}, 1, 2)
+
+	// バージョンが一致しない行は更新されず、存在しない場合と同じエラーになる
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "version=")
+	assert.Contains(t, sqls[0], `"version"=version + 1`)
+}
+
+func TestReplaceOwnTags(t *testing.T) {
+	var sqls []string
+	db := newDryRunDB(t, &sqls)
+
+	err := replaceOwnTags(db, &model.FoodItem{ID: 2}, 1)
+
+	// 外すのは更新したユーザーのタグだけで、他のメンバーのタグは残す
+	assert.NoError(t, err)
+	assert.Len(t, sqls, 2)
+	assert.Contains(t, sqls[0], "DELETE FROM food_item_tags WHERE food_item_id = $1 AND tag_id IN (SELECT id FROM tags WHERE user_id = $2)")
+	assert.Contains(t, sqls[1], "food_item_tags.food_item_id = $1")
+}
+
+func TestFoodItemRepository_OpenFoodItem_StoresEffectiveExpiry(t *testing.T) {
+	var sqls []string
+	db := newDryRunDB(t, &sqls)
+
+	openedAt := time.Now()
+	days := 3
+	_ = openFoodItem(db, &model.FoodItem{ID: 2, ExpiryDate: openedAt.AddDate(0, 0, 30), OpenedAt: &openedAt, OpenedShelfLifeDays: &days}, 1, 2)
+
+	// 同時に行われた冷凍・調理の期限を上書きしないよう、行ロックを取得してから期限を計算し直す
+	assert.Len(t, sqls, 2)
+	assert.Contains(t, sqls[0], "FOR UPDATE")
+	// 開封後の期限で索引を使えるよう、計算した期限を列に保存する
+	assert.Contains(t, sqls[1], `"effective_expiry"=`)
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"fmt"
+	"go-rest-api/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// memberHouseholds は認証済みユーザーが所属する世帯のデータに絞り込む条件。引数はユーザー ID。
+// 食材・保管場所・タスクは所有者ではなく世帯で絞り込み、同じ世帯のメンバー同士で共有する。
+const memberHouseholds = "household_id IN (SELECT household_id FROM household_members WHERE user_id=?)"
+
+type IHouseholdRepository interface {
+	GetHouseholds(households *[]model.Household, userId uint) error
+	GetHouseholdById(household *model.Household, userId uint, householdId uint) error
+	CreateHousehold(household *model.Household, userId uint) error
+	UpdateHousehold(household *model.Household, userId uint, householdId uint) error
+	GetUsersWithoutHousehold(users *[]model.User) error
+	GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error)
+	UpdateMemberRole(householdId uint, userId uint, role model.HouseholdRole) error
+	RemoveMember(householdId uint, userId uint) error
+	TransferOwnership(householdId uint, fromUserId uint, toUserId uint) error
+	CreateInvitation(invitation *model.HouseholdInvitation) error
+	GetPendingInvitations(invitations *[]model.HouseholdInvitation, householdId uint, now time.Time) error
+	AcceptInvitation(invitation *model.HouseholdInvitation, tokenHash string, userId uint, now time.Time) error
+	DeclineInvitation(tokenHash string, userId uint, now time.Time) error
+}
+
+type householdRepository struct {
+	db *gorm.DB
+}
+
+func NewHouseholdRepository(db *gorm.DB) IHouseholdRepository {
+	return &householdRepository{db}
+}
+
+// GetHouseholds はユーザーが所属する世帯をメンバーとともに作成順に取得する
+func (hr *householdRepository) GetHouseholds(households *[]model.Household, userId uint) error {
+	if err := hr.db.Preload("Members", orderMembers).Preload("Members.User").
+		Where("id IN (SELECT household_id FROM household_members WHERE user_id=?)", userId).
+		Order("created_at, id").
+		Find(households).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (hr *householdRepository) GetHouseholdById(household *model.Household, userId uint, householdId uint) error {
+	if err := hr.db.Preload("Members", orderMembers).Preload("Members.User").
+		Where("id IN (SELECT household_id FROM household_members WHERE user_id=?)", userId).
+		First(household, householdId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateHousehold は世帯を作成し、userId のユーザーを所有者にする
+func (hr *householdRepository) CreateHousehold(household *model.Household, userId uint) error {
+	return hr.db.Transaction(func(tx *gorm.DB) error {
+		return createHousehold(tx, household, userId)
+	})
+}
+
+func (hr *householdRepository) UpdateHousehold(household *model.Household, userId uint, householdId uint) error {
+	result := hr.db.Model(household).Clauses(clause.Returning{}).
+		Where("id=? AND id IN (SELECT household_id FROM household_members WHERE user_id=?)", householdId, userId).
+		Update("name", household.Name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetUsersWithoutHousehold はどの世帯にも所属していないユーザーを取得する。
+// 世帯の導入前に登録したユーザーに個人の世帯を作成するために使う。
+func (hr *householdRepository) GetUsersWithoutHousehold(users *[]model.User) error {
+	if err := hr.db.Where("id NOT IN (SELECT user_id FROM household_members)").Order("id").Find(users).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMemberRole は resource の resourceId のデータが属する世帯での、ユーザーのロールを取得する。
+// データが存在しない、またはユーザーがその世帯に所属していない場合は gorm.ErrRecordNotFound を返す。
+func (hr *householdRepository) GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error) {
+	if !resource.IsValid() {
+		return "", fmt.Errorf("invalid household resource: %s", resource)
+	}
+	query := hr.db.Model(&model.HouseholdMember{}).Select("household_members.role").Where("household_members.user_id=?", userId)
+	if resource == model.ResourceHousehold {
+		query = query.Where("household_members.household_id=?", resourceId)
+	} else {
+		// ごみ箱にある食材の復元でも確認できるよう、削除済みのデータも対象にする
+		query = query.Joins(fmt.Sprintf("JOIN %s r ON r.household_id = household_members.household_id", resource)).Where("r.id=?", resourceId)
+	}
+	var roles []model.HouseholdRole
+	if err := query.Limit(1).Pluck("household_members.role", &roles).Error; err != nil {
+		return "", err
+	}
+	if len(roles) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return roles[0], nil
+}
+
+// UpdateMemberRole は所有者以外のメンバーのロールを変更する。
+// メンバーでない、または所有者の場合は gorm.ErrRecordNotFound を返す。
+func (hr *householdRepository) UpdateMemberRole(householdId uint, userId uint, role model.HouseholdRole) error {
+	result := hr.db.Model(&model.HouseholdMember{}).
+		Where("household_id=? AND user_id=? AND role<>?", householdId, userId, model.RoleOwner).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RemoveMember は所有者以外のメンバーを世帯から外す。
+// メンバーでない、または所有者の場合は gorm.ErrRecordNotFound を返す。
+func (hr *householdRepository) RemoveMember(householdId uint, userId uint) error {
+	result := hr.db.Where("household_id=? AND user_id=? AND role<>?", householdId, userId, model.RoleOwner).Delete(&model.HouseholdMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TransferOwnership は所有者 fromUserId から、同じ世帯のメンバー toUserId に所有権を移す。
+// 元の所有者は編集者になる。toUserId がメンバーでない場合は gorm.ErrRecordNotFound を返す。
+func (hr *householdRepository) TransferOwnership(householdId uint, fromUserId uint, toUserId uint) error {
+	return hr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.HouseholdMember{}).
+			Where("household_id=? AND user_id=? AND role=?", householdId, fromUserId, model.RoleOwner).
+			Update("role", model.RoleEditor)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return gorm.ErrRecordNotFound
+		}
+		result = tx.Model(&model.HouseholdMember{}).
+			Where("household_id=? AND user_id=?", householdId, toUserId).
+			Update("role", model.RoleOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (hr *householdRepository) CreateInvitation(invitation *model.HouseholdInvitation) error {
+	if err := hr.db.Create(invitation).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingInvitations は世帯の未回答で有効期限内の招待を作成の新しい順に取得する
+func (hr *householdRepository) GetPendingInvitations(invitations *[]model.HouseholdInvitation, householdId uint, now time.Time) error {
+	if err := hr.db.Where("household_id=? AND status=? AND expires_at > ?", householdId, model.InvitationPending, now).
+		Order("created_at DESC, id DESC").
+		Find(invitations).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation は招待を承諾済みにし、招待のロールでユーザーを世帯のメンバーにする。
+// 使用済み・期限切れ・存在しない招待の場合は gorm.ErrRecordNotFound を返す。
+// すでにメンバーの場合は model.ErrAlreadyHouseholdMember を返し、招待は未回答のまま残す。
+func (hr *householdRepository) AcceptInvitation(invitation *model.HouseholdInvitation, tokenHash string, userId uint, now time.Time) error {
+	return hr.db.Transaction(func(tx *gorm.DB) error {
+		if err := respondInvitation(tx, invitation, tokenHash, model.InvitationAccepted, userId, now); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&model.HouseholdMember{}).Where("household_id=? AND user_id=?", invitation.HouseholdId, userId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return model.ErrAlreadyHouseholdMember
+		}
+		return tx.Create(&model.HouseholdMember{HouseholdId: invitation.HouseholdId, UserId: userId, Role: invitation.Role}).Error
+	})
+}
+
+// DeclineInvitation は招待を辞退済みにする。
+// 使用済み・期限切れ・存在しない招待の場合は gorm.ErrRecordNotFound を返す。
+func (hr *householdRepository) DeclineInvitation(tokenHash string, userId uint, now time.Time) error {
+	return respondInvitation(hr.db, &model.HouseholdInvitation{}, tokenHash, model.InvitationDeclined, userId, now)
+}
+
+// respondInvitation は未回答で有効期限内の招待の状態を status にする。招待は 1 回だけ使える。
+func respondInvitation(db *gorm.DB, invitation *model.HouseholdInvitation, tokenHash string, status model.InvitationStatus, userId uint, now time.Time) error {
+	result := db.Model(invitation).Clauses(clause.Returning{}).
+		Where("token_hash=? AND status=? AND expires_at > ?", tokenHash, model.InvitationPending, now).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_by": userId,
+			"responded_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func createHousehold(tx *gorm.DB, household *model.Household, userId uint) error {
+	if err := tx.Create(household).Error; err != nil {
+		return err
+	}
+	member := model.HouseholdMember{HouseholdId: household.ID, UserId: userId, Role: model.RoleOwner}
+	if err := tx.Create(&member).Error; err != nil {
+		return err
+	}
+	household.Members = []model.HouseholdMember{member}
+	return nil
+}
+
+// resolveHousehold は作成するデータの世帯を決める。
+// *householdId が 0 の場合はユーザーが編集できる世帯のうち最初に所属した世帯（通常は個人の世帯）を設定し、
+// 指定されている場合はユーザーがその世帯に所属していることを確認する。
+// 所属していない場合は gorm.ErrRecordNotFound を、閲覧者として所属している場合は model.ErrHouseholdReadOnly を返す。
+func resolveHousehold(db *gorm.DB, householdId *uint, userId uint) error {
+	query := db.Where("user_id=?", userId)
+	if *householdId != 0 {
+		query = query.Where("household_id=?", *householdId)
+	} else {
+		query = query.Where("role<>?", model.RoleViewer)
+	}
+	member := model.HouseholdMember{}
+	if err := query.Order("created_at, household_id").First(&member).Error; err != nil {
+		return err
+	}
+	if !member.Role.Allows(model.RoleEditor) {
+		return model.ErrHouseholdReadOnly
+	}
+	*householdId = member.HouseholdId
+	return nil
+}
+
+// orderMembers は世帯のメンバーを所属した順に並べる
+func orderMembers(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at, user_id")
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestHouseholdRepository_ScopedToMember(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(hr IHouseholdRepository) error
+	}{
+		{
+			name: "一覧取得",
+			call: func(hr IHouseholdRepository) error {
+				return hr.GetHouseholds(&[]model.Household{}, 1)
+			},
+		},
+		{
+			name: "ID指定の取得",
+			call: func(hr IHouseholdRepository) error {
+				return hr.GetHouseholdById(&model.Household{}, 1, 2)
+			},
+		},
+		{
+			name: "名前の変更",
+			call: func(hr IHouseholdRepository) error {
+				return hr.UpdateHousehold(&model.Household{Name: "実家"}, 1, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sqls []string
+			hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+			_ = tt.call(hr)
+
+			assert.NotEmpty(t, sqls)
+			assert.Contains(t, sqls[0], "id IN (SELECT household_id FROM household_members WHERE user_id=")
+		})
+	}
+}
+
+func TestHouseholdRepository_UpdateNoRowsIsNotFound(t *testing.T) {
+	var sqls []string
+	hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+	assert.ErrorIs(t, hr.UpdateHousehold(&model.Household{Name: "実家"}, 1, 2), gorm.ErrRecordNotFound)
+}
+
+func TestResolveHousehold(t *testing.T) {
+	t.Run("指定がなければ最初に所属した世帯を使う", func(t *testing.T) {
+		var sqls []string
+		householdId := uint(0)
+
+		_ = resolveHousehold(newDryRunDB(t, &sqls), &householdId, 1)
+
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], `FROM "household_members" WHERE user_id=`)
+		assert.NotContains(t, sqls[0], "household_id=")
+		assert.Contains(t, sqls[0], "role<>")
+		assert.Contains(t, sqls[0], "ORDER BY created_at, household_id")
+	})
+
+	t.Run("指定された世帯に所属しているか確認する", func(t *testing.T) {
+		var sqls []string
+		householdId := uint(3)
+
+		_ = resolveHousehold(newDryRunDB(t, &sqls), &householdId, 1)
+
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], "user_id=")
+		assert.Contains(t, sqls[0], "household_id=")
+	})
+
+	t.Run("食材の作成前に世帯を確認する", func(t *testing.T) {
+		var sqls []string
+		fr := NewFoodItemRepository(newDryRunDB(t, &sqls))
+
+		_ = fr.CreateFoodItem(&model.FoodItem{Title: "牛乳", UserId: 1, HouseholdId: 3})
+
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], `FROM "household_members"`)
+	})
+}
+
+func TestHouseholdRepository_GetMemberRole(t *testing.T) {
+	t.Run("データの世帯でのロールを取得する", func(t *testing.T) {
+		var sqls []string
+		hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+		_, err := hr.GetMemberRole(1, model.ResourceFoodItem, 5)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Len(t, sqls, 1)
+		assert.Contains(t, sqls[0], "JOIN food_items r ON r.household_id = household_members.household_id")
+		assert.Contains(t, sqls[0], "household_members.user_id=")
+	})
+
+	t.Run("定義されていないデータの種類は使えない", func(t *testing.T) {
+		var sqls []string
+		hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+		_, err := hr.GetMemberRole(1, model.HouseholdResource("users; --"), 5)
+
+		assert.Error(t, err)
+		assert.Empty(t, sqls)
+	})
+}
+
+func TestHouseholdRepository_OwnerIsProtected(t *testing.T) {
+	var sqls []string
+	hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+	assert.ErrorIs(t, hr.UpdateMemberRole(2, 3, model.RoleViewer), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, hr.RemoveMember(2, 3), gorm.ErrRecordNotFound)
+
+	assert.Len(t, sqls, 2)
+	for _, sql := range sqls {
+		assert.Contains(t, sql, "role<>")
+	}
+}
+
+func TestHouseholdRepository_DeclineInvitation(t *testing.T) {
+	var sqls []string
+	hr := NewHouseholdRepository(newDryRunDB(t, &sqls))
+
+	err := hr.DeclineInvitation("hash", 3, time.Now())
+
+	// 未回答で有効期限内の招待のみ回答できる
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "token_hash=")
+	assert.Contains(t, sqls[0], "status=")
+	assert.Contains(t, sqls[0], "expires_at >")
+}
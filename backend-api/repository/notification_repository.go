@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type INotificationRepository interface {
+	GetExpiryAlertTargets(targets *[]model.ExpiryAlertTarget, now time.Time) error
+	CreateNotifications(notifications []model.Notification) (int64, error)
+	GetNotifications(notifications *[]model.Notification, userId uint, unreadOnly bool) error
+	MarkAsRead(userId uint, notificationId uint, readAt time.Time) error
+	MarkAllAsRead(userId uint, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) INotificationRepository {
+	return &notificationRepository{db}
+}
+
+// GetExpiryAlertTargets は全世帯の在庫のある食材を世帯のメンバーごとに確認し、
+// メンバーの通知日数の範囲に期限（開封後の期限を含む）が入っている（または過ぎている）食材とメンバーの組を取得する。
+func (nr *notificationRepository) GetExpiryAlertTargets(targets *[]model.ExpiryAlertTarget, now time.Time) error {
+	pairs := []struct {
+		FoodItemId uint
+		UserId     uint
+	}{}
+	if err := nr.db.Model(&model.FoodItem{}).
+		Select("food_items.id AS food_item_id, household_members.user_id").
+		Joins("JOIN household_members ON household_members.household_id = food_items.household_id").
+		Joins("LEFT JOIN user_settings ON user_settings.user_id = household_members.user_id").
+		Where("food_items.quantity > 0").
+		Where(effectiveExpiry+" < ?::timestamptz + COALESCE(user_settings.expiry_warning_days, ?) * INTERVAL '1 day'", now, model.DefaultExpiryWarningDays).
+		Order("food_items.id, household_members.user_id").
+		Find(&pairs).Error; err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	ids := []uint{}
+	for _, pair := range pairs {
+		ids = append(ids, pair.FoodItemId)
+	}
+	foodItems := []model.FoodItem{}
+	if err := nr.db.Where("id IN ?", ids).Find(&foodItems).Error; err != nil {
+		return err
+	}
+	byId := map[uint]model.FoodItem{}
+	for _, foodItem := range foodItems {
+		byId[foodItem.ID] = foodItem
+	}
+	for _, pair := range pairs {
+		if foodItem, ok := byId[pair.FoodItemId]; ok {
+			*targets = append(*targets, model.ExpiryAlertTarget{FoodItem: foodItem, UserId: pair.UserId})
+		}
+	}
+	return nil
+}
+
+// CreateNotifications は通知を作成する。作成済みの通知（同じユーザー・食材・種類・賞味期限）は無視し、
+// 新しく作成した件数を返す。
+func (nr *notificationRepository) CreateNotifications(notifications []model.Notification) (int64, error) {
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	result := nr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&notifications)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (nr *notificationRepository) GetNotifications(notifications *[]model.Notification, userId uint, unreadOnly bool) error {
+	query := nr.db.Where("user_id=?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("created_at DESC, id DESC").Find(notifications).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarkAsRead は通知を既読にする。既読の通知は既読日時を変更しない。
+func (nr *notificationRepository) MarkAsRead(userId uint, notificationId uint, readAt time.Time) error {
+	result := nr.db.Model(&model.Notification{}).
+		Where("id=? AND user_id=?", notificationId, userId).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (nr *notificationRepository) MarkAllAsRead(userId uint, readAt time.Time) error {
+	if err := nr.db.Model(&model.Notification{}).
+		Where("user_id=? AND read_at IS NULL", userId).
+		Update("read_at", readAt).Error; err != nil {
+		return err
+	}
+	return nil
+}
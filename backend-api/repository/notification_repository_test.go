@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationRepository_GetExpiryAlertTargets_PerMember(t *testing.T) {
+	var sqls []string
+	nr := NewNotificationRepository(newDryRunDB(t, &sqls))
+
+	err := nr.GetExpiryAlertTargets(&[]model.ExpiryAlertTarget{}, time.Now())
+
+	// 世帯のメンバーごとに、それぞれの通知日数で判定する
+	assert.NoError(t, err)
+	assert.Len(t, sqls, 1)
+	assert.Contains(t, sqls[0], "JOIN household_members ON household_members.household_id = food_items.household_id")
+	assert.Contains(t, sqls[0], "user_settings.user_id = household_members.user_id")
+	assert.Contains(t, sqls[0], effectiveExpiry+" < ")
+	assert.Contains(t, sqls[0], `"food_items"."deleted_at" IS NULL`)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IParLevelRepository interface {
+	GetAllParLevels(parLevels *[]model.ParLevel, userId uint) error
+	SaveParLevel(parLevel *model.ParLevel) error
+	UpdateParLevel(parLevel *model.ParLevel, userId uint, parLevelId uint) error
+	DeleteParLevel(userId uint, parLevelId uint) error
+}
+
+type parLevelRepository struct {
+	db *gorm.DB
+}
+
+func NewParLevelRepository(db *gorm.DB) IParLevelRepository {
+	return &parLevelRepository{db}
+}
+
+func (pr *parLevelRepository) GetAllParLevels(parLevels *[]model.ParLevel, userId uint) error {
+	if err := pr.db.Where("user_id=?", userId).Order("name").Find(parLevels).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveParLevel は最低在庫を登録する。同じ名前の最低在庫がある場合は数量と単位を置き換える。
+func (pr *parLevelRepository) SaveParLevel(parLevel *model.ParLevel) error {
+	if err := pr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min_quantity", "unit", "updated_at"}),
+	}, clause.Returning{}).Create(parLevel).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *parLevelRepository) UpdateParLevel(parLevel *model.ParLevel, userId uint, parLevelId uint) error {
+	result := pr.db.Model(parLevel).Clauses(clause.Returning{}).Where("id=? AND user_id=?", parLevelId, userId).Updates(map[string]interface{}{
+		"name":         parLevel.Name,
+		"min_quantity": parLevel.MinQuantity,
+		"unit":         parLevel.Unit,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (pr *parLevelRepository) DeleteParLevel(userId uint, parLevelId uint) error {
+	result := pr.db.Where("id=? AND user_id=?", parLevelId, userId).Delete(&model.ParLevel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestParLevelRepository_ScopedToUser(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(pr IParLevelRepository) error
+	}{
+		{
+			name: "一覧取得",
+			call: func(pr IParLevelRepository) error {
+				return pr.GetAllParLevels(&[]model.ParLevel{}, 1)
+			},
+		},
+		{
+			name: "更新",
+			call: func(pr IParLevelRepository) error {
+				return pr.UpdateParLevel(&model.ParLevel{Name: "卵", MinQuantity: 10}, 1, 2)
+			},
+		},
+		{
+			name: "削除",
+			call: func(pr IParLevelRepository) error {
+				return pr.DeleteParLevel(1, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sqls []string
+			pr := NewParLevelRepository(newDryRunDB(t, &sqls))
+
+			_ = tt.call(pr)
+
+			assert.Len(t, sqls, 1)
+			assert.Contains(t, sqls[0], "user_id=")
+		})
+	}
+}
+
+func TestParLevelRepository_NoRowsIsNotFound(t *testing.T) {
+	var sqls []string
+	pr := NewParLevelRepository(newDryRunDB(t, &sqls))
+
+	assert.ErrorIs(t, pr.UpdateParLevel(&model.ParLevel{Name: "卵"}, 1, 2), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, pr.DeleteParLevel(1, 2), gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IPurchaseRepository interface {
+	GetPurchases(purchases *[]model.Purchase, userId uint, from time.Time, to time.Time) error
+}
+
+type purchaseRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseRepository(db *gorm.DB) IPurchaseRepository {
+	return &purchaseRepository{db}
+}
+
+// GetPurchases は from 以上 to 未満に購入した記録を古い順に取得する
+func (pr *purchaseRepository) GetPurchases(purchases *[]model.Purchase, userId uint, from time.Time, to time.Time) error {
+	if err := pr.db.Preload("Category.Parent").
+		Where("user_id=? AND purchased_at >= ? AND purchased_at < ?", userId, from, to).
+		Order("purchased_at").
+		Find(purchases).Error; err != nil {
+		return err
+	}
+	return nil
+}
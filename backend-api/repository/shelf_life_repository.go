@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IShelfLifeRepository interface {
+	GetShelfLifeOverride(override *model.ShelfLifeOverride, userId uint, ingredient string, locationType model.LocationType) error
+	SaveShelfLifeOverride(override *model.ShelfLifeOverride) error
+}
+
+type shelfLifeRepository struct {
+	db *gorm.DB
+}
+
+func NewShelfLifeRepository(db *gorm.DB) IShelfLifeRepository {
+	return &shelfLifeRepository{db}
+}
+
+// GetShelfLifeOverride は食材と保管場所の種類について学習した日持ちを取得する。ない場合は gorm.ErrRecordNotFound を返す
+func (sr *shelfLifeRepository) GetShelfLifeOverride(override *model.ShelfLifeOverride, userId uint, ingredient string, locationType model.LocationType) error {
+	if err := sr.db.Where("user_id=? AND ingredient=? AND location_type=?", userId, ingredient, locationType).First(override).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveShelfLifeOverride は学習した日持ちを作成または上書きする
+func (sr *shelfLifeRepository) SaveShelfLifeOverride(override *model.ShelfLifeOverride) error {
+	if err := sr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "ingredient"}, {Name: "location_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"days", "sample_count", "updated_at"}),
+	}, clause.Returning{}).Create(override).Error; err != nil {
+		return err
+	}
+	return nil
+}
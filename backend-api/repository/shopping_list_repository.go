@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IShoppingListRepository interface {
+	GetShoppingListItems(items *[]model.ShoppingListItem, userId uint) error
+	GetShoppingListItemById(item *model.ShoppingListItem, userId uint, itemId uint) error
+	CreateShoppingListItem(item *model.ShoppingListItem) error
+	UpdateShoppingListItem(item *model.ShoppingListItem, userId uint, itemId uint) error
+	DeleteShoppingListItem(userId uint, itemId uint) error
+	ReorderShoppingListItems(userId uint, itemIds []uint) error
+	PurchaseShoppingListItems(userId uint, itemIds []uint, foodItems []model.FoodItem) error
+}
+
+type shoppingListRepository struct {
+	db *gorm.DB
+}
+
+func NewShoppingListRepository(db *gorm.DB) IShoppingListRepository {
+	return &shoppingListRepository{db}
+}
+
+func (sr *shoppingListRepository) GetShoppingListItems(items *[]model.ShoppingListItem, userId uint) error {
+	if err := sr.db.Where("user_id=?", userId).Order("position, id").Find(items).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *shoppingListRepository) GetShoppingListItemById(item *model.ShoppingListItem, userId uint, itemId uint) error {
+	if err := sr.db.Where("user_id=?", userId).First(item, itemId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateShoppingListItem は項目をリストの末尾に追加する。
+// 同時に追加されて位置が重なった場合も、一覧は ID 順で並ぶ。
+func (sr *shoppingListRepository) CreateShoppingListItem(item *model.ShoppingListItem) error {
+	var last *int
+	if err := sr.db.Model(&model.ShoppingListItem{}).Where("user_id=?", item.UserId).Select("MAX(position)").Scan(&last).Error; err != nil {
+		return err
+	}
+	item.Position = 1
+	if last != nil {
+		item.Position = *last + 1
+	}
+	if err := sr.db.Create(item).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *shoppingListRepository) UpdateShoppingListItem(item *model.ShoppingListItem, userId uint, itemId uint) error {
+	result := sr.db.Model(item).Clauses(clause.Returning{}).Where("id=? AND user_id=?", itemId, userId).Updates(map[string]interface{}{
+		"name":     item.Name,
+		"quantity": item.Quantity,
+		"unit":     item.Unit,
+		"note":     item.Note,
+		"checked":  item.Checked,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (sr *shoppingListRepository) DeleteShoppingListItem(userId uint, itemId uint) error {
+	result := sr.db.Where("id=? AND user_id=?", itemId, userId).Delete(&model.ShoppingListItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReorderShoppingListItems は itemIds の順に位置を 1 から振り直す。
+// 他ユーザーの項目や存在しない項目が含まれる場合は gorm.ErrRecordNotFound を返し、何も変更しない。
+func (sr *shoppingListRepository) ReorderShoppingListItems(userId uint, itemIds []uint) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range itemIds {
+			result := tx.Model(&model.ShoppingListItem{}).Where("id=? AND user_id=?", id, userId).Update("position", i+1)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < 1 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// PurchaseShoppingListItems はチェック済みの項目をリストから削除し、foodItems を登録する。
+// 項目のいずれかが存在しない、またはチェックされていない場合は gorm.ErrRecordNotFound を返し、何も変更しない。
+// 食材を登録する世帯と異なる世帯の保管場所を指定した場合は model.ErrLocationHouseholdMismatch を返す。
+func (sr *shoppingListRepository) PurchaseShoppingListItems(userId uint, itemIds []uint, foodItems []model.FoodItem) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id IN ? AND user_id=? AND checked", itemIds, userId).Delete(&model.ShoppingListItem{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(itemIds)) {
+			return gorm.ErrRecordNotFound
+		}
+		for i := range foodItems {
+			foodItems[i].UserId = userId
+			if err := resolveHousehold(tx, &foodItems[i].HouseholdId, userId); err != nil {
+				return err
+			}
+			if err := checkLocationHousehold(tx, foodItems[i].StorageLocationId, foodItems[i].HouseholdId); err != nil {
+				return err
+			}
+			if err := tx.Create(&foodItems[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
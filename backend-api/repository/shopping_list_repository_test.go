@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestShoppingListRepository_ScopedToUser(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(sr IShoppingListRepository) error
+	}{
+		{
+			name: "一覧取得",
+			call: func(sr IShoppingListRepository) error {
+				return sr.GetShoppingListItems(&[]model.ShoppingListItem{}, 1)
+			},
+		},
+		{
+			name: "ID指定の取得",
+			call: func(sr IShoppingListRepository) error {
+				return sr.GetShoppingListItemById(&model.ShoppingListItem{}, 1, 2)
+			},
+		},
+		{
+			name: "更新",
+			call: func(sr IShoppingListRepository) error {
+				return sr.UpdateShoppingListItem(&model.ShoppingListItem{Name: "卵"}, 1, 2)
+			},
+		},
+		{
+			name: "削除",
+			call: func(sr IShoppingListRepository) error {
+				return sr.DeleteShoppingListItem(1, 2)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sqls []string
+			sr := NewShoppingListRepository(newDryRunDB(t, &sqls))
+
+			_ = tt.call(sr)
+
+			assert.Len(t, sqls, 1)
+			assert.Contains(t, sqls[0], "user_id=")
+		})
+	}
+}
+
+func TestShoppingListRepository_NoRowsIsNotFound(t *testing.T) {
+	var sqls []string
+	sr := NewShoppingListRepository(newDryRunDB(t, &sqls))
+
+	assert.ErrorIs(t, sr.UpdateShoppingListItem(&model.ShoppingListItem{Name: "卵"}, 1, 2), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, sr.DeleteShoppingListItem(1, 2), gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IStorageLocationRepository interface {
+	GetAllStorageLocations(locations *[]model.StorageLocation, userId uint) error
+	GetStorageLocationById(location *model.StorageLocation, userId uint, locationId uint) error
+	CreateStorageLocation(location *model.StorageLocation) error
+	UpdateStorageLocation(location *model.StorageLocation, userId uint, locationId uint) error
+	DeleteStorageLocation(userId uint, locationId uint) error
+}
+
+type storageLocationRepository struct {
+	db *gorm.DB
+}
+
+func NewStorageLocationRepository(db *gorm.DB) IStorageLocationRepository {
+	return &storageLocationRepository{db}
+}
+
+func (sr *storageLocationRepository) GetAllStorageLocations(locations *[]model.StorageLocation, userId uint) error {
+	if err := sr.db.Where(memberHouseholds, userId).Order("created_at").Find(locations).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *storageLocationRepository) GetStorageLocationById(location *model.StorageLocation, userId uint, locationId uint) error {
+	if err := sr.db.Where(memberHouseholds, userId).First(location, locationId).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateStorageLocation は保管場所を作成する。location.HouseholdId が 0 の場合は登録したユーザーの個人の世帯に作成し、
+// 所属していない世帯を指定した場合は gorm.ErrRecordNotFound を返す。
+func (sr *storageLocationRepository) CreateStorageLocation(location *model.StorageLocation) error {
+	if err := resolveHousehold(sr.db, &location.HouseholdId, location.UserId); err != nil {
+		return err
+	}
+	if err := sr.db.Create(location).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *storageLocationRepository) UpdateStorageLocation(location *model.StorageLocation, userId uint, locationId uint) error {
+	result := sr.db.Model(location).Clauses(clause.Returning{}).Where("id=? AND "+memberHouseholds, locationId, userId).Updates(map[string]interface{}{
+		"name": location.Name,
+		"type": location.Type,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteStorageLocation は保管場所を削除する。
+// 保管されていた食材は外部キー制約により保管場所なしになる。
+func (sr *storageLocationRepository) DeleteStorageLocation(userId uint, locationId uint) error {
+	result := sr.db.Where("id=? AND "+memberHouseholds, locationId, userId).Delete(&model.StorageLocation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
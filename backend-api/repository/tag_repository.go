@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITagRepository interface {
+	GetAllTags(tags *[]model.Tag, userId uint) error
+	GetOrCreateTags(tags *[]model.Tag, userId uint, names []string) error
+	CreateTag(tag *model.Tag) error
+	UpdateTag(tag *model.Tag, userId uint, tagId uint) error
+	DeleteTag(userId uint, tagId uint) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) ITagRepository {
+	return &tagRepository{db}
+}
+
+func (tr *tagRepository) GetAllTags(tags *[]model.Tag, userId uint) error {
+	if err := tr.db.Where("user_id=?", userId).Order("name").Find(tags).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetOrCreateTags は名前に対応するユーザーのタグを返し、存在しないものは作成する
+func (tr *tagRepository) GetOrCreateTags(tags *[]model.Tag, userId uint, names []string) error {
+	return getOrCreateTags(tr.db, tags, userId, names)
+}
+
+func getOrCreateTags(db *gorm.DB, tags *[]model.Tag, userId uint, names []string) error {
+	if len(names) == 0 {
+		*tags = []model.Tag{}
+		return nil
+	}
+	newTags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		newTags = append(newTags, model.Tag{Name: name, UserId: userId})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+		return err
+	}
+	if err := db.Where("user_id=? AND name IN ?", userId, names).Order("name").Find(tags).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) CreateTag(tag *model.Tag) error {
+	if err := tr.db.Create(tag).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tr *tagRepository) UpdateTag(tag *model.Tag, userId uint, tagId uint) error {
+	result := tr.db.Model(tag).Clauses(clause.Returning{}).Where("id=? AND user_id=?", tagId, userId).Update("name", tag.Name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (tr *tagRepository) DeleteTag(userId uint, tagId uint) error {
+	result := tr.db.Where("id=? AND user_id=?", tagId, userId).Delete(&model.Tag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -25,20 +25,24 @@ func NewTaskRepository(db *gorm.DB) ITaskRepository {
 }
 
 func (tr *taskRepository) GetAllTasks(tasks *[]model.Task, userId uint) error {
-	if err := tr.db.Joins("User").Where("user_id=?", userId).Order("created_at").Find(tasks).Error; err != nil {
+	if err := tr.db.Joins("User").Where(memberHouseholds, userId).Order("created_at").Find(tasks).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
 func (tr *taskRepository) GetTaskById(task *model.Task, userId uint, taskId uint) error {
-	if err := tr.db.Joins("User").Where("user_id=?", userId).First(task, taskId).Error; err != nil {
+	if err := tr.db.Joins("User").Where(memberHouseholds, userId).First(task, taskId).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// CreateTask はタスクを作成する。task.HouseholdId が 0 の場合は作成したユーザーの個人の世帯に作成する。
 func (tr *taskRepository) CreateTask(task *model.Task) error {
+	if err := resolveHousehold(tr.db, &task.HouseholdId, task.UserId); err != nil {
+		return err
+	}
 	if err := tr.db.Create(task).Error; err != nil {
 		return err
 	}
@@ -46,7 +50,7 @@ func (tr *taskRepository) CreateTask(task *model.Task) error {
 }
 
 func (tr *taskRepository) UpdateTask(task *model.Task, userId uint, taskId uint) error {
-	result := tr.db.Model(task).Clauses(clause.Returning{}).Where("id=? AND user_id=?", taskId, userId).Update("title", task.Title)
+	result := tr.db.Model(task).Clauses(clause.Returning{}).Where("id=? AND "+memberHouseholds, taskId, userId).Update("title", task.Title)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -57,7 +61,7 @@ func (tr *taskRepository) UpdateTask(task *model.Task, userId uint, taskId uint)
 }
 
 func (tr *taskRepository) DeleteTask(userId uint, taskId uint) error {
-	result := tr.db.Where("id=? AND user_id=?", taskId, userId).Delete(&model.Task{})
+	result := tr.db.Where("id=? AND "+memberHouseholds, taskId, userId).Delete(&model.Task{})
 	if result.Error != nil {
 		return result.Error
 	}
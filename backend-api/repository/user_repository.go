@@ -26,9 +26,12 @@ func (ur *userRepository) GetUserByEmail(user *model.User, email string) error {
 	return nil
 }
 
+// CreateUser はユーザーを作成し、同じトランザクションで個人の世帯を作成する
 func (ur *userRepository) CreateUser(user *model.User) error {
-	if err := ur.db.Create(user).Error; err != nil {
-		return err
-	}
-	return nil
+	return ur.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return createHousehold(tx, &model.Household{Name: model.PersonalHouseholdName}, user.ID)
+	})
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+	"go-rest-api/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserSettingRepository interface {
+	GetUserSetting(setting *model.UserSetting, userId uint) error
+	SaveUserSetting(setting *model.UserSetting) error
+}
+
+type userSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSettingRepository(db *gorm.DB) IUserSettingRepository {
+	return &userSettingRepository{db}
+}
+
+// GetUserSetting はユーザー設定を取得する。保存されていない場合は初期値を返す。
+func (ur *userSettingRepository) GetUserSetting(setting *model.UserSetting, userId uint) error {
+	err := ur.db.Where("user_id=?", userId).First(setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		*setting = model.DefaultUserSetting(userId)
+		return nil
+	}
+	return err
+}
+
+// SaveUserSetting はユーザー設定を作成または上書きする
+func (ur *userSettingRepository) SaveUserSetting(setting *model.UserSetting) error {
+	if err := ur.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(setting).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"go-rest-api/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IWasteRepository interface {
+	GetWasteRecords(records *[]model.WasteRecord, userId uint, from time.Time, to time.Time) error
+}
+
+type wasteRepository struct {
+	db *gorm.DB
+}
+
+func NewWasteRepository(db *gorm.DB) IWasteRepository {
+	return &wasteRepository{db}
+}
+
+// GetWasteRecords は from 以上 to 未満に廃棄した記録を古い順に取得する
+func (wr *wasteRepository) GetWasteRecords(records *[]model.WasteRecord, userId uint, from time.Time, to time.Time) error {
+	if err := wr.db.Preload("Category.Parent").
+		Where("user_id=? AND discarded_at >= ? AND discarded_at < ?", userId, from, to).
+		Order("discarded_at").
+		Find(records).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"go-rest-api/controller"
+	"go-rest-api/model"
 	"net/http"
 	"os"
 
@@ -11,7 +12,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(tc controller.ITaskController, uc controller.IUserController, fc controller.IFoodItemController, rc controller.IRecipeController) *echo.Echo {
+func NewRouter(tc controller.ITaskController, uc controller.IUserController, fc controller.IFoodItemController, rc controller.IRecipeController, slc controller.IStorageLocationController, cc controller.ICategoryController, tgc controller.ITagController, usc controller.IUserSettingController, rpc controller.IReportController, nc controller.INotificationController, slic controller.IShoppingListController, plc controller.IParLevelController, hc controller.IHouseholdController, sfc controller.IShelfLifeController, hm controller.IHouseholdRoleMiddleware) *echo.Echo {
 	e := echo.New()
 
 	// CORSミドルウェアの設定を修正
@@ -19,6 +20,7 @@ func NewRouter(tc controller.ITaskController, uc controller.IUserController, fc
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"*"},
 		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           86400,
 	}))
@@ -50,7 +52,7 @@ func NewRouter(tc controller.ITaskController, uc controller.IUserController, fc
 	api.GET("/verify-token", uc.VerifyToken)
 
 	// タスク関連
-	tasks := api.Group("/tasks")
+	tasks := api.Group("/tasks", hm.ReadWrite(model.ResourceTask, "taskId"))
 	tasks.GET("", tc.GetAllTasks)
 	tasks.GET("/:taskId", tc.GetTaskById)
 	tasks.POST("", tc.CreateTask)
@@ -58,12 +60,109 @@ func NewRouter(tc controller.ITaskController, uc controller.IUserController, fc
 	tasks.DELETE("/:taskId", tc.DeleteTask)
 
 	// 食材関連
-	foodItems := api.Group("/food-items")
+	// 閲覧者は参照のみ。編集には世帯の編集者以上のロールが必要
+	foodItems := api.Group("/food-items", hm.ReadWrite(model.ResourceFoodItem, "id"))
 	foodItems.GET("", fc.GetAllFoodItems)
+	foodItems.GET("/export", fc.ExportFoodItems)
+	foodItems.GET("/trash", fc.GetTrashedFoodItems)
+	foodItems.GET("/nutrition-summary", fc.GetNutritionSummary)
 	foodItems.GET("/:id", fc.GetFoodItemById)
 	foodItems.POST("", fc.CreateFoodItem)
+	foodItems.POST("/import", fc.ImportFoodItems)
 	foodItems.PUT("/:id", fc.UpdateFoodItem)
+	foodItems.PATCH("/:id", fc.PatchFoodItem)
 	foodItems.DELETE("/:id", fc.DeleteFoodItem)
+	foodItems.POST("/:id/restore", fc.RestoreFoodItem)
+	foodItems.POST("/:id/move", fc.MoveFoodItem)
+	foodItems.POST("/:id/open", fc.OpenFoodItem)
+	foodItems.POST("/:id/freeze", fc.FreezeFoodItem)
+	foodItems.POST("/:id/thaw", fc.ThawFoodItem)
+	foodItems.POST("/:id/cook", fc.CookFoodItem)
+	foodItems.PUT("/:id/nutrition", fc.UpdateNutrition)
+	foodItems.DELETE("/:id/nutrition", fc.DeleteNutrition)
+	foodItems.GET("/:id/state-transitions", fc.GetFoodStateTransitions)
+	foodItems.GET("/:id/lots", fc.GetFoodLots)
+	foodItems.POST("/:id/restock", fc.RestockFoodItem)
+	foodItems.POST("/:id/consume", fc.ConsumeFoodItem)
+	foodItems.GET("/:id/movements", fc.GetInventoryMovements)
+	foodItems.POST("/:id/discard", fc.DiscardFoodItem)
+
+	// 保管場所関連
+	storageLocations := api.Group("/storage-locations", hm.ReadWrite(model.ResourceStorageLocation, "id"))
+	storageLocations.GET("", slc.GetAllStorageLocations)
+	storageLocations.GET("/:id", slc.GetStorageLocationById)
+	storageLocations.POST("", slc.CreateStorageLocation)
+	storageLocations.PUT("/:id", slc.UpdateStorageLocation)
+	storageLocations.DELETE("/:id", slc.DeleteStorageLocation)
+
+	// カテゴリ関連
+	api.GET("/categories", cc.GetCategories)
+
+	// タグ関連
+	tags := api.Group("/tags")
+	tags.GET("", tgc.GetAllTags)
+	tags.POST("", tgc.CreateTag)
+	tags.PUT("/:id", tgc.UpdateTag)
+	tags.DELETE("/:id", tgc.DeleteTag)
+
+	// 通知関連
+	notifications := api.Group("/notifications")
+	notifications.GET("", nc.GetNotifications)
+	notifications.POST("/read-all", nc.MarkAllAsRead)
+	notifications.POST("/:id/read", nc.MarkAsRead)
+
+	// 買い物リスト関連
+	shoppingList := api.Group("/shopping-list")
+	shoppingList.GET("", slic.GetShoppingListItems)
+	shoppingList.POST("", slic.CreateShoppingListItem)
+	shoppingList.PUT("/order", slic.ReorderShoppingListItems)
+	shoppingList.POST("/purchase", slic.PurchaseShoppingListItems)
+	shoppingList.PUT("/:id", slic.UpdateShoppingListItem)
+	shoppingList.DELETE("/:id", slic.DeleteShoppingListItem)
+
+	// 最低在庫関連
+	parLevels := api.Group("/par-levels")
+	parLevels.GET("", plc.GetAllParLevels)
+	parLevels.POST("", plc.SaveParLevel)
+	parLevels.PUT("/:id", plc.UpdateParLevel)
+	parLevels.DELETE("/:id", plc.DeleteParLevel)
+	api.GET("/restock-suggestions", plc.GetRestockSuggestions)
+
+	// 世帯関連
+	member := hm.Require(model.ResourceHousehold, "id", model.RoleViewer)
+	owner := hm.Require(model.ResourceHousehold, "id", model.RoleOwner)
+	households := api.Group("/households")
+	households.GET("", hc.GetHouseholds)
+	households.POST("", hc.CreateHousehold)
+	households.GET("/:id", hc.GetHouseholdById, member)
+	households.PUT("/:id", hc.UpdateHousehold, owner)
+	households.DELETE("/:id/membership", hc.LeaveHousehold, member)
+	households.POST("/:id/transfer", hc.TransferOwnership, owner)
+	households.PUT("/:id/members/:user_id", hc.UpdateMemberRole, owner)
+	households.DELETE("/:id/members/:user_id", hc.RemoveMember, owner)
+	households.GET("/:id/invitations", hc.GetInvitations, owner)
+	households.POST("/:id/invitations", hc.CreateInvitation, owner)
+	invitations := api.Group("/invitations")
+	invitations.POST("/:token/accept", hc.AcceptInvitation)
+	invitations.POST("/:token/decline", hc.DeclineInvitation)
+
+	// ユーザー設定
+	api.GET("/settings", usc.GetUserSetting)
+	api.PUT("/settings", usc.UpdateUserSetting)
+
+	// レポート関連
+	reports := api.Group("/reports")
+	reports.GET("/waste", rpc.GetWasteReport)
+	reports.GET("/spending", rpc.GetSpendingReport)
+
+	// レシートからの一括登録
+	receipts := api.Group("/receipts")
+	receipts.POST("/parse", fc.ParseReceipt)
+	receipts.POST("/commit", fc.CommitReceipt)
+
+	// 日持ちの目安
+	shelfLife := api.Group("/shelf-life")
+	shelfLife.GET("/estimate", sfc.EstimateShelfLife)
 
 	// レシピ関連
 	recipes := api.Group("/recipes")
@@ -0,0 +1,60 @@
+// Package scheduler はサーバーのプロセス内で定期的に実行する処理をまとめる
+package scheduler
+
+import (
+	"context"
+	"go-rest-api/usecase"
+	"log"
+	"time"
+)
+
+// DefaultExpiryAlertInterval は賞味期限の通知を確認する間隔の初期値
+const DefaultExpiryAlertInterval = time.Hour
+
+// ExpiryAlertScheduler は一定間隔で賞味期限の近い食材を確認し、通知を作成する
+type ExpiryAlertScheduler struct {
+	nu       usecase.INotificationUsecase
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewExpiryAlertScheduler(nu usecase.INotificationUsecase, interval time.Duration) *ExpiryAlertScheduler {
+	if interval <= 0 {
+		interval = DefaultExpiryAlertInterval
+	}
+	return &ExpiryAlertScheduler{nu: nu, interval: interval, now: time.Now}
+}
+
+// Start はバックグラウンドで定期実行を開始する。起動直後に一度実行し、ctx がキャンセルされると停止する。
+func (s *ExpiryAlertScheduler) Start(ctx context.Context) {
+	runEvery(ctx, s.interval, s.run)
+}
+
+// run は通知の作成を一度実行する。失敗しても次の実行で再試行されるので、ログだけ残す。
+func (s *ExpiryAlertScheduler) run() {
+	created, err := s.nu.GenerateExpiryAlerts(s.now())
+	if err != nil {
+		log.Printf("賞味期限の通知の作成に失敗しました: %v", err)
+		return
+	}
+	if created > 0 {
+		log.Printf("賞味期限の通知を%d件作成しました", created)
+	}
+}
+
+// runEvery は起動直後と interval ごとに fn をバックグラウンドで実行する。ctx がキャンセルされると停止する。
+func runEvery(ctx context.Context, interval time.Duration, fn func()) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		fn()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fn()
+			}
+		}
+	}()
+}
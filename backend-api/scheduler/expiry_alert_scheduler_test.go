@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"go-rest-api/model"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeNotificationUsecase は GenerateExpiryAlerts の呼び出し回数を数える
+type fakeNotificationUsecase struct {
+	calls atomic.Int32
+}
+
+func (f *fakeNotificationUsecase) GenerateExpiryAlerts(now time.Time) (int64, error) {
+	f.calls.Add(1)
+	return 0, nil
+}
+
+func (f *fakeNotificationUsecase) GetNotifications(userId uint, unreadOnly bool) ([]model.Notification, error) {
+	return nil, nil
+}
+
+func (f *fakeNotificationUsecase) MarkAsRead(userId uint, notificationId uint) error {
+	return nil
+}
+
+func (f *fakeNotificationUsecase) MarkAllAsRead(userId uint) error {
+	return nil
+}
+
+func TestExpiryAlertScheduler_Start(t *testing.T) {
+	nu := &fakeNotificationUsecase{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	NewExpiryAlertScheduler(nu, 10*time.Millisecond).Start(ctx)
+
+	// 起動直後の1回と、間隔ごとの実行
+	deadline := time.Now().Add(time.Second)
+	for nu.calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("通知の作成が定期的に実行されませんでした（%d回）", nu.calls.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// 停止後は実行されない
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	stopped := nu.calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	if nu.calls.Load() != stopped {
+		t.Fatal("キャンセル後も実行が続いています")
+	}
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// DefaultTrashRetention はごみ箱の食材を完全に削除するまでの期間の初期値
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// DefaultTrashPurgeInterval はごみ箱を確認する間隔の初期値
+	DefaultTrashPurgeInterval = time.Hour
+)
+
+// TrashPurger は一定期間ごみ箱にある食材を完全に削除する。usecase.IFoodItemUsecase が満たす。
+type TrashPurger interface {
+	PurgeTrashedFoodItems(deletedBefore time.Time) (int64, error)
+}
+
+// TrashPurgeScheduler は一定間隔でごみ箱を確認し、保持期間を過ぎた食材を完全に削除する
+type TrashPurgeScheduler struct {
+	purger    TrashPurger
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewTrashPurgeScheduler(purger TrashPurger, retention time.Duration, interval time.Duration) *TrashPurgeScheduler {
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+	if interval <= 0 {
+		interval = DefaultTrashPurgeInterval
+	}
+	return &TrashPurgeScheduler{purger: purger, retention: retention, interval: interval, now: time.Now}
+}
+
+// Start はバックグラウンドで定期実行を開始する。起動直後に一度実行し、ctx がキャンセルされると停止する。
+func (s *TrashPurgeScheduler) Start(ctx context.Context) {
+	runEvery(ctx, s.interval, s.run)
+}
+
+// run は保持期間を過ぎた食材の削除を一度実行する。失敗しても次の実行で再試行されるので、ログだけ残す。
+func (s *TrashPurgeScheduler) run() {
+	purged, err := s.purger.PurgeTrashedFoodItems(s.now().Add(-s.retention))
+	if err != nil {
+		log.Printf("ごみ箱の食材の削除に失敗しました: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("ごみ箱の食材を%d件削除しました", purged)
+	}
+}
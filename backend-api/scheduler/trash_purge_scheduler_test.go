@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeTrashPurger は PurgeTrashedFoodItems に渡された日時を記録する
+type fakeTrashPurger struct {
+	deletedBefore time.Time
+}
+
+func (f *fakeTrashPurger) PurgeTrashedFoodItems(deletedBefore time.Time) (int64, error) {
+	f.deletedBefore = deletedBefore
+	return 1, nil
+}
+
+func TestTrashPurgeScheduler_Run(t *testing.T) {
+	purger := &fakeTrashPurger{}
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	s := NewTrashPurgeScheduler(purger, 7*24*time.Hour, 0)
+	s.now = func() time.Time { return now }
+
+	s.run()
+
+	// 保持期間より前にごみ箱に移動した食材が対象
+	if want := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC); !purger.deletedBefore.Equal(want) {
+		t.Fatalf("deletedBefore = %v, want %v", purger.deletedBefore, want)
+	}
+	if s.interval != DefaultTrashPurgeInterval {
+		t.Fatalf("interval = %v, want %v", s.interval, DefaultTrashPurgeInterval)
+	}
+}
@@ -35,6 +35,33 @@ func NewGeminiService() (IGeminiService, error) {
 	}, nil
 }
 
+// formatFoodItem はプロンプトの食材リストの1行を組み立てる。
+// ロットが複数ある場合は、どの分がいつ期限を迎えるかが分かるようにロットごとの賞味期限を並べる。
+// 開封済みで開封後の期限がある場合は、その期限も併記する。
+// 冷凍・解凍・調理した食材は、状態と計算し直した期限を示す。
+func formatFoodItem(item model.FoodItem) string {
+	category := ""
+	if item.Category != nil {
+		category = "[" + item.Category.Path() + "]"
+	}
+	expiry := item.ExpiryDate.Format("2006/01/02")
+	if len(item.Lots) > 1 {
+		lots := make([]string, 0, len(item.Lots))
+		for _, lot := range item.Lots {
+			amount := model.Quantity{Amount: lot.Quantity, Unit: item.Unit}
+			lots = append(lots, fmt.Sprintf("%s（%s）", lot.ExpiryDate.Format("2006/01/02"), amount))
+		}
+		expiry = strings.Join(lots, ", ")
+	}
+	if item.StateExpiryDate != nil {
+		return fmt.Sprintf("- %s（%s）%s: %s、期限 %s\n", item.Title, item.Amount(), category, item.State.Label(), item.StateExpiryDate.Format("2006/01/02"))
+	}
+	if opened, ok := item.OpenedExpiryDate(); ok {
+		expiry += "、開封後の期限 " + opened.Format("2006/01/02")
+	}
+	return fmt.Sprintf("- %s（%s）%s: 賞味期限 %s\n", item.Title, item.Amount(), category, expiry)
+}
+
 func (s *geminiService) GenerateRecipe(foodItems []model.FoodItem) (string, error) {
 	if len(foodItems) == 0 {
 		return "", fmt.Errorf("食材が指定されていません")
@@ -45,8 +72,8 @@ func (s *geminiService) GenerateRecipe(foodItems []model.FoodItem) (string, erro
 	// 期限切れ間近の食材を抽出
 	var expiringItems []model.FoodItem
 	for _, item := range foodItems {
-		// 現在時刻と賞味期限の差を計算
-		timeUntilExpiry := item.ExpiryDate.Sub(time.Now())
+		// 現在時刻と期限（開封後の期限を含む）の差を計算
+		timeUntilExpiry := item.EffectiveExpiryDate().Sub(time.Now())
 		daysUntilExpiry := timeUntilExpiry.Hours() / 24
 		fmt.Printf("食材: %s, 期限まで: %.2f日\n", item.Title, daysUntilExpiry)
 
@@ -70,10 +97,7 @@ func (s *geminiService) GenerateRecipe(foodItems []model.FoodItem) (string, erro
 	promptBuilder.WriteString("以下の食材を使用した、栄養バランスの良いレシピを提案してください：\n\n")
 	promptBuilder.WriteString("【食材リスト】\n")
 	for _, item := range expiringItems {
-		promptBuilder.WriteString(fmt.Sprintf("- %s（%d個）: 賞味期限 %s\n",
-			item.Title,
-			item.Quantity,
-			item.ExpiryDate.Format("2006/01/02")))
+		promptBuilder.WriteString(formatFoodItem(item))
 	}
 	promptBuilder.WriteString("\n【条件】\n")
 	promptBuilder.WriteString("1. 上記の食材を優先的に使用すること\n")
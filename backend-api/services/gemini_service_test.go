@@ -108,7 +108,7 @@ func TestGeminiService_GenerateRecipe_LargeQuantity(t *testing.T) {
 			ID:         uint(i),
 			UserId:     userID,
 			Title:      "テスト食材",
-			Quantity:   i,
+			Quantity:   float64(i),
 			ExpiryDate: time.Now().AddDate(0, 0, 7),
 		})
 	}
@@ -168,3 +168,22 @@ func TestGeminiService_GenerateRecipe_Performance(t *testing.T) {
 	assert.NotEmpty(t, recipe)
 	assert.Less(t, duration.Seconds(), 2.0, "レシピ生成は2秒以内に完了すべき")
 }
+
+func TestFormatFoodItem_PerLotExpiry(t *testing.T) {
+	soon := time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)
+	later := time.Date(2026, 10, 27, 0, 0, 0, 0, time.Local)
+	item := model.FoodItem{
+		Title:      "牛乳",
+		Quantity:   1.5,
+		Unit:       model.UnitLiter,
+		ExpiryDate: soon,
+		Lots: []model.FoodLot{
+			{Quantity: 0.5, ExpiryDate: soon},
+			{Quantity: 1, ExpiryDate: later},
+		},
+	}
+
+	line := formatFoodItem(item)
+
+	assert.Equal(t, "- 牛乳（1.5L）: 賞味期限 2026/10/20（0.5L）, 2026/10/27（1L）\n", line)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+//go:generate mockgen -source=category_usecase.go -destination=../mock/category_usecase_mock.go -package=mock
+
+import (
+	"go-rest-api/model"
+	"go-rest-api/repository"
+)
+
+type ICategoryUsecase interface {
+	GetCategoryTree() ([]model.CategoryResponse, error)
+}
+
+type categoryUsecase struct {
+	cr repository.ICategoryRepository
+}
+
+func NewCategoryUsecase(cr repository.ICategoryRepository) ICategoryUsecase {
+	return &categoryUsecase{cr}
+}
+
+// GetCategoryTree はカテゴリを親子関係の木構造で返す
+func (cu *categoryUsecase) GetCategoryTree() ([]model.CategoryResponse, error) {
+	categories := []model.Category{}
+	if err := cu.cr.GetAllCategories(&categories); err != nil {
+		return nil, err
+	}
+	children := map[uint][]model.Category{}
+	roots := []model.Category{}
+	for _, v := range categories {
+		if v.ParentId == nil {
+			roots = append(roots, v)
+			continue
+		}
+		children[*v.ParentId] = append(children[*v.ParentId], v)
+	}
+	return buildCategoryTree(roots, children), nil
+}
+
+func buildCategoryTree(categories []model.Category, children map[uint][]model.Category) []model.CategoryResponse {
+	resCategories := []model.CategoryResponse{}
+	for _, v := range categories {
+		c := toCategoryResponse(v)
+		c.Children = buildCategoryTree(children[v.ID], children)
+		resCategories = append(resCategories, c)
+	}
+	return resCategories
+}
+
+func toCategoryResponse(category model.Category) model.CategoryResponse {
+	return model.CategoryResponse{
+		ID:       category.ID,
+		Code:     category.Code,
+		Name:     category.Name,
+		ParentId: category.ParentId,
+		// 親カテゴリから引き継ぐ日数は含めない（木構造で親の値を参照できる）
+		OpenedShelfLifeDays: category.OpenedShelfLifeDays,
+		FrozenShelfLifeDays: category.FrozenShelfLifeDays,
+		ThawedShelfLifeDays: category.ThawedShelfLifeDays,
+		CookedShelfLifeDays: category.CookedShelfLifeDays,
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"go-rest-api/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) GetAllCategories(categories *[]model.Category) error {
+	args := m.Called(categories)
+	if items, ok := args.Get(0).([]model.Category); ok {
+		*categories = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetCategoryById(category *model.Category, categoryId uint) error {
+	args := m.Called(category, categoryId)
+	if c, ok := args.Get(0).(model.Category); ok {
+		*category = c
+	}
+	return args.Error(1)
+}
+
+func (m *MockCategoryRepository) SeedCategories(defaults []model.DefaultCategory) error {
+	args := m.Called(defaults)
+	return args.Error(0)
+}
+
+func TestCategoryUsecase_GetCategoryTree(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	usecase := NewCategoryUsecase(mockRepo)
+
+	vegetables := uint(1)
+	mockRepo.On("GetAllCategories", mock.Anything).Return([]model.Category{
+		{ID: 1, Code: "vegetables", Name: "野菜"},
+		{ID: 2, Code: "vegetables.leafy", Name: "葉物野菜", ParentId: &vegetables},
+		{ID: 3, Code: "fruits", Name: "果物"},
+	}, nil)
+
+	tree, err := usecase.GetCategoryTree()
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "野菜", tree[0].Name)
+	assert.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "葉物野菜", tree[0].Children[0].Name)
+	assert.Empty(t, tree[1].Children)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-rest-api/model"
+	"io"
+	"strings"
+)
+
+// exportBatchSize は書き出し時に 1 度に読み込む食材の件数
+const exportBatchSize = 500
+
+// ExportFoodItems は条件に一致するすべての食材を format の形式で w に書き出す。
+// 食材は exportBatchSize 件ずつ読み込みながら書き出すため、件数が多くてもメモリに溜めない。
+// filter.Limit と filter.Cursor は無視する。
+func (fu *foodItemUsecase) ExportFoodItems(userId uint, filter model.FoodItemFilter, format model.ExportFormat, w io.Writer) error {
+	if !format.IsValid() {
+		return importError("対応していない形式です")
+	}
+	locations := []model.StorageLocation{}
+	if err := fu.slr.GetAllStorageLocations(&locations, userId); err != nil {
+		return err
+	}
+	locationNames := map[uint]string{}
+	for _, location := range locations {
+		locationNames[location.ID] = location.Name
+	}
+
+	filter.Limit = exportBatchSize
+	filter.Cursor = nil
+	foodItems, nextCursor, err := fu.listFoodItems(userId, filter)
+	if err != nil {
+		return err
+	}
+	writer := newExportWriter(format, w)
+	if err := writer.begin(); err != nil {
+		return err
+	}
+	for {
+		for _, foodItem := range foodItems {
+			locationName := ""
+			if foodItem.StorageLocationId != nil {
+				locationName = locationNames[*foodItem.StorageLocationId]
+			}
+			if err := writer.write(model.NewFoodItemExport(foodItem, locationName)); err != nil {
+				return err
+			}
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor, err := model.DecodeFoodItemCursor(nextCursor, filter.Sort)
+		if err != nil {
+			return err
+		}
+		filter.Cursor = &cursor
+		if foodItems, nextCursor, err = fu.listFoodItems(userId, filter); err != nil {
+			return err
+		}
+	}
+	return writer.end()
+}
+
+// exportWriter は形式ごとの書き出し処理
+type exportWriter interface {
+	begin() error
+	write(item model.FoodItemExport) error
+	end() error
+}
+
+func newExportWriter(format model.ExportFormat, w io.Writer) exportWriter {
+	switch format {
+	case model.ExportJSON:
+		return &jsonExportWriter{w: w}
+	case model.ExportMarkdown:
+		return &markdownExportWriter{w: w}
+	}
+	return &csvExportWriter{w: w, csv: csv.NewWriter(w)}
+}
+
+// csvExportWriter は Excel で文字化けしないよう BOM 付きの UTF-8 で書き出す
+type csvExportWriter struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (cw *csvExportWriter) begin() error {
+	if _, err := cw.w.Write(utf8BOM); err != nil {
+		return err
+	}
+	return cw.csv.Write(model.ExportColumns)
+}
+
+func (cw *csvExportWriter) write(item model.FoodItemExport) error {
+	return cw.csv.Write(item.Record())
+}
+
+func (cw *csvExportWriter) end() error {
+	cw.csv.Flush()
+	return cw.csv.Error()
+}
+
+// jsonExportWriter は食材の配列を 1 件ずつ書き出す
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (jw *jsonExportWriter) begin() error {
+	_, err := io.WriteString(jw.w, "[")
+	return err
+}
+
+func (jw *jsonExportWriter) write(item model.FoodItemExport) error {
+	if jw.count > 0 {
+		if _, err := io.WriteString(jw.w, ","); err != nil {
+			return err
+		}
+	}
+	jw.count++
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = jw.w.Write(b)
+	return err
+}
+
+func (jw *jsonExportWriter) end() error {
+	_, err := io.WriteString(jw.w, "]\n")
+	return err
+}
+
+// markdownExportWriter は共有用の表を書き出す。取り込みには対応しない。
+type markdownExportWriter struct {
+	w io.Writer
+}
+
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+func (mw *markdownExportWriter) begin() error {
+	_, err := io.WriteString(mw.w, "| 名前 | 数量 | 賞味期限 | カテゴリ | タグ | 保管場所 |\n| --- | ---: | --- | --- | --- | --- |\n")
+	return err
+}
+
+func (mw *markdownExportWriter) write(item model.FoodItemExport) error {
+	cells := []string{
+		item.Title,
+		model.Quantity{Amount: item.Quantity, Unit: item.Unit}.String(),
+		item.ExpiryDate,
+		item.CategoryName,
+		strings.Join(item.Tags, model.ExportTagSeparator),
+		item.Location,
+	}
+	for i, cell := range cells {
+		cells[i] = markdownCellEscaper.Replace(cell)
+	}
+	_, err := fmt.Fprintf(mw.w, "| %s |\n", strings.Join(cells, " | "))
+	return err
+}
+
+func (mw *markdownExportWriter) end() error {
+	return nil
+}
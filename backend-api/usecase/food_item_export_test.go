@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-rest-api/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportTestItems() []model.FoodItem {
+	vegetables := model.Category{ID: 1, Code: "vegetables", Name: "野菜"}
+	locationId := uint(5)
+	purchasedAt := time.Date(2026, 10, 10, 9, 30, 0, 0, time.UTC)
+	openedAt := time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+	stateExpiryDate := time.Date(2026, 11, 12, 18, 0, 0, 0, time.UTC)
+	openedDays := 3
+	priced := model.FoodLot{Quantity: 2, ExpiryDate: time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC), PurchasedAt: purchasedAt}
+	priced.SetPrice(model.Money{Amount: 198, Currency: "JPY"})
+	return []model.FoodItem{
+		{
+			ID: 1, Title: "にんじん", Quantity: 3, Unit: model.UnitHon,
+			ExpiryDate: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			Category:   &vegetables, CategoryId: &vegetables.ID, StorageLocationId: &locationId,
+			Tags: []model.Tag{{Name: "特売"}, {Name: "常備"}},
+			Lots: []model.FoodLot{
+				{Quantity: 1, ExpiryDate: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), PurchasedAt: purchasedAt},
+				priced,
+			},
+			OpenedAt: &openedAt, OpenedShelfLifeDays: &openedDays,
+			State: model.StateFrozen, StateExpiryDate: &stateExpiryDate,
+			Nutrition: model.Nutrition{
+				Basis: model.NutritionPer100g, Source: model.NutritionSourceReference,
+				NutritionValues: model.NutritionValues{Kcal: 35, Protein: 0.7, Carbohydrate: 9.3, Salt: 0.1},
+			},
+		},
+		{ID: 2, Title: "牛乳 | 低脂肪", Quantity: 1.5, Unit: model.UnitLiter, ExpiryDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestFoodItemUsecase_ExportFoodItems_RoundTrip(t *testing.T) {
+	for _, format := range []model.ExportFormat{model.ExportCSV, model.ExportJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			usecase, mockRepo := newImportUsecase()
+			mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), mock.Anything).Return(exportTestItems(), nil)
+
+			var out bytes.Buffer
+			err := usecase.ExportFoodItems(1, model.FoodItemFilter{}, format, &out)
+			assert.NoError(t, err)
+
+			// 書き出した内容をそのまま取り込める
+			result, err := usecase.ImportFoodItems(1, &out, model.ImportOptions{DryRun: true, Format: format})
+			assert.NoError(t, err)
+			assert.Empty(t, result.Errors)
+			assert.Len(t, result.Items, 2)
+			carrot := result.Items[0]
+			assert.Equal(t, "にんじん", carrot.Title)
+			assert.Equal(t, 3.0, carrot.Quantity)
+			assert.Equal(t, model.UnitHon, carrot.Unit)
+			assert.Equal(t, uint(1), *carrot.CategoryId)
+			assert.Equal(t, uint(5), *carrot.StorageLocationId)
+			assert.Equal(t, []string{"特売", "常備"}, carrot.Tags)
+			assert.Equal(t, "2026-10-25", carrot.ExpiryDate.Format("2006-01-02"))
+			assert.Equal(t, model.UnitLiter, result.Items[1].Unit)
+
+			// 複数のロットと価格、開封・保存状態、栄養成分も元に戻る
+			original := exportTestItems()[0]
+			if assert.Len(t, carrot.Lots, 2) {
+				assert.Equal(t, 1.0, carrot.Lots[0].Quantity)
+				assert.Nil(t, carrot.Lots[0].Price)
+				assert.Equal(t, 2.0, carrot.Lots[1].Quantity)
+				assert.Equal(t, "2026-10-28", carrot.Lots[1].ExpiryDate.Format("2006-01-02"))
+				assert.True(t, original.Lots[1].PurchasedAt.Equal(carrot.Lots[1].PurchasedAt))
+				assert.Equal(t, 198.0, *carrot.Lots[1].Price)
+				assert.Equal(t, 99.0, *carrot.Lots[1].UnitPrice)
+				assert.Equal(t, "JPY", carrot.Lots[1].Currency)
+			}
+			assert.True(t, original.OpenedAt.Equal(*carrot.OpenedAt))
+			assert.Equal(t, 3, *carrot.OpenedShelfLifeDays)
+			assert.Equal(t, model.StateFrozen, carrot.State)
+			assert.True(t, original.StateExpiryDate.Equal(*carrot.StateExpiryDate))
+			assert.Equal(t, &original.Nutrition, carrot.Nutrition)
+
+			milk := result.Items[1]
+			assert.Equal(t, model.StateFresh, milk.State)
+			assert.Nil(t, milk.OpenedAt)
+			assert.Nil(t, milk.StateExpiryDate)
+			assert.Len(t, milk.Lots, 1)
+		})
+	}
+}
+
+func TestFoodItemUsecase_ExportFoodItems_Markdown(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), mock.Anything).Return(exportTestItems(), nil)
+
+	var out bytes.Buffer
+	err := usecase.ExportFoodItems(1, model.FoodItemFilter{}, model.ExportMarkdown, &out)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "| にんじん | 3本 | 2026-10-25 | 野菜 | 特売、常備 | 冷蔵庫 |", lines[2])
+	assert.Contains(t, lines[3], `牛乳 \| 低脂肪`)
+}
+
+func TestFoodItemUsecase_ExportFoodItems_Batches(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	first := []model.FoodItem{}
+	for i := 1; i <= exportBatchSize+1; i++ {
+		first = append(first, model.FoodItem{ID: uint(i), Title: fmt.Sprintf("食材%d", i)})
+	}
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), mock.MatchedBy(func(filter model.FoodItemFilter) bool {
+		return filter.Cursor == nil && filter.Limit == exportBatchSize+1
+	})).Return(first, nil).Once()
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), mock.MatchedBy(func(filter model.FoodItemFilter) bool {
+		return filter.Cursor != nil && filter.Cursor.ID == exportBatchSize
+	})).Return(first[exportBatchSize:], nil).Once()
+
+	var out bytes.Buffer
+	err := usecase.ExportFoodItems(1, model.FoodItemFilter{Limit: 100}, model.ExportJSON, &out)
+
+	assert.NoError(t, err)
+	items := []model.FoodItemExport{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	assert.Len(t, items, exportBatchSize+1)
+	mockRepo.AssertExpectations(t)
+}
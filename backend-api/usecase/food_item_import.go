@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/width"
+)
+
+// maxImportRows は 1 回の取り込みで受け付けるデータ行数の上限
+const maxImportRows = 1000
+
+// importDateLayouts は賞味期限として受け付ける日付の形式
+var importDateLayouts = []string{"2006-01-02", "2006/01/02", "2006-1-2", "2006/1/2", "2006年1月2日", "20060102"}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ImportFoodItems は CSV（または書き出した JSON）の各行を食材として登録する。
+// 行ごとの誤りは結果の Errors に集め、1 件でもあれば何も登録しない。
+// ヘッダーの誤りなど CSV 全体を読めない場合はエラーを返す。
+func (fu *foodItemUsecase) ImportFoodItems(userId uint, r io.Reader, options model.ImportOptions) (model.ImportResult, error) {
+	var records [][]string
+	var err error
+	switch options.Format {
+	case "", model.ExportCSV:
+		records, err = readImportCSV(r, options.Encoding)
+	case model.ExportJSON:
+		records, err = readImportJSON(r)
+	default:
+		err = importError("対応していない形式です")
+	}
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	columns, err := mapImportColumns(records[0], options.Mapping)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	rows := records[1:]
+	if len(rows) > maxImportRows {
+		return model.ImportResult{}, importError(fmt.Sprintf("一度に取り込めるのは %d 行までです", maxImportRows))
+	}
+
+	resolver, err := fu.newImportResolver(userId)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	result := model.ImportResult{
+		DryRun: options.DryRun,
+		Items:  []model.FoodItemResponse{},
+		Errors: []model.ImportRowError{},
+	}
+	foodItems := []model.FoodItem{}
+	for i, record := range rows {
+		// ヘッダーを 1 行目として数える
+		row := i + 2
+		if isBlankRecord(record) {
+			continue
+		}
+		result.Total++
+		foodItem, rowErrors := resolver.toFoodItem(row, record, columns)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		foodItems = append(foodItems, foodItem)
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if !options.DryRun && len(foodItems) > 0 {
+		if err := fu.fr.CreateFoodItems(foodItems, userId); err != nil {
+			return model.ImportResult{}, err
+		}
+		result.Imported = len(foodItems)
+	}
+	for _, foodItem := range foodItems {
+		result.Items = append(result.Items, toFoodItemResponse(foodItem))
+	}
+	return result, nil
+}
+
+// readImportCSV は文字コードを UTF-8 に変換して CSV を読み込む。先頭の行はヘッダーとする。
+func readImportCSV(r io.Reader, encoding model.ImportEncoding) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+	switch encoding {
+	case model.EncodingAuto:
+		if !utf8.Valid(data) {
+			encoding = model.EncodingShiftJIS
+		}
+	case model.EncodingUTF8:
+		if !utf8.Valid(data) {
+			return nil, importError("UTF-8 として読み込めません")
+		}
+	case model.EncodingShiftJIS:
+	default:
+		return nil, importError("対応していない文字コードです")
+	}
+	if encoding == model.EncodingShiftJIS {
+		if data, _, err = transform.Bytes(japanese.ShiftJIS.NewDecoder(), data); err != nil {
+			return nil, importError("Shift_JIS として読み込めません")
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, apperrors.New(apperrors.ValidationError, "CSV の形式が正しくありません", http.StatusBadRequest, err)
+	}
+	if len(records) == 0 {
+		return nil, importError("CSV が空です")
+	}
+	return records, nil
+}
+
+// readImportJSON は書き出した JSON を CSV と同じ行の形式に変換する
+func readImportJSON(r io.Reader) ([][]string, error) {
+	items := []model.FoodItemExport{}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, apperrors.New(apperrors.ValidationError, "JSON の形式が正しくありません", http.StatusBadRequest, err)
+	}
+	records := [][]string{model.ExportColumns}
+	for _, item := range items {
+		records = append(records, item.Record())
+	}
+	return records, nil
+}
+
+// mapImportColumns はヘッダーの各列を食材の項目に対応付ける。
+// 対応しない列は無視し、必須の項目が見つからない場合はエラーにする。
+func mapImportColumns(header []string, mapping map[string]model.ImportField) (map[model.ImportField]int, error) {
+	columns := map[model.ImportField]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		field, ok := mapping[name]
+		if ok && !field.IsValid() {
+			return nil, importError(fmt.Sprintf("列「%s」の対応先 %s は存在しない項目です", name, field))
+		}
+		if !ok {
+			if field, ok = model.ImportFieldForHeader(name); !ok {
+				continue
+			}
+		}
+		if _, dup := columns[field]; dup {
+			return nil, importError(fmt.Sprintf("項目 %s に対応する列が複数あります", field))
+		}
+		columns[field] = i
+	}
+	for _, field := range model.RequiredImportFields {
+		if _, ok := columns[field]; !ok {
+			return nil, importError(fmt.Sprintf("項目 %s に対応する列がありません", field))
+		}
+	}
+	return columns, nil
+}
+
+// importResolver は CSV の値をカテゴリや保管場所に変換する。
+// 食材はすべて householdId の世帯に登録し、保管場所はその世帯のものだけを名前で探す。
+type importResolver struct {
+	categories  map[string]*uint
+	locations   map[string]*uint
+	householdId uint
+	now         time.Time
+	fv          validator.IFoodItemValidator
+}
+
+func (fu *foodItemUsecase) newImportResolver(userId uint) (importResolver, error) {
+	categories := []model.Category{}
+	if err := fu.cr.GetAllCategories(&categories); err != nil {
+		return importResolver{}, err
+	}
+	var householdId uint
+	if err := fu.fr.ResolveHousehold(&householdId, userId); err != nil {
+		return importResolver{}, householdError(err)
+	}
+	locations := []model.StorageLocation{}
+	if err := fu.slr.GetAllStorageLocations(&locations, userId); err != nil {
+		return importResolver{}, err
+	}
+	resolver := importResolver{
+		categories:  map[string]*uint{},
+		locations:   map[string]*uint{},
+		householdId: householdId,
+		now:         time.Now(),
+		fv:          fu.fv,
+	}
+	for _, category := range categories {
+		id := category.ID
+		resolver.categories[category.Code] = &id
+		resolver.categories[category.Name] = &id
+	}
+	for _, location := range locations {
+		if location.HouseholdId != householdId {
+			continue
+		}
+		id := location.ID
+		resolver.locations[location.Name] = &id
+	}
+	return resolver, nil
+}
+
+// toFoodItem は 1 行を食材に変換する。誤りのある列はすべて返す。
+func (ir importResolver) toFoodItem(row int, record []string, columns map[model.ImportField]int) (model.FoodItem, []model.ImportRowError) {
+	rowErrors := []model.ImportRowError{}
+	fail := func(field model.ImportField, message string) {
+		rowErrors = append(rowErrors, model.ImportRowError{Row: row, Column: string(field), Message: message})
+	}
+	value := func(field model.ImportField) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	foodItem := model.FoodItem{Title: value(model.ImportFieldTitle), HouseholdId: ir.householdId}
+	if foodItem.Title == "" {
+		fail(model.ImportFieldTitle, "名前は必須です")
+	}
+	quantity, err := strconv.ParseFloat(width.Narrow.String(value(model.ImportFieldQuantity)), 64)
+	if err == nil {
+		err = model.Quantity{Amount: quantity}.Validate()
+	}
+	if err != nil {
+		fail(model.ImportFieldQuantity, model.ErrInvalidQuantity.Error())
+	}
+	foodItem.Quantity = quantity
+	unit, err := model.ParseUnit(value(model.ImportFieldUnit))
+	if err != nil {
+		fail(model.ImportFieldUnit, err.Error())
+	}
+	foodItem.Unit = unit
+	expiryDate, ok := parseImportDate(value(model.ImportFieldExpiryDate))
+	if !ok {
+		fail(model.ImportFieldExpiryDate, "賞味期限は YYYY-MM-DD の形式で指定してください")
+	}
+	foodItem.ExpiryDate = expiryDate
+	if name := value(model.ImportFieldCategory); name != "" {
+		if foodItem.CategoryId, ok = ir.categories[name]; !ok {
+			fail(model.ImportFieldCategory, fmt.Sprintf("カテゴリ「%s」は存在しません", name))
+		}
+	}
+	if name := value(model.ImportFieldLocation); name != "" {
+		if foodItem.StorageLocationId, ok = ir.locations[name]; !ok {
+			fail(model.ImportFieldLocation, fmt.Sprintf("保管場所「%s」は存在しません", name))
+		}
+	}
+	// タグは名前のみを設定し、登録時にユーザーのタグに変換する
+	for _, name := range normalizeTagNames(splitImportTags(value(model.ImportFieldTags))) {
+		foodItem.Tags = append(foodItem.Tags, model.Tag{Name: name})
+	}
+	ir.parseStoredState(&foodItem, value, fail)
+	nutritionSource := ir.parseNutrition(&foodItem, value, fail)
+	var lots []model.FoodLot
+	if s := value(model.ImportFieldLots); s != "" {
+		var err error
+		if lots, err = ir.parseLots(s); err != nil {
+			fail(model.ImportFieldLots, err.Error())
+		} else if total, earliest, ok := model.SummarizeLots(lots); ok {
+			if total != foodItem.Quantity {
+				fail(model.ImportFieldQuantity, "数量がロットの数量の合計と一致しません")
+			}
+			foodItem.ExpiryDate = earliest
+		}
+	}
+	if len(rowErrors) > 0 {
+		return model.FoodItem{}, rowErrors
+	}
+	// 形式が正しい行は、画面からの登録と同じ規則で検証する
+	var errs validation.Errors
+	if err := ir.fv.FoodItemValidate(foodItem); errors.As(err, &errs) {
+		for _, field := range slices.Sorted(maps.Keys(errs)) {
+			fail(model.ImportField(field), errs[field].Error())
+		}
+		return model.FoodItem{}, rowErrors
+	}
+
+	// CreateFoodItem と同じく、栄養成分を成分表から補い、登録時の数量と賞味期限を最初のロットにする。
+	// 書き出した食材はロットと栄養成分の出どころをそのまま戻す
+	fillNutrition(&foodItem)
+	if nutritionSource == model.NutritionSourceReference && foodItem.Nutrition.IsSet() {
+		foodItem.Nutrition.Source = nutritionSource
+	}
+	if len(lots) > 0 {
+		foodItem.Lots = lots
+	} else if foodItem.Quantity > 0 {
+		foodItem.Lots = []model.FoodLot{{
+			Quantity:    foodItem.Quantity,
+			ExpiryDate:  foodItem.ExpiryDate,
+			PurchasedAt: ir.now,
+		}}
+	}
+	return foodItem, nil
+}
+
+// parseStoredState は開封日時・開封後の日数・保存状態を読み込む。
+// 冷凍・解凍・調理した食材は state_expiry_date を必須とし、生の食材には指定できない。
+func (ir importResolver) parseStoredState(foodItem *model.FoodItem, value func(model.ImportField) string, fail func(model.ImportField, string)) {
+	if s := value(model.ImportFieldOpenedAt); s != "" {
+		openedAt, ok := parseImportTime(s)
+		if !ok || openedAt.After(ir.now) {
+			fail(model.ImportFieldOpenedAt, "開封日時は現在までの日時を RFC3339 または YYYY-MM-DD の形式で指定してください")
+		} else {
+			foodItem.OpenedAt = &openedAt
+		}
+	}
+	if s := value(model.ImportFieldOpenedShelfLifeDays); s != "" {
+		days, err := strconv.Atoi(width.Narrow.String(s))
+		if err != nil {
+			fail(model.ImportFieldOpenedShelfLifeDays, "開封後の日数は整数で指定してください")
+		} else {
+			foodItem.OpenedShelfLifeDays = &days
+		}
+	}
+	state := model.FoodState(value(model.ImportFieldState)).OrDefault()
+	if !state.IsValid() {
+		fail(model.ImportFieldState, "保存状態は fresh・frozen・thawed・cooked のいずれかで指定してください")
+		return
+	}
+	foodItem.State = state
+	s := value(model.ImportFieldStateExpiryDate)
+	switch {
+	case state == model.StateFresh && s != "":
+		fail(model.ImportFieldStateExpiryDate, "生の食材には保存状態の期限を指定できません")
+	case state != model.StateFresh && s == "":
+		fail(model.ImportFieldStateExpiryDate, "冷凍・解凍・調理した食材は保存状態の期限が必須です")
+	case s != "":
+		stateExpiryDate, ok := parseImportTime(s)
+		if !ok {
+			fail(model.ImportFieldStateExpiryDate, "保存状態の期限は RFC3339 または YYYY-MM-DD の形式で指定してください")
+			return
+		}
+		foodItem.StateExpiryDate = &stateExpiryDate
+	}
+}
+
+// parseNutrition は栄養成分を読み込み、指定された出どころを返す。
+// 基準量と値の範囲は登録と同じく FoodItemValidate で検証する。
+func (ir importResolver) parseNutrition(foodItem *model.FoodItem, value func(model.ImportField) string, fail func(model.ImportField, string)) model.NutritionSource {
+	nutrition := model.Nutrition{Basis: model.NutritionBasis(value(model.ImportFieldNutritionBasis))}
+	values := []*float64{&nutrition.Kcal, &nutrition.Protein, &nutrition.Fat, &nutrition.Carbohydrate, &nutrition.Salt}
+	for i, field := range model.ImportNutritionFields {
+		s := value(field)
+		if s == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(width.Narrow.String(s), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			fail(field, "栄養成分は数値で指定してください")
+			continue
+		}
+		*values[i] = v
+	}
+	foodItem.Nutrition = nutrition
+	source := model.NutritionSource(value(model.ImportFieldNutritionSource))
+	switch source {
+	case "", model.NutritionSourceReference, model.NutritionSourceUser:
+	default:
+		fail(model.ImportFieldNutritionSource, "栄養成分の出どころは reference または user で指定してください")
+	}
+	return source
+}
+
+// parseLots は書き出した形式のロットの配列を読み込む。購入日時を省略したロットは取り込んだ日時にする。
+// 書き出したロットの購入は書き出し元で記録済みのため、取り込みでは記録しない
+func (ir importResolver) parseLots(s string) ([]model.FoodLot, error) {
+	exported := []model.FoodLotExport{}
+	if err := json.Unmarshal([]byte(s), &exported); err != nil {
+		return nil, errors.New("ロットは書き出した形式（JSON の配列）で指定してください")
+	}
+	lots := []model.FoodLot{}
+	for _, e := range exported {
+		if err := (model.Quantity{Amount: e.Quantity}).Validate(); err != nil || e.Quantity == 0 {
+			return nil, errors.New("ロットの数量は 0 より大きい数値で指定してください")
+		}
+		expiryDate, ok := parseImportDate(e.ExpiryDate)
+		if !ok {
+			return nil, errors.New("ロットの賞味期限は YYYY-MM-DD の形式で指定してください")
+		}
+		lot := model.FoodLot{Quantity: e.Quantity, ExpiryDate: expiryDate, PurchasedAt: e.PurchasedAt, PurchaseRecorded: true}
+		if lot.PurchasedAt.IsZero() {
+			lot.PurchasedAt = ir.now
+		}
+		if e.Price != nil {
+			if *e.Price < 0 || *e.Price > validator.FoodItemPriceMax {
+				return nil, fmt.Errorf("ロットの金額は 0 から %d の範囲で指定してください", validator.FoodItemPriceMax)
+			}
+			currency, err := model.ParseCurrency(e.Currency)
+			if err != nil {
+				return nil, errors.New("ロットの通貨は 3 文字の通貨コードで指定してください")
+			}
+			lot.SetPrice(model.Money{Amount: *e.Price, Currency: currency})
+		}
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
+
+// parseImportTime は RFC3339 の日時、または賞味期限と同じ形式の日付を読み込む
+func parseImportTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, width.Narrow.String(s)); err == nil {
+		return t, true
+	}
+	return parseImportDate(s)
+}
+
+func parseImportDate(s string) (time.Time, bool) {
+	s = width.Narrow.String(s)
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func splitImportTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == '、' || r == ';' || r == '|' || r == ','
+	})
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func importError(message string) error {
+	return apperrors.New(apperrors.ValidationError, message, http.StatusBadRequest, nil)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// newImportUsecase はカテゴリ「野菜」と、登録先の世帯 1 に保管場所「冷蔵庫」がある状態のユースケースを返す。
+// 別の世帯 2 の保管場所「物置」は取り込みに使えない
+func newImportUsecase() (IFoodItemUsecase, *MockFoodItemRepository) {
+	mockRepo := new(MockFoodItemRepository)
+	mockCategoryRepo := new(MockCategoryRepository)
+	mockLocationRepo := new(MockStorageLocationRepository)
+	mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil).Maybe()
+	mockCategoryRepo.On("GetAllCategories", mock.Anything).Return([]model.Category{{ID: 1, Code: "vegetables", Name: "野菜"}}, nil)
+	mockLocationRepo.On("GetAllStorageLocations", mock.Anything, uint(1)).Return([]model.StorageLocation{
+		{ID: 5, Name: "冷蔵庫", HouseholdId: 1},
+		{ID: 6, Name: "物置", HouseholdId: 2},
+	}, nil)
+	return newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo, cr: mockCategoryRepo}), mockRepo
+}
+
+const importCSV = `品名,数量,単位,賞味期限,カテゴリ,タグ,保管場所
+にんじん,３,本,2026/10/25,野菜,特売、常備,冷蔵庫
+牛乳,1,L,2026-10-20,,,
+`
+
+func TestFoodItemUsecase_ImportFoodItems(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+	mockRepo.On("CreateFoodItems", mock.MatchedBy(func(foodItems []model.FoodItem) bool {
+		carrot := foodItems[0]
+		return len(foodItems) == 2 &&
+			carrot.Quantity == 3 && carrot.Unit == model.UnitHon &&
+			*carrot.CategoryId == 1 && *carrot.StorageLocationId == 5 && carrot.HouseholdId == 1 &&
+			len(carrot.Tags) == 2 && len(carrot.Lots) == 1
+	}), uint(1)).Return(nil)
+
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(importCSV), model.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 2, result.Imported)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{"特売", "常備"}, result.Items[0].Tags)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_ShiftJIS(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+	mockRepo.On("CreateFoodItems", mock.Anything, uint(1)).Return(nil)
+
+	sjis, err := japanese.ShiftJIS.NewEncoder().String(importCSV)
+	assert.NoError(t, err)
+
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(sjis), model.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, "にんじん", result.Items[0].Title)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_RowErrors(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	csv := "title,quantity,unit,expiry_date,location\n" +
+		"卵,10,個,2026-10-30,\n" +
+		",-1,箱,10月30日,物置\n"
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{})
+
+	// 1 行でも誤りがあれば何も登録しない。登録先と別の世帯の保管場所（物置）も誤りになる
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Imported)
+	assert.Len(t, result.Errors, 5)
+	assert.Contains(t, result.Errors, model.ImportRowError{Row: 3, Column: "location", Message: "保管場所「物置」は存在しません"})
+	for _, e := range result.Errors {
+		assert.Equal(t, 3, e.Row)
+	}
+	mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_Validation(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	// 形式は正しくても、画面からの登録と同じ規則に合わない行は取り込まない
+	csv := "title,quantity,unit,expiry_date\n" +
+		"乾パン,1,個," + time.Now().AddDate(30, 0, 0).Format("2006-01-02") + "\n"
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ImportRowError{{Row: 2, Column: "expiry_date", Message: "must not be more than 10 years ahead"}}, result.Errors)
+	mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_StoredState(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	// ロットの合計と数量の食い違いや、期限のない冷凍の食材は取り込まない
+	csv := "title,quantity,expiry_date,state,state_expiry_date,lots\n" +
+		`鶏もも肉,300,2026-10-30,frozen,,"[{""quantity"":200,""expiry_date"":""2026-10-30""}]"` + "\n"
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ImportRowError{
+		{Row: 2, Column: "state_expiry_date", Message: "冷凍・解凍・調理した食材は保存状態の期限が必須です"},
+		{Row: 2, Column: "quantity", Message: "数量がロットの数量の合計と一致しません"},
+	}, result.Errors)
+	mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_ExportedLots(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+	// 書き出したロットの購入は記録済みのため、取り込み直しても重ねて記録しない
+	mockRepo.On("CreateFoodItems", mock.MatchedBy(func(foodItems []model.FoodItem) bool {
+		lots := foodItems[0].Lots
+		return len(lots) == 1 && *lots[0].Price == 398 && lots[0].PurchaseRecorded
+	}), uint(1)).Return(nil)
+
+	csv := "title,quantity,expiry_date,lots\n" +
+		`鶏もも肉,300,2026-10-30,"[{""quantity"":300,""expiry_date"":""2026-10-30"",""price"":398,""currency"":""JPY""}]"` + "\n"
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_DryRun(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(importCSV), model.ImportOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 0, result.Imported)
+	assert.Len(t, result.Items, 2)
+	mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_ImportFoodItems_Mapping(t *testing.T) {
+	usecase, mockRepo := newImportUsecase()
+
+	csv := "商品,残り,期日\nバター,1,2026-11-30\n"
+
+	_, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{DryRun: true})
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+
+	result, err := usecase.ImportFoodItems(1, strings.NewReader(csv), model.ImportOptions{
+		DryRun: true,
+		Mapping: map[string]model.ImportField{
+			"商品": model.ImportFieldTitle,
+			"残り": model.ImportFieldQuantity,
+			"期日": model.ImportFieldExpiryDate,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "バター", result.Items[0].Title)
+	mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+)
+
+// fillNutrition は栄養成分を入力しなかった食材に同梱の成分表の値を補う。
+// 入力された栄養成分はユーザーの値として記録する。foodItem.Unit は正規化済みであること。
+func fillNutrition(foodItem *model.FoodItem) {
+	if foodItem.Nutrition.IsSet() {
+		foodItem.Nutrition.Source = model.NutritionSourceUser
+		return
+	}
+	if nutrition, ok := model.ReferenceNutrition(foodItem.Title, foodItem.Unit); ok {
+		foodItem.Nutrition = nutrition
+	}
+}
+
+// UpdateNutrition は食材の栄養成分を置き換える。
+// basis と値をすべて省略した場合は、食材名と単位から同梱の成分表の値を設定し直す（成分表にない場合は apperrors.NutritionUnknown）。
+func (fu *foodItemUsecase) UpdateNutrition(userId uint, foodItemId uint, nutrition model.Nutrition) (model.FoodItemResponse, error) {
+	if err := fu.fv.NutritionValidate(nutrition); err != nil {
+		return model.FoodItemResponse{}, validationError(err)
+	}
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	foodItem.Nutrition = nutrition
+	fillNutrition(&foodItem)
+	if !foodItem.Nutrition.IsSet() {
+		return model.FoodItemResponse{}, apperrors.NutritionUnknown
+	}
+	if err := fu.fr.UpdateFoodItemNutrition(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// DeleteNutrition は食材の栄養成分を削除する。削除した食材は栄養成分の集計で成分表の値も使わない
+func (fu *foodItemUsecase) DeleteNutrition(userId uint, foodItemId uint) (model.FoodItemResponse, error) {
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	foodItem.Nutrition = model.Nutrition{}
+	if err := fu.fr.UpdateFoodItemNutrition(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// GetNutritionSummary は絞り込んだ食材の在庫の数量に含まれる栄養成分を合計する。
+// 在庫のない食材は含めない。栄養成分がない、または 100 g あたりの値で数量を重さに換算できない食材は
+// Missing に理由とともに返す。
+func (fu *foodItemUsecase) GetNutritionSummary(userId uint, filter model.FoodItemFilter) (model.NutritionSummary, error) {
+	filter.Limit = 0
+	filter.Cursor = nil
+	foodItems, _, err := fu.listFoodItems(userId, filter)
+	if err != nil {
+		return model.NutritionSummary{}, err
+	}
+	summary := model.NutritionSummary{Items: []model.NutritionSummaryItem{}, Missing: []model.NutritionSummaryItem{}}
+	for _, foodItem := range foodItems {
+		if foodItem.Quantity <= 0 {
+			continue
+		}
+		item := model.NutritionSummaryItem{FoodItemId: foodItem.ID, Title: foodItem.Title, Quantity: foodItem.Quantity, Unit: foodItem.Unit}
+		if !foodItem.Nutrition.IsSet() {
+			item.Reason = model.NutritionMissing
+			summary.Missing = append(summary.Missing, item)
+			continue
+		}
+		values, ok := foodItem.Nutrition.For(foodItem.Amount())
+		if !ok {
+			item.Reason = model.NutritionUnknownWeight
+			summary.Missing = append(summary.Missing, item)
+			continue
+		}
+		item.Nutrition = &values
+		summary.Items = append(summary.Items, item)
+		summary.Totals = summary.Totals.Add(values)
+	}
+	return summary, nil
+}
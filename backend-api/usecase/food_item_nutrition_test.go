@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFoodItemUsecase_CreateFoodItem_Nutrition(t *testing.T) {
+	newUsecase := func() (IFoodItemUsecase, *MockFoodItemRepository) {
+		mockRepo := new(MockFoodItemRepository)
+		mockRepo.On("CreateFoodItem", mock.AnythingOfType("*model.FoodItem")).Return(nil)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+		return usecase, mockRepo
+	}
+	expiry := time.Now().AddDate(0, 0, 7)
+
+	t.Run("省略した場合は成分表の値を補う", func(t *testing.T) {
+		usecase, _ := newUsecase()
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{Title: "卵", Quantity: 6, Unit: "個", ExpiryDate: expiry, UserId: 1})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Nutrition)
+		assert.Equal(t, model.NutritionPerUnit, res.Nutrition.Basis)
+		assert.Equal(t, model.NutritionSourceReference, res.Nutrition.Source)
+		assert.Equal(t, 71.0, res.Nutrition.Kcal)
+	})
+
+	t.Run("入力した値はユーザーの値として記録する", func(t *testing.T) {
+		usecase, _ := newUsecase()
+		nutrition := model.Nutrition{Basis: model.NutritionPer100g, NutritionValues: model.NutritionValues{Kcal: 150}}
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{Title: "卵", Quantity: 6, Unit: "g", ExpiryDate: expiry, UserId: 1, Nutrition: nutrition})
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.NutritionSourceUser, res.Nutrition.Source)
+		assert.Equal(t, 150.0, res.Nutrition.Kcal)
+	})
+
+	t.Run("成分表にない食材は栄養成分なし", func(t *testing.T) {
+		usecase, _ := newUsecase()
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{Title: "謎の食材", Quantity: 1, ExpiryDate: expiry, UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.Nutrition)
+	})
+
+	t.Run("値だけを指定した場合は受け付けない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase()
+		nutrition := model.Nutrition{NutritionValues: model.NutritionValues{Kcal: 150}}
+
+		_, err := usecase.CreateFoodItem(model.FoodItem{Title: "卵", Quantity: 6, ExpiryDate: expiry, UserId: 1, Nutrition: nutrition})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_UpdateNutrition(t *testing.T) {
+	newUsecase := func(foodItem model.FoodItem) (IFoodItemUsecase, *MockFoodItemRepository) {
+		mockRepo := new(MockFoodItemRepository)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = foodItem
+		}).Return(nil)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+		return usecase, mockRepo
+	}
+
+	t.Run("指定した値で置き換える", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", Unit: model.UnitGram})
+		mockRepo.On("UpdateFoodItemNutrition", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+			return foodItem.Nutrition.Source == model.NutritionSourceUser && foodItem.Nutrition.Kcal == 200
+		}), uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.UpdateNutrition(1, 10, model.Nutrition{Basis: model.NutritionPer100g, NutritionValues: model.NutritionValues{Kcal: 200}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 200.0, res.Nutrition.Kcal)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("省略した場合は成分表の値に戻す", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", Unit: model.UnitGram, Nutrition: model.Nutrition{
+			Basis: model.NutritionPer100g, Source: model.NutritionSourceUser, NutritionValues: model.NutritionValues{Kcal: 200},
+		}})
+		mockRepo.On("UpdateFoodItemNutrition", mock.Anything, uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.UpdateNutrition(1, 10, model.Nutrition{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.NutritionSourceReference, res.Nutrition.Source)
+		assert.Equal(t, 190.0, res.Nutrition.Kcal)
+	})
+
+	t.Run("成分表にない食材は省略できない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "謎の食材", Unit: model.UnitPiece})
+
+		_, err := usecase.UpdateNutrition(1, 10, model.Nutrition{})
+
+		assert.ErrorIs(t, err, apperrors.NutritionUnknown)
+		assert.Equal(t, http.StatusNotFound, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "UpdateFoodItemNutrition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("範囲外の値と不明な基準は受け付けない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10})
+
+		_, err := usecase.UpdateNutrition(1, 10, model.Nutrition{Basis: model.NutritionPer100g, NutritionValues: model.NutritionValues{Kcal: -1}})
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		_, err = usecase.UpdateNutrition(1, 10, model.Nutrition{Basis: "1kg", NutritionValues: model.NutritionValues{Kcal: 10}})
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "GetFoodItemById", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_GetNutritionSummary(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+	per100g := func(kcal float64) model.Nutrition {
+		return model.Nutrition{Basis: model.NutritionPer100g, NutritionValues: model.NutritionValues{Kcal: kcal, Protein: 10}}
+	}
+	foodItems := []model.FoodItem{
+		{ID: 1, Title: "鶏もも肉", Quantity: 300, Unit: model.UnitGram, Nutrition: per100g(190)},
+		{ID: 2, Title: "卵", Quantity: 2, Unit: model.UnitPiece, Nutrition: model.Nutrition{
+			Basis: model.NutritionPerUnit, NutritionValues: model.NutritionValues{Kcal: 71, Protein: 6.1},
+		}},
+		{ID: 3, Title: "キャベツ", Quantity: 1, Unit: model.UnitPiece, Nutrition: per100g(21)},
+		{ID: 4, Title: "謎の食材", Quantity: 1, Unit: model.UnitPiece},
+		{ID: 5, Title: "使い切った牛乳", Quantity: 0, Unit: model.UnitMilliliter, Nutrition: per100g(61)},
+	}
+	// 集計は一覧の件数の上限を使わない
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), mock.MatchedBy(func(filter model.FoodItemFilter) bool {
+		return filter.Limit == 0 && filter.StorageLocationId != nil && *filter.StorageLocationId == 3
+	})).Return(foodItems, nil)
+	locationId := uint(3)
+
+	res, err := usecase.GetNutritionSummary(1, model.FoodItemFilter{StorageLocationId: &locationId, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.NutritionValues{Kcal: 712, Protein: 42.2}, res.Totals)
+	assert.Len(t, res.Items, 2)
+	assert.Equal(t, 570.0, res.Items[0].Nutrition.Kcal)
+	if assert.Len(t, res.Missing, 2) {
+		assert.Equal(t, uint(3), res.Missing[0].FoodItemId)
+		assert.Equal(t, model.NutritionUnknownWeight, res.Missing[0].Reason)
+		assert.Equal(t, uint(4), res.Missing[1].FoodItemId)
+		assert.Equal(t, model.NutritionMissing, res.Missing[1].Reason)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxReceiptLines は一度に解析できるレシートの行数の上限
+const maxReceiptLines = 300
+
+var (
+	// 2026年10月17日 / 2026/10/17 など。時刻（18:32）が続けば購入日時にする
+	receiptDateRe = regexp.MustCompile(`(\d{4})\s*[年/.\-]\s*(\d{1,2})\s*[月/.\-]\s*(\d{1,2})`)
+	receiptTimeRe = regexp.MustCompile(`(\d{1,2}):(\d{2})`)
+	// 品名と金額の行。金額の前には空白か円記号が必要で、末尾の * や「軽」は軽減税率などの印
+	receiptPriceRe = regexp.MustCompile(`^(.+?)(?:\s+|\s*[¥\\]\s*)([-▲△]?)\s*[¥\\]?([\d,]+)\s*(?:[*※軽外内非]|\(軽\))*$`)
+	// 個数と単価の行（"2コ X 単価 119"、"2個×119"、"@119 x 2"、"単価119×2点"）
+	receiptCountRe     = regexp.MustCompile(`^(\d+)\s*(?:コ|個|点|本|パック|袋|枚|P)?\s*[xX×@]\s*(?:単価)?\s*[¥\\]?\s*([\d,]+)`)
+	receiptUnitPriceRe = regexp.MustCompile(`^(?:@|単価)\s*[¥\\]?\s*([\d,]+)\s*[xX×]\s*(\d+)`)
+	// 品名の先頭の商品コードと、前後の印
+	receiptCodeRe   = regexp.MustCompile(`^\d{4,}\s+`)
+	receiptMarkRe   = regexp.MustCompile(`^[*※軽]+|[*※]+$`)
+	receiptSizeRe   = regexp.MustCompile(`(?i)\s*(\d+(?:\.\d+)?)\s*(ml|l|kg|g)$`)
+	receiptPiecesRe = regexp.MustCompile(`\s*(\d+)\s*(?:コ|個|本|枚|袋|パック|P|入)$`)
+)
+
+// 合計・税・支払いなど、商品ではない行の品名に含まれる語
+var (
+	receiptSubtotalWords = []string{"小計"}
+	receiptTotalWords    = []string{"合計", "お買上計", "総計"}
+	receiptTaxWords      = []string{"消費税", "内税", "外税", "税額"}
+	receiptDiscountWords = []string{"値引", "割引", "引き", "クーポン", "OFF", "off"}
+	receiptIgnoreWords   = []string{"対象", "点数", "預", "釣", "現金", "クレジット", "カード", "電子マネー", "ポイント", "支払", "レジ", "担当", "責", "TEL", "電話", "登録番号", "No."}
+)
+
+// ParseReceipt は貼り付けたレシートの文字列から商品・個数・金額を読み取り、登録前に確認する下書きを返す。
+// 品名は同梱の日持ちのデータの食材に対応付け、購入日時と日持ちの目安から賞味期限を見積もる。
+// 値引きの行は直前の商品の金額から差し引き、合計の行より後は商品として扱わない。
+func (fu *foodItemUsecase) ParseReceipt(userId uint, text string) (model.ReceiptDraft, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(text) == "" {
+		return model.ReceiptDraft{}, apperrors.New(apperrors.ValidationError, "レシートの文字列を指定してください", http.StatusBadRequest, nil)
+	}
+	if len(lines) > maxReceiptLines {
+		return model.ReceiptDraft{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("一度に解析できるのは %d 行までです", maxReceiptLines), http.StatusBadRequest, nil)
+	}
+
+	draft := parseReceiptLines(lines, time.Now())
+	for i := range draft.Items {
+		item := &draft.Items[i]
+		estimate, err := fu.se.EstimateShelfLife(userId, item.Title, "", draft.PurchasedAt)
+		if err == nil {
+			item.ExpiryDate = &estimate.ExpiryDate
+			item.ExpiryEstimated = true
+		} else if !errors.Is(err, apperrors.ShelfLifeUnknown) {
+			return model.ReceiptDraft{}, err
+		}
+	}
+	return draft, nil
+}
+
+// parseReceiptLines はレシートの各行を分類して下書きを組み立てる。
+// 日時の行より前は店名や住所として商品に含めない。日時が読み取れない場合は now を購入日時にする。
+func parseReceiptLines(lines []string, now time.Time) model.ReceiptDraft {
+	draft := model.ReceiptDraft{PurchasedAt: now, Currency: model.DefaultCurrency, Items: []model.ReceiptDraftItem{}, SkippedLines: []string{}}
+	// 半角カナ・全角英数・全角の円記号を揃え、税の行などを囲む括弧を外す
+	normalized := make([]string, len(lines))
+	header := 0
+	for i, raw := range lines {
+		line := strings.TrimSpace(norm.NFKC.String(raw))
+		if strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")") {
+			line = strings.TrimSpace(line[1 : len(line)-1])
+		}
+		normalized[i] = line
+		if purchasedAt, ok := parseReceiptDate(normalized[i], now); ok && header == 0 {
+			draft.PurchasedAt, header = purchasedAt, i+1
+		}
+	}
+
+	var last *model.ReceiptDraftItem
+	pendingCount, pendingUnitPrice := 0, 0
+	totalSeen := false
+	for i, line := range normalized {
+		if line == "" || i+1 == header {
+			continue
+		}
+		if i < header {
+			draft.SkippedLines = append(draft.SkippedLines, line)
+			continue
+		}
+		if count, unitPrice, ok := parseReceiptCount(line); ok {
+			if last != nil {
+				applyReceiptCount(last, count, unitPrice)
+			} else {
+				pendingCount, pendingUnitPrice = count, unitPrice
+			}
+			continue
+		}
+
+		m := receiptPriceRe.FindStringSubmatch(line)
+		if m == nil {
+			if !isReceiptNote(line) {
+				draft.SkippedLines = append(draft.SkippedLines, line)
+			}
+			continue
+		}
+		name := strings.TrimSpace(m[1])
+		amount, _ := strconv.Atoi(strings.ReplaceAll(m[3], ",", ""))
+		negative := m[2] != ""
+		switch {
+		case containsAny(name, receiptIgnoreWords):
+		case containsAny(name, receiptSubtotalWords):
+			draft.Subtotal = &amount
+		case containsAny(name, receiptTotalWords):
+			draft.Total = &amount
+			totalSeen = true
+		case containsAny(name, receiptTaxWords):
+			tax := amount
+			if draft.Tax != nil {
+				tax += *draft.Tax
+			}
+			draft.Tax = &tax
+		case negative || containsAny(name, receiptDiscountWords):
+			if last == nil || totalSeen {
+				draft.SkippedLines = append(draft.SkippedLines, line)
+				continue
+			}
+			last.Discount += amount
+			last.Price -= amount
+		case totalSeen:
+			draft.SkippedLines = append(draft.SkippedLines, line)
+		default:
+			item := newReceiptItem(i+1, name, amount)
+			if pendingCount > 0 {
+				applyReceiptCount(&item, pendingCount, pendingUnitPrice)
+				pendingCount, pendingUnitPrice = 0, 0
+			}
+			draft.Items = append(draft.Items, item)
+			last = &draft.Items[len(draft.Items)-1]
+		}
+	}
+	return draft
+}
+
+// newReceiptItem は品名と金額から 1 商品を作る。品名の内容量（"1000ML"）や入数（"10コ"）は数量にする
+func newReceiptItem(line int, name string, price int) model.ReceiptDraftItem {
+	title := receiptMarkRe.ReplaceAllString(receiptCodeRe.ReplaceAllString(name, ""), "")
+	item := model.ReceiptDraftItem{Line: line, Text: name, Count: 1, Quantity: 1, Unit: model.UnitPiece, UnitPrice: price, Price: price}
+	if m := receiptSizeRe.FindStringSubmatch(title); m != nil {
+		if unit, err := model.ParseUnit(m[2]); err == nil {
+			item.Quantity, _ = strconv.ParseFloat(m[1], 64)
+			item.Unit = unit
+			title = strings.TrimSpace(strings.TrimSuffix(title, m[0]))
+		}
+	} else if m := receiptPiecesRe.FindStringSubmatch(title); m != nil {
+		item.Quantity, _ = strconv.ParseFloat(m[1], 64)
+		title = strings.TrimSpace(strings.TrimSuffix(title, m[0]))
+	}
+	item.Title = strings.TrimSpace(title)
+	if entry, ok := model.FindShelfLifeEntry(item.Title); ok {
+		item.Ingredient = entry.Ingredient
+		item.IngredientName = entry.Name
+	}
+	return item
+}
+
+// applyReceiptCount は個数と単価の行を商品に反映する。数量は 1 個あたりの数量の count 倍にする
+func applyReceiptCount(item *model.ReceiptDraftItem, count int, unitPrice int) {
+	item.Quantity = item.Quantity / float64(item.Count) * float64(count)
+	item.Count = count
+	item.UnitPrice = unitPrice
+}
+
+// parseReceiptCount は個数と単価の行を読み取る
+func parseReceiptCount(line string) (int, int, bool) {
+	if m := receiptCountRe.FindStringSubmatch(line); m != nil {
+		count, _ := strconv.Atoi(m[1])
+		unitPrice, _ := strconv.Atoi(strings.ReplaceAll(m[2], ",", ""))
+		return count, unitPrice, count > 0
+	}
+	if m := receiptUnitPriceRe.FindStringSubmatch(line); m != nil {
+		unitPrice, _ := strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+		count, _ := strconv.Atoi(m[2])
+		return count, unitPrice, count > 0
+	}
+	return 0, 0, false
+}
+
+// parseReceiptDate はレシートの日付（と時刻）の行を読み取る。未来の日時は読み取らない
+func parseReceiptDate(line string, now time.Time) (time.Time, bool) {
+	m := receiptDateRe.FindStringSubmatch(line)
+	if m == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	hour, minute := 0, 0
+	if t := receiptTimeRe.FindStringSubmatch(line); t != nil {
+		hour, _ = strconv.Atoi(t[1])
+		minute, _ = strconv.Atoi(t[2])
+	}
+	date := time.Date(year, time.Month(month), day, hour, minute, 0, 0, now.Location())
+	if date.Month() != time.Month(month) || date.After(now) {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// isReceiptNote は金額のない支払い・点数などの行かどうかを返す
+func isReceiptNote(line string) bool {
+	return containsAny(line, receiptIgnoreWords) || containsAny(line, receiptTotalWords)
+}
+
+func containsAny(s string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitReceipt はレシートの下書きを確認した品目を食材としてまとめて登録する。
+// 品目は POST /food-items と同じ規則で検証し、誤りは結果の Errors（Row は items の 1 始まりの番号）に集める。
+// expiry_estimated の品目は賞味期限が下書きの見積もりのままなので、省略した場合と同じく登録する保管場所で見積もり直す。
+// 1 件でも誤りがあれば何も登録しない。
+func (fu *foodItemUsecase) CommitReceipt(userId uint, receipt model.ReceiptCommit) (model.ImportResult, error) {
+	if len(receipt.Items) == 0 {
+		return model.ImportResult{}, apperrors.New(apperrors.ValidationError, "登録する品目がありません", http.StatusBadRequest, nil)
+	}
+	if len(receipt.Items) > maxReceiptLines {
+		return model.ImportResult{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("一度に登録できるのは %d 件までです", maxReceiptLines), http.StatusBadRequest, nil)
+	}
+	result := model.ImportResult{
+		Total:  len(receipt.Items),
+		Items:  []model.FoodItemResponse{},
+		Errors: []model.ImportRowError{},
+	}
+	foodItems := []model.FoodItem{}
+	prepared := []preparedFoodItem{}
+	for i, item := range receipt.Items {
+		foodItem := model.FoodItem{
+			Title:             strings.TrimSpace(item.Title),
+			Quantity:          item.Quantity,
+			Unit:              item.Unit,
+			StorageLocationId: item.StorageLocationId,
+			CategoryId:        item.CategoryId,
+			PurchasedAt:       receipt.PurchasedAt,
+			Price:             item.Price,
+			Currency:          item.Currency,
+			UserId:            userId,
+		}
+		if foodItem.Currency == "" {
+			foodItem.Currency = receipt.Currency
+		}
+		if item.ExpiryDate != nil && !item.ExpiryEstimated {
+			foodItem.ExpiryDate = *item.ExpiryDate
+		}
+		if foodItem.StorageLocationId == nil {
+			foodItem.StorageLocationId = receipt.StorageLocationId
+		}
+		if receipt.HouseholdId != nil {
+			foodItem.HouseholdId = *receipt.HouseholdId
+		}
+		p, err := fu.prepareFoodItem(&foodItem)
+		if err != nil {
+			result.Errors = append(result.Errors, receiptRowErrors(i+1, err)...)
+			continue
+		}
+		foodItems = append(foodItems, foodItem)
+		prepared = append(prepared, p)
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if err := fu.fr.CreateFoodItems(foodItems, userId); err != nil {
+		return model.ImportResult{}, householdError(err)
+	}
+	result.Imported = len(foodItems)
+	for i, foodItem := range foodItems {
+		if !prepared[i].estimated {
+			fu.learnShelfLife(userId, foodItem.Title, prepared[i].locationType, prepared[i].purchasedAt, foodItem.ExpiryDate)
+		}
+		result.Items = append(result.Items, toFoodItemResponse(foodItem))
+	}
+	return result, nil
+}
+
+// receiptRowErrors は品目の検証エラーを項目ごとの誤りに変換する
+func receiptRowErrors(row int, err error) []model.ImportRowError {
+	fields := apperrors.GetFields(err)
+	if len(fields) == 0 {
+		return []model.ImportRowError{{Row: row, Message: err.Error()}}
+	}
+	rowErrors := []model.ImportRowError{}
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		rowErrors = append(rowErrors, model.ImportRowError{Row: row, Column: field, Message: fields[field]})
+	}
+	return rowErrors
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 半角カナで印字された一般的なスーパーのレシート
+const sampleReceipt = `ｽｰﾊﾟｰﾏﾙｴﾂ 中央店
+TEL 03-1234-5678
+2026年10月17日(土) 18:32  ﾚｼﾞ0012
+ｷｬﾍﾞﾂ                 ¥198
+ﾄﾘﾓﾓﾆｸ                ¥598*
+ｷﾞｭｳﾆｭｳ 1000ML        ¥476
+  2ｺ X 単価 238
+ﾀﾏｺﾞ 10ｺ              ¥258
+  値引                -50
+ｹﾁｬｯﾌﾟ                ¥298
+小計                 ¥1,778
+(8%対象             ¥1,778)
+(内消費税等 8%        ¥131)
+合計                 ¥1,778
+お預り               ¥2,000
+お釣り                 ¥222`
+
+func TestParseReceiptLines(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+
+	draft := parseReceiptLines(strings.Split(sampleReceipt, "\n"), now)
+
+	assert.Equal(t, time.Date(2026, 10, 17, 18, 32, 0, 0, time.Local), draft.PurchasedAt)
+	assert.Len(t, draft.Items, 5)
+
+	cabbage := draft.Items[0]
+	assert.Equal(t, "キャベツ", cabbage.Title)
+	assert.Equal(t, "cabbage", cabbage.Ingredient)
+	assert.Equal(t, 198, cabbage.Price)
+	assert.Equal(t, 4, cabbage.Line)
+
+	chicken := draft.Items[1]
+	assert.Equal(t, "トリモモニク", chicken.Title)
+	assert.Equal(t, "chicken", chicken.Ingredient)
+	assert.Equal(t, 598, chicken.Price)
+
+	// 内容量と個数の行から数量を求める
+	milk := draft.Items[2]
+	assert.Equal(t, "ギュウニュウ", milk.Title)
+	assert.Equal(t, "milk", milk.Ingredient)
+	assert.Equal(t, 2, milk.Count)
+	assert.Equal(t, 2000.0, milk.Quantity)
+	assert.Equal(t, model.UnitMilliliter, milk.Unit)
+	assert.Equal(t, 238, milk.UnitPrice)
+	assert.Equal(t, 476, milk.Price)
+
+	// 値引きは直前の商品から差し引く
+	eggs := draft.Items[3]
+	assert.Equal(t, "タマゴ", eggs.Title)
+	assert.Equal(t, 10.0, eggs.Quantity)
+	assert.Equal(t, model.UnitPiece, eggs.Unit)
+	assert.Equal(t, 50, eggs.Discount)
+	assert.Equal(t, 208, eggs.Price)
+
+	ketchup := draft.Items[4]
+	assert.Equal(t, "ケチャップ", ketchup.Title)
+	assert.Empty(t, ketchup.Ingredient)
+
+	assert.Equal(t, 1778, *draft.Subtotal)
+	assert.Equal(t, 131, *draft.Tax)
+	assert.Equal(t, 1778, *draft.Total)
+	assert.Equal(t, []string{"スーパーマルエツ 中央店", "TEL 03-1234-5678"}, draft.SkippedLines)
+}
+
+func TestParseReceiptLines_WithoutDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+
+	draft := parseReceiptLines([]string{"ﾊﾞﾅﾅ ▲20", "@100 x 3", "ﾄｳﾌ 300", "ﾊﾞﾅﾅ 3本 ¥198"}, now)
+
+	assert.Equal(t, now, draft.PurchasedAt)
+	// 商品より前の値引きは対応する商品がないため読み飛ばす
+	assert.Equal(t, []string{"バナナ ▲20"}, draft.SkippedLines)
+	assert.Len(t, draft.Items, 2)
+	// 商品より前の個数の行は次の商品に反映する
+	assert.Equal(t, 3, draft.Items[0].Count)
+	assert.Equal(t, 100, draft.Items[0].UnitPrice)
+	assert.Equal(t, 3.0, draft.Items[1].Quantity)
+}
+
+func TestFoodItemUsecase_ParseReceipt(t *testing.T) {
+	estimator := &stubShelfLifeEstimator{estimate: &model.ShelfLifeEstimate{Days: 14}}
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{se: estimator})
+
+	t.Run("レシートの日付から賞味期限を見積もる", func(t *testing.T) {
+		draft, err := usecase.ParseReceipt(1, "2026/10/01\r\nｷｬﾍﾞﾂ ¥198\r\n")
+
+		assert.NoError(t, err)
+		assert.Len(t, draft.Items, 1)
+		assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local), *draft.Items[0].ExpiryDate)
+		assert.True(t, draft.Items[0].ExpiryEstimated)
+	})
+
+	t.Run("空のレシートは400", func(t *testing.T) {
+		_, err := usecase.ParseReceipt(1, "  \n")
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestFoodItemUsecase_CommitReceipt(t *testing.T) {
+	purchasedAt := time.Now().AddDate(0, 0, -1)
+	expiry := time.Now().AddDate(0, 0, 7)
+	price := 476.0
+
+	t.Run("品目をまとめて登録する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		estimator := &stubShelfLifeEstimator{estimate: &model.ShelfLifeEstimate{Days: 14}}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, se: estimator})
+		mockRepo.On("CreateFoodItems", mock.MatchedBy(func(foodItems []model.FoodItem) bool {
+			return len(foodItems) == 2 &&
+				foodItems[0].Lots[0].PurchasedAt.Equal(purchasedAt) &&
+				foodItems[1].ExpiryDate.Equal(purchasedAt.AddDate(0, 0, 14)) &&
+				foodItems[1].Unit == model.UnitMilliliter &&
+				*foodItems[1].Lots[0].Price == 476 && *foodItems[1].Lots[0].UnitPrice == 0.238 &&
+				foodItems[1].Lots[0].Currency == "JPY"
+		}), uint(1)).Return(nil)
+
+		result, err := usecase.CommitReceipt(1, model.ReceiptCommit{
+			PurchasedAt: &purchasedAt,
+			Currency:    "JPY",
+			Items: []model.ReceiptCommitItem{
+				{Title: "ケチャップ", Quantity: 1, ExpiryDate: &expiry},
+				{Title: "ギュウニュウ", Quantity: 2000, Unit: "ml", Price: &price},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Len(t, result.Items, 2)
+		// 入力された賞味期限だけを学習する
+		assert.Equal(t, []time.Time{expiry}, estimator.learned)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("下書きの見積もりのままの賞味期限は登録する保管場所で見積もり直す", func(t *testing.T) {
+		freezer := uint(5)
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		// 下書きは既定の保管場所（冷蔵）の 2 日、冷凍庫は 60 日
+		estimator := &stubShelfLifeEstimator{estimate: &model.ShelfLifeEstimate{Days: 2}, locationDays: map[model.LocationType]int{model.LocationFreezer: 60}}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo, se: estimator})
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezer).
+			Return(model.StorageLocation{ID: freezer, Type: model.LocationFreezer, HouseholdId: 1}, nil)
+		mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil)
+		mockRepo.On("CreateFoodItems", mock.Anything, uint(1)).Return(nil)
+		drafted := purchasedAt.AddDate(0, 0, 2)
+
+		result, err := usecase.CommitReceipt(1, model.ReceiptCommit{
+			PurchasedAt:       &purchasedAt,
+			StorageLocationId: &freezer,
+			Items:             []model.ReceiptCommitItem{{Title: "トリモモ", Quantity: 1, ExpiryDate: &drafted, ExpiryEstimated: true}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, purchasedAt.AddDate(0, 0, 60), result.Items[0].ExpiryDate)
+		// 見積もりは学習しない
+		assert.Empty(t, estimator.learned)
+	})
+
+	t.Run("expiry_estimated でなければ見積もりと同じ日でも入力した賞味期限を登録する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		estimator := &stubShelfLifeEstimator{estimate: &model.ShelfLifeEstimate{Days: 2}}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, se: estimator})
+		mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil)
+		mockRepo.On("CreateFoodItems", mock.Anything, uint(1)).Return(nil)
+		entered := purchasedAt.AddDate(0, 0, 2)
+
+		result, err := usecase.CommitReceipt(1, model.ReceiptCommit{
+			PurchasedAt: &purchasedAt,
+			Items:       []model.ReceiptCommitItem{{Title: "トリモモ", Quantity: 1, ExpiryDate: &entered}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entered, result.Items[0].ExpiryDate)
+		assert.Len(t, estimator.learned, 1)
+	})
+
+	t.Run("誤りのある品目があれば何も登録しない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+		result, err := usecase.CommitReceipt(1, model.ReceiptCommit{
+			Items: []model.ReceiptCommitItem{
+				{Title: "ケチャップ", Quantity: 1, ExpiryDate: &expiry},
+				{Title: "", Quantity: 1, ExpiryDate: &expiry},
+				{Title: "ソース", Quantity: 1},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.ImportRowError{
+			{Row: 2, Column: "title", Message: "title is required"},
+			{Row: 3, Column: "expiry_date", Message: "expiry_date is required"},
+		}, result.Errors)
+		mockRepo.AssertNotCalled(t, "CreateFoodItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("品目がなければ400", func(t *testing.T) {
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{})
+
+		_, err := usecase.CommitReceipt(1, model.ReceiptCommit{})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+}
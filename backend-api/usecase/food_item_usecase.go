@@ -1,59 +1,772 @@
 package usecase
 
+//go:generate mockgen -source=food_item_usecase.go -destination=../mock/food_item_usecase_mock.go -package=mock
+
 import (
+	"errors"
+	"fmt"
+	apperrors "go-rest-api/errors"
 	"go-rest-api/model"
 	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
 )
 
 type IFoodItemUsecase interface {
-	GetAllFoodItems() ([]model.FoodItem, error)
-	GetFoodItemById(id uint) (model.FoodItem, error)
-	CreateFoodItem(foodItem model.FoodItem) (model.FoodItem, error)
-	UpdateFoodItem(foodItem model.FoodItem) error
-	DeleteFoodItem(id uint) error
+	GetAllFoodItems(userId uint, filter model.FoodItemFilter) ([]model.FoodItemResponse, string, error)
+	GetFoodItemById(userId uint, foodItemId uint) (model.FoodItemResponse, error)
+	CreateFoodItem(foodItem model.FoodItem) (model.FoodItemResponse, error)
+	UpdateFoodItem(foodItem model.FoodItem, userId uint, foodItemId uint) (model.FoodItemResponse, error)
+	PatchFoodItem(patch model.FoodItemPatch, userId uint, foodItemId uint, version uint) (model.FoodItemResponse, error)
+	DeleteFoodItem(userId uint, foodItemId uint) error
+	GetTrashedFoodItems(userId uint) ([]model.FoodItemResponse, error)
+	RestoreFoodItem(userId uint, foodItemId uint) (model.FoodItemResponse, error)
+	PurgeTrashedFoodItems(deletedBefore time.Time) (int64, error)
+	MoveFoodItem(userId uint, foodItemId uint, locationId *uint) (model.LocationMove, error)
+	OpenFoodItem(userId uint, foodItemId uint, openedAt *time.Time, shelfLifeDays *int) (model.FoodItemResponse, error)
+	ChangeFoodState(userId uint, foodItemId uint, state model.FoodState, shelfLifeDays *int) (model.FoodItemResponse, error)
+	GetFoodStateTransitions(userId uint, foodItemId uint) ([]model.FoodStateTransition, error)
+	GetFoodItemsByCategory(userId uint, filter model.FoodItemFilter) ([]model.FoodItemGroup, string, error)
+	GetFoodLots(userId uint, foodItemId uint) ([]model.FoodLot, error)
+	RestockFoodItem(userId uint, foodItemId uint, amount model.Quantity, expiryDate time.Time, price *model.Money, note string) (model.StockChange, error)
+	ConsumeFoodItem(userId uint, foodItemId uint, amount model.Quantity, note string) (model.StockChange, error)
+	GetInventoryMovements(userId uint, foodItemId uint) ([]model.InventoryMovement, error)
+	DiscardFoodItem(waste model.WasteRecord, userId uint, foodItemId uint) (model.StockChange, error)
+	ImportFoodItems(userId uint, r io.Reader, options model.ImportOptions) (model.ImportResult, error)
+	ExportFoodItems(userId uint, filter model.FoodItemFilter, format model.ExportFormat, w io.Writer) error
+	ParseReceipt(userId uint, text string) (model.ReceiptDraft, error)
+	CommitReceipt(userId uint, receipt model.ReceiptCommit) (model.ImportResult, error)
+	UpdateNutrition(userId uint, foodItemId uint, nutrition model.Nutrition) (model.FoodItemResponse, error)
+	DeleteNutrition(userId uint, foodItemId uint) (model.FoodItemResponse, error)
+	GetNutritionSummary(userId uint, filter model.FoodItemFilter) (model.NutritionSummary, error)
+}
+
+// RestockSyncer は在庫が減ったときに、最低在庫を下回った常備品を買い物リストに反映する
+type RestockSyncer interface {
+	SyncRestock(userId uint) error
+}
+
+// ShelfLifeEstimator は賞味期限を省略した食材の期限を見積もり、入力された賞味期限から日持ちを学習する
+type ShelfLifeEstimator interface {
+	EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error)
+	LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time, expiryDate time.Time) error
 }
 
 type foodItemUsecase struct {
-	fr repository.IFoodItemRepository
+	fr  repository.IFoodItemRepository
+	slr repository.IStorageLocationRepository
+	cr  repository.ICategoryRepository
+	tr  repository.ITagRepository
+	usr repository.IUserSettingRepository
+	fv  validator.IFoodItemValidator
+	rs  RestockSyncer
+	se  ShelfLifeEstimator
 }
 
-func NewFoodItemUsecase(fr repository.IFoodItemRepository) IFoodItemUsecase {
-	return &foodItemUsecase{fr}
+func NewFoodItemUsecase(fr repository.IFoodItemRepository, slr repository.IStorageLocationRepository, cr repository.ICategoryRepository, tr repository.ITagRepository, usr repository.IUserSettingRepository, fv validator.IFoodItemValidator, rs RestockSyncer, se ShelfLifeEstimator) IFoodItemUsecase {
+	return &foodItemUsecase{fr, slr, cr, tr, usr, fv, rs, se}
 }
 
-func (fu *foodItemUsecase) GetAllFoodItems() ([]model.FoodItem, error) {
+// GetAllFoodItems は条件に一致する食材を返す。
+// filter.Limit を超える食材がある場合は、次のページを取得するためのカーソルも返す。
+func (fu *foodItemUsecase) GetAllFoodItems(userId uint, filter model.FoodItemFilter) ([]model.FoodItemResponse, string, error) {
+	foodItems, nextCursor, err := fu.listFoodItems(userId, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	resFoodItems := []model.FoodItemResponse{}
+	for _, v := range foodItems {
+		resFoodItems = append(resFoodItems, toFoodItemResponse(v))
+	}
+	return resFoodItems, nextCursor, nil
+}
+
+// listFoodItems は 1 件多く取得して次のページの有無を判定する
+func (fu *foodItemUsecase) listFoodItems(userId uint, filter model.FoodItemFilter) ([]model.FoodItem, string, error) {
+	if filter.Sort.Field != "" && !filter.Sort.Field.IsValid() {
+		return nil, "", apperrors.New(apperrors.ValidationError, "並び替えの項目が正しくありません", http.StatusBadRequest, nil)
+	}
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
 	foodItems := []model.FoodItem{}
-	if err := fu.fr.GetAllFoodItems(&foodItems); err != nil {
-		return nil, err
+	if err := fu.fr.GetAllFoodItems(&foodItems, userId, filter); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 || len(foodItems) <= limit {
+		return foodItems, "", nil
 	}
-	return foodItems, nil
+	foodItems = foodItems[:limit]
+	return foodItems, model.NewFoodItemCursor(foodItems[limit-1], filter.Sort).Encode(), nil
 }
 
-func (fu *foodItemUsecase) GetFoodItemById(id uint) (model.FoodItem, error) {
+func (fu *foodItemUsecase) GetFoodItemById(userId uint, foodItemId uint) (model.FoodItemResponse, error) {
 	foodItem := model.FoodItem{}
-	if err := fu.fr.GetFoodItemById(&foodItem, id); err != nil {
-		return model.FoodItem{}, err
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
 	}
-	return foodItem, nil
+	return toFoodItemResponse(foodItem), nil
 }
 
-func (fu *foodItemUsecase) CreateFoodItem(foodItem model.FoodItem) (model.FoodItem, error) {
+func (fu *foodItemUsecase) CreateFoodItem(foodItem model.FoodItem) (model.FoodItemResponse, error) {
+	prepared, err := fu.prepareFoodItem(&foodItem)
+	if err != nil {
+		return model.FoodItemResponse{}, err
+	}
 	if err := fu.fr.CreateFoodItem(&foodItem); err != nil {
-		return model.FoodItem{}, err
+		return model.FoodItemResponse{}, householdError(err)
+	}
+	if !prepared.estimated {
+		fu.learnShelfLife(foodItem.UserId, foodItem.Title, prepared.locationType, prepared.purchasedAt, foodItem.ExpiryDate)
 	}
-	return foodItem, nil
+	return toFoodItemResponse(foodItem), nil
 }
 
-func (fu *foodItemUsecase) UpdateFoodItem(foodItem model.FoodItem) error {
-	if err := fu.fr.UpdateFoodItem(&foodItem); err != nil {
-		return err
+// preparedFoodItem は登録前の準備で決まった、食材の購入日時・保管場所の種類と賞味期限を見積もったかどうか
+type preparedFoodItem struct {
+	purchasedAt  time.Time
+	locationType model.LocationType
+	estimated    bool
+}
+
+// prepareFoodItem は登録する食材を検証し、賞味期限の見積もり・単位の正規化・栄養成分の補完・最初のロットの設定を行う。
+// 賞味期限を省略した場合は購入日時と日持ちの目安から求め、目安がなければ検証で必須エラーになる。
+// 保管場所を指定した場合は登録先の世帯を決め、保管場所が同じ世帯のものであることを確認する。
+func (fu *foodItemUsecase) prepareFoodItem(foodItem *model.FoodItem) (preparedFoodItem, error) {
+	prepared := preparedFoodItem{purchasedAt: time.Now()}
+	if foodItem.PurchasedAt != nil {
+		prepared.purchasedAt = *foodItem.PurchasedAt
+	}
+	if foodItem.StorageLocationId != nil {
+		location, err := fu.getStorageLocation(foodItem.UserId, *foodItem.StorageLocationId)
+		if err != nil {
+			return prepared, err
+		}
+		if err := fu.fr.ResolveHousehold(&foodItem.HouseholdId, foodItem.UserId); err != nil {
+			return prepared, householdError(err)
+		}
+		if location.HouseholdId != foodItem.HouseholdId {
+			return prepared, apperrors.LocationHouseholdMismatch
+		}
+		prepared.locationType = location.Type
+	}
+	if foodItem.ExpiryDate.IsZero() && strings.TrimSpace(foodItem.Title) != "" {
+		estimate, err := fu.se.EstimateShelfLife(foodItem.UserId, foodItem.Title, prepared.locationType, prepared.purchasedAt)
+		if err == nil {
+			foodItem.ExpiryDate = estimate.ExpiryDate
+			prepared.estimated = true
+		} else if !errors.Is(err, apperrors.ShelfLifeUnknown) {
+			return prepared, err
+		}
+	}
+	if err := fu.fv.FoodItemValidate(*foodItem); err != nil {
+		return prepared, validationError(err)
 	}
+	if err := normalizeUnit(foodItem); err != nil {
+		return prepared, err
+	}
+	if err := fu.resolveClassification(foodItem, foodItem.UserId); err != nil {
+		return prepared, err
+	}
+	fillNutrition(foodItem)
+	// 開封と保存状態の変更は OpenFoodItem と ChangeFoodState で記録する（冷凍庫に登録した食材は冷凍にする）
+	foodItem.OpenedAt = nil
+	foodItem.State = model.StateFresh
+	foodItem.StateExpiryDate = nil
+	foodItem.StateTransitions = nil
+	applyStorageState(foodItem, prepared.locationType, prepared.purchasedAt)
+	// 登録時の数量と賞味期限を最初のロットにする
+	foodItem.Lots = nil
+	if foodItem.Quantity > 0 {
+		lot := model.FoodLot{
+			Quantity:    foodItem.Quantity,
+			ExpiryDate:  foodItem.ExpiryDate,
+			PurchasedAt: prepared.purchasedAt,
+		}
+		if foodItem.Price != nil {
+			currency, _ := model.ParseCurrency(foodItem.Currency)
+			lot.SetPrice(model.Money{Amount: *foodItem.Price, Currency: currency})
+		}
+		foodItem.Lots = []model.FoodLot{lot}
+	}
+	return prepared, nil
+}
+
+// UpdateFoodItem は食材の名前・カテゴリ・タグを更新する。
+// 数量・単位・賞味期限はロットから集計するため、RestockFoodItem・ConsumeFoodItem・DiscardFoodItem で変更する。
+// foodItem.Version には編集を始めたときのバージョンを指定する。他の更新が先に行われていた場合は
+// apperrors.VersionConflict と最新の食材を返す。
+func (fu *foodItemUsecase) UpdateFoodItem(foodItem model.FoodItem, userId uint, foodItemId uint) (model.FoodItemResponse, error) {
+	if err := fu.fv.FoodItemUpdateValidate(foodItem); err != nil {
+		return model.FoodItemResponse{}, validationError(err)
+	}
+	if err := fu.resolveClassification(&foodItem, userId); err != nil {
+		return model.FoodItemResponse{}, err
+	}
+	if err := fu.fr.UpdateFoodItem(&foodItem, userId, foodItemId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 食材が存在するならバージョンが古い。呼び出し側で比べられるよう最新の内容を返す
+			current := model.FoodItem{}
+			if getErr := fu.fr.GetFoodItemById(&current, userId, foodItemId); getErr == nil {
+				return toFoodItemResponse(current), apperrors.VersionConflict
+			}
+		}
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// PatchFoodItem は patch で指定された項目だけを変更する（JSON Merge Patch）。
+// version が現在のバージョンと一致しない場合は apperrors.VersionConflict と最新の食材を返す。
+func (fu *foodItemUsecase) PatchFoodItem(patch model.FoodItemPatch, userId uint, foodItemId uint, version uint) (model.FoodItemResponse, error) {
+	current := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&current, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	if current.Version != version {
+		return toFoodItemResponse(current), apperrors.VersionConflict
+	}
+	foodItem := model.FoodItem{Title: current.Title, CategoryId: current.CategoryId, Version: version}
+	patch.ApplyTo(&foodItem)
+	return fu.UpdateFoodItem(foodItem, userId, foodItemId)
+}
+
+func (fu *foodItemUsecase) DeleteFoodItem(userId uint, foodItemId uint) error {
+	if err := fu.fr.DeleteFoodItem(userId, foodItemId); err != nil {
+		return foodItemError(err)
+	}
+	fu.syncRestock(userId, foodItemId)
 	return nil
 }
 
-func (fu *foodItemUsecase) DeleteFoodItem(id uint) error {
-	if err := fu.fr.DeleteFoodItem(id); err != nil {
+// GetTrashedFoodItems はごみ箱にある食材を削除日時の新しい順に返す
+func (fu *foodItemUsecase) GetTrashedFoodItems(userId uint) ([]model.FoodItemResponse, error) {
+	foodItems := []model.FoodItem{}
+	if err := fu.fr.GetTrashedFoodItems(&foodItems, userId); err != nil {
+		return nil, err
+	}
+	resFoodItems := []model.FoodItemResponse{}
+	for _, v := range foodItems {
+		resFoodItems = append(resFoodItems, toFoodItemResponse(v))
+	}
+	return resFoodItems, nil
+}
+
+// RestoreFoodItem はごみ箱にある食材を元に戻す
+func (fu *foodItemUsecase) RestoreFoodItem(userId uint, foodItemId uint) (model.FoodItemResponse, error) {
+	foodItem := model.FoodItem{}
+	if err := fu.fr.RestoreFoodItem(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// PurgeTrashedFoodItems は deletedBefore より前にごみ箱に移動した全ユーザーの食材を完全に削除する
+func (fu *foodItemUsecase) PurgeTrashedFoodItems(deletedBefore time.Time) (int64, error) {
+	return fu.fr.PurgeFoodItems(deletedBefore)
+}
+
+// MoveFoodItem は食材を別の保管場所へ移動し、移動履歴を返す。
+// locationId が nil の場合は保管場所なしにする。食材と異なる世帯の保管場所には移動できない。
+// 冷凍庫に入れると冷凍、冷凍庫から出すと解凍に保存状態を変え（model.FoodState.AfterMove）、
+// 期限を ChangeFoodState と同じ日数で計算し直す。
+func (fu *foodItemUsecase) MoveFoodItem(userId uint, foodItemId uint, locationId *uint) (model.LocationMove, error) {
+	move := model.LocationMove{ToLocationId: locationId}
+	if locationId != nil {
+		location, err := fu.getStorageLocation(userId, *locationId)
+		if err != nil {
+			return model.LocationMove{}, err
+		}
+		move.ToType = location.Type
+	}
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.LocationMove{}, foodItemError(err)
+	}
+	var fromType model.LocationType
+	if foodItem.StorageLocationId != nil {
+		location, err := fu.getStorageLocation(userId, *foodItem.StorageLocationId)
+		if err != nil {
+			return model.LocationMove{}, err
+		}
+		fromType = location.Type
+	}
+	if state, ok := foodItem.State.AfterMove(fromType, move.ToType); ok {
+		transition := changeFoodState(&foodItem, state, nil, time.Now())
+		move.StateTransition = &transition
+	}
+	if err := fu.fr.MoveFoodItem(&move, userId, foodItemId); err != nil {
+		if errors.Is(err, model.ErrLocationHouseholdMismatch) {
+			return model.LocationMove{}, apperrors.LocationHouseholdMismatch
+		}
+		if errors.Is(err, model.ErrInvalidStateTransition) {
+			return model.LocationMove{}, apperrors.InvalidStateTransition
+		}
+		return model.LocationMove{}, foodItemError(err)
+	}
+	return move, nil
+}
+
+// OpenFoodItem は食材を開封済みにする。openedAt が nil の場合は現在時刻を開封日時にする。
+// 開封後の日数は shelfLifeDays、食材の日数、カテゴリ（親カテゴリを含む）の日数の順に決め、
+// どれもない場合は開封日時だけを記録する（期限は賞味期限のまま）。開封済みの食材は開封日時を置き換える。
+func (fu *foodItemUsecase) OpenFoodItem(userId uint, foodItemId uint, openedAt *time.Time, shelfLifeDays *int) (model.FoodItemResponse, error) {
+	now := time.Now()
+	if openedAt == nil {
+		openedAt = &now
+	}
+	if openedAt.After(now) {
+		return model.FoodItemResponse{}, apperrors.New(apperrors.ValidationError, "開封日時に未来の日時は指定できません", http.StatusBadRequest, nil)
+	}
+	if shelfLifeDays != nil && (*shelfLifeDays < 1 || *shelfLifeDays > validator.FoodItemOpenedShelfLifeMaxDays) {
+		return model.FoodItemResponse{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("開封後の日数は1から%dの範囲で指定してください", validator.FoodItemOpenedShelfLifeMaxDays), http.StatusBadRequest, nil)
+	}
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	if shelfLifeDays == nil {
+		shelfLifeDays = foodItem.OpenedShelfLifeDays
+	}
+	if shelfLifeDays == nil && foodItem.Category != nil {
+		shelfLifeDays = foodItem.Category.OpenedShelfLife()
+	}
+	foodItem.OpenedAt = openedAt
+	foodItem.OpenedShelfLifeDays = shelfLifeDays
+	if err := fu.fr.OpenFoodItem(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// ChangeFoodState は食材の保存状態を state に変更し、期限を計算し直して変更履歴を記録する。
+// 期限の計算は changeFoodState を参照。
+// 現在の状態から変更できない場合は apperrors.InvalidStateTransition を返す。
+func (fu *foodItemUsecase) ChangeFoodState(userId uint, foodItemId uint, state model.FoodState, shelfLifeDays *int) (model.FoodItemResponse, error) {
+	if shelfLifeDays != nil && (*shelfLifeDays < 1 || *shelfLifeDays > validator.FoodItemStateShelfLifeMaxDays) {
+		return model.FoodItemResponse{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("日持ちの日数は1から%dの範囲で指定してください", validator.FoodItemStateShelfLifeMaxDays), http.StatusBadRequest, nil)
+	}
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	from := foodItem.State.OrDefault()
+	if !from.CanTransitionTo(state) {
+		return model.FoodItemResponse{}, apperrors.InvalidStateTransition
+	}
+	transition := changeFoodState(&foodItem, state, shelfLifeDays, time.Now())
+	if err := fu.fr.ChangeFoodState(&foodItem, &transition, userId, foodItemId); err != nil {
+		if errors.Is(err, model.ErrInvalidStateTransition) {
+			return model.FoodItemResponse{}, apperrors.InvalidStateTransition
+		}
+		return model.FoodItemResponse{}, foodItemError(err)
+	}
+	return toFoodItemResponse(foodItem), nil
+}
+
+// changeFoodState は foodItem の保存状態を state に変更して期限を計算し直し、変更履歴を返す。
+// 新しい期限は now から shelfLifeDays 日後で、省略した場合はカテゴリ（親カテゴリを含む）の日数、
+// カテゴリにもなければ model.DefaultStateShelfLifeDays の日数を使う。
+// 冷凍は期限を縮めないため、今の期限のほうが遅い場合（冷凍食品の賞味期限など）はその期限のままにする。
+func changeFoodState(foodItem *model.FoodItem, state model.FoodState, shelfLifeDays *int, now time.Time) model.FoodStateTransition {
+	days := model.DefaultStateShelfLifeDays[state]
+	if foodItem.Category != nil {
+		days = foodItem.Category.StateShelfLife(state)
+	}
+	if shelfLifeDays != nil {
+		days = *shelfLifeDays
+	}
+	expiry := now.AddDate(0, 0, days)
+	if current := foodItem.EffectiveExpiryDate(); state == model.StateFrozen && current.After(expiry) {
+		expiry = current
+	}
+	transition := model.FoodStateTransition{
+		FromState:      foodItem.State.OrDefault(),
+		ToState:        state,
+		ShelfLifeDays:  days,
+		ExpiryBefore:   foodItem.EffectiveExpiryDate(),
+		ExpiryAfter:    expiry,
+		TransitionedAt: now,
+	}
+	foodItem.State = state
+	foodItem.StateExpiryDate = &expiry
+	return transition
+}
+
+// applyStorageState は登録する食材を保管場所の種類に応じた保存状態にする。
+// 冷凍庫に登録した食材は移動した場合と同じく冷凍にし（model.FoodState.AfterMove）、
+// 変更履歴を foodItem.StateTransitions に加えて食材と一緒に作成する。
+func applyStorageState(foodItem *model.FoodItem, locationType model.LocationType, storedAt time.Time) {
+	state, ok := foodItem.State.AfterMove("", locationType)
+	if !ok {
+		return
+	}
+	transition := changeFoodState(foodItem, state, nil, storedAt)
+	transition.UserId = foodItem.UserId
+	foodItem.StateTransitions = append(foodItem.StateTransitions, transition)
+}
+
+// GetFoodStateTransitions は食材の保存状態の変更履歴を新しい順に返す
+func (fu *foodItemUsecase) GetFoodStateTransitions(userId uint, foodItemId uint) ([]model.FoodStateTransition, error) {
+	transitions := []model.FoodStateTransition{}
+	if err := fu.fr.GetFoodStateTransitions(&transitions, userId, foodItemId); err != nil {
+		return nil, foodItemError(err)
+	}
+	return transitions, nil
+}
+
+// GetFoodLots は食材のロットを賞味期限の早い順に返す
+func (fu *foodItemUsecase) GetFoodLots(userId uint, foodItemId uint) ([]model.FoodLot, error) {
+	lots := []model.FoodLot{}
+	if err := fu.fr.GetFoodLots(&lots, userId, foodItemId); err != nil {
+		return nil, foodItemError(err)
+	}
+	return lots, nil
+}
+
+// RestockFoodItem は購入した分を新しいロットとして追加し、増減を記録する。
+// 数量は食材の単位に換算して記録する。price を指定した場合はロットの価格として記録する。
+func (fu *foodItemUsecase) RestockFoodItem(userId uint, foodItemId uint, amount model.Quantity, expiryDate time.Time, price *model.Money, note string) (model.StockChange, error) {
+	if expiryDate.IsZero() {
+		return model.StockChange{}, apperrors.New(apperrors.ValidationError, "賞味期限を指定してください", http.StatusBadRequest, nil)
+	}
+	if price != nil {
+		normalized, err := normalizePrice(*price)
+		if err != nil {
+			return model.StockChange{}, err
+		}
+		price = &normalized
+	}
+	quantity, err := fu.toItemUnit(userId, foodItemId, amount)
+	if err != nil {
+		return model.StockChange{}, err
+	}
+	foodItem := model.FoodItem{}
+	lot := model.FoodLot{Quantity: quantity, ExpiryDate: expiryDate, PurchasedAt: time.Now()}
+	if price != nil {
+		lot.SetPrice(*price)
+	}
+	movement := model.InventoryMovement{Delta: quantity, Reason: model.MovementRestock, Note: note}
+	if err := fu.fr.RestockFoodItem(&foodItem, &lot, &movement, userId, foodItemId); err != nil {
+		return model.StockChange{}, foodItemError(err)
+	}
+	var locationType model.LocationType
+	if foodItem.StorageLocationId != nil {
+		if location, err := fu.getStorageLocation(userId, *foodItem.StorageLocationId); err == nil {
+			locationType = location.Type
+		}
+	}
+	fu.learnShelfLife(userId, foodItem.Title, locationType, lot.PurchasedAt, lot.ExpiryDate)
+	return model.StockChange{FoodItem: toFoodItemResponse(foodItem), Movement: movement}, nil
+}
+
+// ConsumeFoodItem は賞味期限の早いロットから順に消費し、増減を記録する。
+// 在庫がなくなった食材は、ユーザー設定に応じて削除するか数量 0 で残す。
+func (fu *foodItemUsecase) ConsumeFoodItem(userId uint, foodItemId uint, amount model.Quantity, note string) (model.StockChange, error) {
+	quantity, err := fu.toItemUnit(userId, foodItemId, amount)
+	if err != nil {
+		return model.StockChange{}, err
+	}
+	setting := model.UserSetting{}
+	if err := fu.usr.GetUserSetting(&setting, userId); err != nil {
+		return model.StockChange{}, err
+	}
+	foodItem := model.FoodItem{}
+	movement := model.InventoryMovement{Delta: -quantity, Reason: model.MovementConsume, Note: note}
+	if err := fu.fr.ConsumeFoodItem(&foodItem, &movement, setting.DeleteEmptyItems, userId, foodItemId); err != nil {
+		if errors.Is(err, model.ErrInsufficientStock) {
+			return model.StockChange{}, apperrors.InsufficientStock
+		}
+		return model.StockChange{}, foodItemError(err)
+	}
+	fu.syncRestock(userId, foodItemId)
+	return model.StockChange{
+		FoodItem: toFoodItemResponse(foodItem),
+		Movement: movement,
+		Deleted:  setting.DeleteEmptyItems && foodItem.Quantity <= 0,
+	}, nil
+}
+
+// DiscardFoodItem は食材を廃棄する。在庫は消費と同じく賞味期限の早いロットから差し引き、
+// 廃棄の記録を消費とは別に保存する。waste の数量は食材の単位に換算して記録する。
+func (fu *foodItemUsecase) DiscardFoodItem(waste model.WasteRecord, userId uint, foodItemId uint) (model.StockChange, error) {
+	if !waste.Reason.IsValid() {
+		return model.StockChange{}, apperrors.New(apperrors.ValidationError, "廃棄理由は expired, spoiled, leftover, other のいずれかを指定してください", http.StatusBadRequest, nil)
+	}
+	if waste.EstimatedCost != nil && *waste.EstimatedCost < 0 {
+		return model.StockChange{}, apperrors.New(apperrors.ValidationError, "推定金額は0以上を指定してください", http.StatusBadRequest, nil)
+	}
+	currency, err := model.ParseCurrency(waste.Currency)
+	if err != nil {
+		return model.StockChange{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	waste.Currency = currency
+	quantity, err := fu.toItemUnit(userId, foodItemId, model.Quantity{Amount: waste.Quantity, Unit: waste.Unit})
+	if err != nil {
+		return model.StockChange{}, err
+	}
+	setting := model.UserSetting{}
+	if err := fu.usr.GetUserSetting(&setting, userId); err != nil {
+		return model.StockChange{}, err
+	}
+	foodItem := model.FoodItem{}
+	waste.Quantity = quantity
+	waste.DiscardedAt = time.Now()
+	movement := model.InventoryMovement{Delta: -quantity, Reason: model.MovementDiscard, Note: waste.Note}
+	if err := fu.fr.DiscardFoodItem(&foodItem, &movement, &waste, setting.DeleteEmptyItems, userId, foodItemId); err != nil {
+		if errors.Is(err, model.ErrInsufficientStock) {
+			return model.StockChange{}, apperrors.InsufficientStock
+		}
+		return model.StockChange{}, foodItemError(err)
+	}
+	fu.syncRestock(userId, foodItemId)
+	return model.StockChange{
+		FoodItem: toFoodItemResponse(foodItem),
+		Movement: movement,
+		Deleted:  setting.DeleteEmptyItems && foodItem.Quantity <= 0,
+		Waste:    &waste,
+	}, nil
+}
+
+// GetInventoryMovements は食材の在庫の増減の記録を新しい順に返す
+func (fu *foodItemUsecase) GetInventoryMovements(userId uint, foodItemId uint) ([]model.InventoryMovement, error) {
+	movements := []model.InventoryMovement{}
+	if err := fu.fr.GetInventoryMovements(&movements, userId, foodItemId); err != nil {
+		return nil, foodItemError(err)
+	}
+	return movements, nil
+}
+
+// toItemUnit は数量を検証し、食材の単位に換算する。
+// 単位が省略された場合は食材の単位とみなす。
+func (fu *foodItemUsecase) toItemUnit(userId uint, foodItemId uint, amount model.Quantity) (float64, error) {
+	if amount.Validate() != nil || amount.Amount == 0 {
+		return 0, apperrors.New(apperrors.ValidationError, "数量は0より大きい値を指定してください", http.StatusBadRequest, nil)
+	}
+	foodItem := model.FoodItem{}
+	if err := fu.fr.GetFoodItemById(&foodItem, userId, foodItemId); err != nil {
+		return 0, foodItemError(err)
+	}
+	if amount.Unit == "" {
+		return amount.Amount, nil
+	}
+	unit, err := model.ParseUnit(string(amount.Unit))
+	if err != nil {
+		return 0, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	converted, err := model.Quantity{Amount: amount.Amount, Unit: unit}.ConvertTo(foodItem.Unit)
+	if err != nil {
+		return 0, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	return converted.Amount, nil
+}
+
+// GetFoodItemsByCategory は食材をカテゴリごとにまとめて返す。
+// グループはカテゴリが最初に現れた順に並び、未分類の食材は最後のグループになる。
+// ページングはグループ分けの前の食材の並びに対して行う。
+func (fu *foodItemUsecase) GetFoodItemsByCategory(userId uint, filter model.FoodItemFilter) ([]model.FoodItemGroup, string, error) {
+	foodItems, nextCursor, err := fu.listFoodItems(userId, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	groups := []model.FoodItemGroup{}
+	index := map[uint]int{}
+	uncategorized := model.FoodItemGroup{Items: []model.FoodItemResponse{}}
+	for _, v := range foodItems {
+		if v.Category == nil {
+			uncategorized.Items = append(uncategorized.Items, toFoodItemResponse(v))
+			continue
+		}
+		i, ok := index[v.Category.ID]
+		if !ok {
+			category := toCategoryResponse(*v.Category)
+			groups = append(groups, model.FoodItemGroup{Category: &category, Items: []model.FoodItemResponse{}})
+			i = len(groups) - 1
+			index[v.Category.ID] = i
+		}
+		groups[i].Items = append(groups[i].Items, toFoodItemResponse(v))
+	}
+	if len(uncategorized.Items) > 0 {
+		groups = append(groups, uncategorized)
+	}
+	return groups, nextCursor, nil
+}
+
+// resolveClassification はカテゴリの存在を確認し、タグ名をユーザーのタグに変換する。
+// TagNames が nil の場合はタグを変更しない。
+func (fu *foodItemUsecase) resolveClassification(foodItem *model.FoodItem, userId uint) error {
+	if foodItem.CategoryId != nil {
+		category := model.Category{}
+		if err := fu.cr.GetCategoryById(&category, *foodItem.CategoryId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.CategoryNotFound
+			}
+			return err
+		}
+	}
+	if foodItem.TagNames == nil {
+		return nil
+	}
+	tags := []model.Tag{}
+	if err := fu.tr.GetOrCreateTags(&tags, userId, normalizeTagNames(foodItem.TagNames)); err != nil {
 		return err
 	}
+	foodItem.Tags = tags
+	return nil
+}
+
+// normalizeTagNames は前後の空白を除き、空のタグと重複を取り除く
+func normalizeTagNames(names []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// getStorageLocation はユーザーが所属する世帯の保管場所であることを確認して取得する。
+// 食材と同じ世帯のものかどうかは呼び出し側で確認する
+func (fu *foodItemUsecase) getStorageLocation(userId uint, locationId uint) (model.StorageLocation, error) {
+	location := model.StorageLocation{}
+	if err := fu.slr.GetStorageLocationById(&location, userId, locationId); err != nil {
+		return model.StorageLocation{}, storageLocationError(err)
+	}
+	return location, nil
+}
+
+// toFoodItemResponse はモデルをレスポンス用の構造体に変換する
+func toFoodItemResponse(foodItem model.FoodItem) model.FoodItemResponse {
+	return model.FoodItemResponse{
+		ID:                  foodItem.ID,
+		Title:               foodItem.Title,
+		Quantity:            foodItem.Quantity,
+		Unit:                foodItem.Unit,
+		ExpiryDate:          foodItem.ExpiryDate,
+		OpenedAt:            foodItem.OpenedAt,
+		OpenedShelfLifeDays: foodItem.OpenedShelfLifeDays,
+		State:               foodItem.State.OrDefault(),
+		StateExpiryDate:     foodItem.StateExpiryDate,
+		Nutrition:           nutrition(foodItem),
+		EffectiveExpiryDate: foodItem.EffectiveExpiryDate(),
+		CreatedAt:           foodItem.CreatedAt,
+		UpdatedAt:           foodItem.UpdatedAt,
+		Version:             foodItem.Version,
+		HouseholdId:         foodItem.HouseholdId,
+		StorageLocationId:   foodItem.StorageLocationId,
+		CategoryId:          foodItem.CategoryId,
+		Tags:                tagNames(foodItem.Tags),
+		Lots:                foodItem.Lots,
+		DeletedAt:           deletedAt(foodItem),
+	}
+}
+
+// nutrition は登録されている栄養成分を返す。登録されていない場合は nil
+func nutrition(foodItem model.FoodItem) *model.Nutrition {
+	if !foodItem.Nutrition.IsSet() {
+		return nil
+	}
+	n := foodItem.Nutrition
+	return &n
+}
+
+// deletedAt はごみ箱に移動した日時を返す。ごみ箱にない場合は nil
+func deletedAt(foodItem model.FoodItem) *time.Time {
+	if !foodItem.DeletedAt.Valid {
+		return nil
+	}
+	t := foodItem.DeletedAt.Time
+	return &t
+}
+
+// tagNames はタグ名を返す。タグはユーザーごとのため、世帯のメンバーが同じ名前のタグを付けた場合は 1 つにまとめる
+func tagNames(tags []model.Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		if !slices.Contains(names, tag.Name) {
+			names = append(names, tag.Name)
+		}
+	}
+	return names
+}
+
+// syncRestock は在庫の減少を、食材の世帯のメンバー全員の買い物リストに反映する。
+// 最低在庫と買い物リストはユーザーごとのため、在庫を変更したユーザー userId 以外のリストも更新する。
+// 在庫の変更は確定しているため、反映に失敗してもエラーにせず記録だけを残す。
+func (fu *foodItemUsecase) syncRestock(userId uint, foodItemId uint) {
+	memberIds := []uint{}
+	if err := fu.fr.GetHouseholdMemberIds(&memberIds, foodItemId); err != nil {
+		log.Printf("食材 %d の世帯のメンバーの取得に失敗しました: %v", foodItemId, err)
+		memberIds = []uint{userId}
+	}
+	for _, memberId := range memberIds {
+		if err := fu.rs.SyncRestock(memberId); err != nil {
+			log.Printf("買い物リストへの補充の反映に失敗しました（ユーザー %d）: %v", memberId, err)
+		}
+	}
+}
+
+// learnShelfLife は入力された賞味期限から日持ちを学習する。失敗しても食材の登録は取り消さない
+func (fu *foodItemUsecase) learnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time, expiryDate time.Time) {
+	if err := fu.se.LearnShelfLife(userId, title, locationType, purchasedAt, expiryDate); err != nil {
+		log.Printf("日持ちの学習に失敗しました（ユーザー %d）: %v", userId, err)
+	}
+}
+
+// normalizeUnit は単位の表記ゆれ（"個" や "l" など）を単位コードに揃える。
+// 単位が省略された場合は個数として扱う。
+func normalizeUnit(foodItem *model.FoodItem) error {
+	unit, err := model.ParseUnit(string(foodItem.Unit))
+	if err != nil {
+		return apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	foodItem.Unit = unit
 	return nil
 }
+
+// normalizePrice は購入金額の範囲を検証し、通貨コードをそろえる
+func normalizePrice(price model.Money) (model.Money, error) {
+	if price.Amount < 0 || price.Amount > validator.FoodItemPriceMax {
+		return model.Money{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("金額は 0 以上 %d 以下で指定してください", validator.FoodItemPriceMax), http.StatusBadRequest, nil)
+	}
+	currency, err := model.ParseCurrency(price.Currency)
+	if err != nil {
+		return model.Money{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	price.Currency = currency
+	return price, nil
+}
+
+// foodItemError はリポジトリの「レコードなし」を 404 のアプリケーションエラーに変換する
+func foodItemError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.FoodItemNotFound
+	}
+	return err
+}
+
+// validationError は検証の誤りを、項目ごとのメッセージを持つ apperrors.AppError に変換する
+func validationError(err error) error {
+	fields := map[string]string{}
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		for field, fieldErr := range errs {
+			fields[field] = fieldErr.Error()
+		}
+	}
+	return apperrors.NewValidationError(err.Error(), fields)
+}
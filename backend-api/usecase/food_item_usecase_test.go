@@ -0,0 +1,1022 @@
+package usecase
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// foodItemUsecaseDeps はテストで差し替える食材のユースケースの依存。
+// 指定しなかった依存は newFoodItemUsecase が空のモックとスタブで補う
+type foodItemUsecaseDeps struct {
+	fr  repository.IFoodItemRepository
+	slr repository.IStorageLocationRepository
+	cr  repository.ICategoryRepository
+	tr  repository.ITagRepository
+	usr repository.IUserSettingRepository
+	rs  RestockSyncer
+	se  ShelfLifeEstimator
+}
+
+// newFoodItemUsecase はテスト用の食材のユースケースを作成する
+func newFoodItemUsecase(deps foodItemUsecaseDeps) IFoodItemUsecase {
+	if deps.fr == nil {
+		deps.fr = new(MockFoodItemRepository)
+	}
+	if deps.slr == nil {
+		deps.slr = new(MockStorageLocationRepository)
+	}
+	if deps.cr == nil {
+		deps.cr = new(MockCategoryRepository)
+	}
+	if deps.tr == nil {
+		deps.tr = new(MockTagRepository)
+	}
+	if deps.usr == nil {
+		deps.usr = new(MockUserSettingRepository)
+	}
+	if deps.rs == nil {
+		deps.rs = &stubRestockSyncer{}
+	}
+	if deps.se == nil {
+		deps.se = &stubShelfLifeEstimator{}
+	}
+	return NewFoodItemUsecase(deps.fr, deps.slr, deps.cr, deps.tr, deps.usr, validator.NewFoodItemValidator(), deps.rs, deps.se)
+}
+
+// stubRestockSyncer は買い物リストへの反映を呼び出したユーザーを記録する
+type stubRestockSyncer struct {
+	userIds []uint
+	err     error
+}
+
+func (s *stubRestockSyncer) SyncRestock(userId uint) error {
+	s.userIds = append(s.userIds, userId)
+	return s.err
+}
+
+// stubShelfLifeEstimator は estimate を日持ちの目安として返し、学習した入力を記録する。
+// estimate が nil の場合は目安がないものとして扱う。locationDays に保管場所の種類の日数があればそれを使う
+type stubShelfLifeEstimator struct {
+	estimate     *model.ShelfLifeEstimate
+	locationDays map[model.LocationType]int
+	learned      []time.Time
+}
+
+func (s *stubShelfLifeEstimator) EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error) {
+	if s.estimate == nil {
+		return model.ShelfLifeEstimate{}, apperrors.ShelfLifeUnknown
+	}
+	estimate := *s.estimate
+	estimate.LocationType = locationType
+	if days, ok := s.locationDays[locationType]; ok {
+		estimate.Days = days
+	}
+	estimate.ExpiryDate = purchasedAt.AddDate(0, 0, estimate.Days)
+	return estimate, nil
+}
+
+func (s *stubShelfLifeEstimator) LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time, expiryDate time.Time) error {
+	s.learned = append(s.learned, expiryDate)
+	return nil
+}
+
+func TestFoodItemUsecase_GetAllFoodItems(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	foodItems := []model.FoodItem{
+		{ID: 1, Title: "トマト", Quantity: 2, ExpiryDate: time.Now(), UserId: 1},
+	}
+	mockRepo.On("GetAllFoodItems", mock.AnythingOfType("*[]model.FoodItem"), uint(1), model.FoodItemFilter{}).
+		Return(foodItems, nil)
+
+	// テスト実行
+	res, nextCursor, err := usecase.GetAllFoodItems(1, model.FoodItemFilter{})
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "トマト", res[0].Title)
+	assert.Empty(t, nextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFoodItemUsecase_GetAllFoodItems_NextCursor(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	sort := model.FoodItemSort{Field: model.SortByTitle}
+	// 次のページの有無を判定するため 1 件多く取得する
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), model.FoodItemFilter{Sort: sort, Limit: 3}).Return([]model.FoodItem{
+		{ID: 4, Title: "いちご"},
+		{ID: 2, Title: "なす"},
+		{ID: 9, Title: "りんご"},
+	}, nil)
+
+	res, nextCursor, err := usecase.GetAllFoodItems(1, model.FoodItemFilter{Sort: sort, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	cursor, err := model.DecodeFoodItemCursor(nextCursor, sort)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), cursor.ID)
+	assert.Equal(t, "なす", cursor.Value)
+}
+
+func TestFoodItemUsecase_GetAllFoodItems_InvalidSort(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	_, _, err := usecase.GetAllFoodItems(1, model.FoodItemFilter{Sort: model.FoodItemSort{Field: "user_id"}})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	mockRepo.AssertNotCalled(t, "GetAllFoodItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_CrossUserAccess(t *testing.T) {
+	// リポジトリは所有者が一致しない場合 gorm.ErrRecordNotFound を返す
+	tests := []struct {
+		name string
+		call func(uc IFoodItemUsecase, m *MockFoodItemRepository) error
+	}{
+		{
+			name: "他ユーザーの食材を取得できない",
+			call: func(uc IFoodItemUsecase, m *MockFoodItemRepository) error {
+				m.On("GetFoodItemById", mock.Anything, uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+				_, err := uc.GetFoodItemById(1, 99)
+				return err
+			},
+		},
+		{
+			name: "他ユーザーの食材を更新できない",
+			call: func(uc IFoodItemUsecase, m *MockFoodItemRepository) error {
+				m.On("UpdateFoodItem", mock.Anything, uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+				m.On("GetFoodItemById", mock.Anything, uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+				_, err := uc.UpdateFoodItem(model.FoodItem{Title: "横取り"}, 1, 99)
+				return err
+			},
+		},
+		{
+			name: "他ユーザーの食材を削除できない",
+			call: func(uc IFoodItemUsecase, m *MockFoodItemRepository) error {
+				m.On("DeleteFoodItem", uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+				return uc.DeleteFoodItem(1, 99)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFoodItemRepository)
+			usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+			err := tt.call(usecase, mockRepo)
+
+			assert.ErrorIs(t, err, apperrors.FoodItemNotFound)
+			assert.Equal(t, http.StatusNotFound, apperrors.GetHTTPStatus(err))
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFoodItemUsecase_CreateFoodItem_Unit(t *testing.T) {
+	tests := []struct {
+		name     string
+		unit     model.Unit
+		wantUnit model.Unit
+		wantErr  bool
+	}{
+		{name: "単位省略は個数", unit: "", wantUnit: model.UnitPiece},
+		{name: "日本語の助数詞", unit: "本", wantUnit: model.UnitHon},
+		{name: "未対応の単位", unit: "ダース", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFoodItemRepository)
+			usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+			if !tt.wantErr {
+				mockRepo.On("CreateFoodItem", mock.AnythingOfType("*model.FoodItem")).Return(nil)
+			}
+
+			res, err := usecase.CreateFoodItem(model.FoodItem{Title: "牛乳", Quantity: 1.5, Unit: tt.unit, ExpiryDate: time.Now().AddDate(0, 0, 7), UserId: 1})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+				mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnit, res.Unit)
+			assert.Equal(t, 1.5, res.Quantity)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFoodItemUsecase_CreateFoodItem_Household(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+	// 所属していない世帯を指定した場合、リポジトリはレコードなしを返す
+	mockRepo.On("CreateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+		return foodItem.HouseholdId == 9
+	})).Return(gorm.ErrRecordNotFound)
+
+	_, err := usecase.CreateFoodItem(model.FoodItem{Title: "牛乳", Quantity: 1, ExpiryDate: time.Now().AddDate(0, 0, 7), UserId: 1, HouseholdId: 9})
+
+	assert.ErrorIs(t, err, apperrors.HouseholdNotFound)
+}
+
+func TestFoodItemUsecase_MoveFoodItem(t *testing.T) {
+	freezerId := uint(5)
+
+	fridgeId := uint(6)
+	days := func(n int) *int { return &n }
+	expiry := time.Now().AddDate(0, 0, 3)
+	chicken := &model.Category{Name: "鶏肉", FrozenShelfLifeDays: days(60), ThawedShelfLifeDays: days(1)}
+	newUsecase := func(foodItem model.FoodItem) (IFoodItemUsecase, *MockFoodItemRepository) {
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezerId).
+			Return(model.StorageLocation{ID: freezerId, Name: "冷凍庫", Type: model.LocationFreezer, UserId: 1}, nil).Maybe()
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), fridgeId).
+			Return(model.StorageLocation{ID: fridgeId, Name: "冷蔵庫", Type: model.LocationFridge, UserId: 1}, nil).Maybe()
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = foodItem
+		}).Return(nil)
+		return newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo}), mockRepo
+	}
+
+	t.Run("冷凍庫へ移すと冷凍してカテゴリの日数で期限を計算し直す", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken, StorageLocationId: &fridgeId})
+		mockRepo.On("MoveFoodItem", mock.MatchedBy(func(move *model.LocationMove) bool {
+			return *move.ToLocationId == freezerId && move.ToType == model.LocationFreezer
+		}), uint(1), uint(10)).Return(nil)
+
+		move, err := usecase.MoveFoodItem(1, 10, &freezerId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.LocationFreezer, move.ToType)
+		if assert.NotNil(t, move.StateTransition) {
+			assert.Equal(t, model.StateFresh, move.StateTransition.FromState)
+			assert.Equal(t, model.StateFrozen, move.StateTransition.ToState)
+			assert.Equal(t, 60, move.StateTransition.ShelfLifeDays)
+			assert.True(t, move.StateTransition.ExpiryBefore.Equal(expiry))
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("冷凍庫から出すと解凍する", func(t *testing.T) {
+		frozenExpiry := time.Now().AddDate(0, 2, 0)
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken, StorageLocationId: &freezerId, State: model.StateFrozen, StateExpiryDate: &frozenExpiry})
+		mockRepo.On("MoveFoodItem", mock.Anything, uint(1), uint(10)).Return(nil)
+
+		move, err := usecase.MoveFoodItem(1, 10, &fridgeId)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, move.StateTransition) {
+			assert.Equal(t, model.StateThawed, move.StateTransition.ToState)
+			assert.Equal(t, 1, move.StateTransition.ShelfLifeDays)
+		}
+	})
+
+	t.Run("解凍済みの食材は冷凍庫へ移しても状態を変えない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken, State: model.StateThawed})
+		mockRepo.On("MoveFoodItem", mock.Anything, uint(1), uint(10)).Return(nil)
+
+		move, err := usecase.MoveFoodItem(1, 10, &freezerId)
+
+		assert.NoError(t, err)
+		assert.Nil(t, move.StateTransition)
+	})
+
+	t.Run("移動中に状態が変わった場合は409", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, StorageLocationId: &fridgeId})
+		mockRepo.On("MoveFoodItem", mock.Anything, uint(1), uint(10)).Return(model.ErrInvalidStateTransition)
+
+		_, err := usecase.MoveFoodItem(1, 10, &freezerId)
+
+		assert.ErrorIs(t, err, apperrors.InvalidStateTransition)
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+	})
+
+	t.Run("他ユーザーの保管場所には移動できない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo})
+
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezerId).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := usecase.MoveFoodItem(1, 10, &freezerId)
+
+		assert.ErrorIs(t, err, apperrors.StorageLocationNotFound)
+		mockRepo.AssertNotCalled(t, "MoveFoodItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("食材と別の世帯の保管場所には移動できない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo})
+
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezerId).
+			Return(model.StorageLocation{ID: freezerId, Type: model.LocationFreezer, HouseholdId: 2}, nil)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Return(nil)
+		mockRepo.On("MoveFoodItem", mock.Anything, uint(1), uint(10)).Return(model.ErrLocationHouseholdMismatch)
+
+		_, err := usecase.MoveFoodItem(1, 10, &freezerId)
+
+		assert.ErrorIs(t, err, apperrors.LocationHouseholdMismatch)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestFoodItemUsecase_CreateFoodItem_LocationHousehold(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	mockLocationRepo := new(MockStorageLocationRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo})
+	locationId := uint(5)
+	mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), locationId).
+		Return(model.StorageLocation{ID: locationId, Type: model.LocationFridge, HouseholdId: 2}, nil)
+	// 世帯を省略した場合は個人の世帯（1）に登録するため、世帯 2 の保管場所は使えない
+	mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil)
+
+	_, err := usecase.CreateFoodItem(model.FoodItem{Title: "牛乳", Quantity: 1, ExpiryDate: time.Now().AddDate(0, 0, 7), StorageLocationId: &locationId, UserId: 1})
+
+	assert.ErrorIs(t, err, apperrors.LocationHouseholdMismatch)
+	mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+}
+
+func TestFoodItemUsecase_OpenFoodItem(t *testing.T) {
+	days := func(n int) *int { return &n }
+	expiry := time.Now().AddDate(1, 0, 0)
+	newUsecase := func(foodItem model.FoodItem) (IFoodItemUsecase, *MockFoodItemRepository) {
+		mockRepo := new(MockFoodItemRepository)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = foodItem
+		}).Return(nil)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+		return usecase, mockRepo
+	}
+
+	t.Run("親カテゴリの日数で開封後の期限を決める", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "ソース", ExpiryDate: expiry, Category: &model.Category{
+			Name: "ソース・たれ", Parent: &model.Category{Name: "調味料", OpenedShelfLifeDays: days(30)},
+		}})
+		mockRepo.On("OpenFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+			return foodItem.OpenedAt != nil && *foodItem.OpenedShelfLifeDays == 30
+		}), uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.OpenFoodItem(1, 10, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expiry, res.ExpiryDate)
+		assert.InDelta(t, 30, time.Until(res.EffectiveExpiryDate).Hours()/24, 0.01)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("指定した日数は食材の日数より優先する", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "牛乳", ExpiryDate: expiry, OpenedShelfLifeDays: days(5)})
+		mockRepo.On("OpenFoodItem", mock.Anything, uint(1), uint(10)).Return(nil)
+		openedAt := time.Now().AddDate(0, 0, -1)
+
+		res, err := usecase.OpenFoodItem(1, 10, &openedAt, days(2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, *res.OpenedShelfLifeDays)
+		assert.Equal(t, openedAt.AddDate(0, 0, 2), res.EffectiveExpiryDate)
+	})
+
+	t.Run("日数が分からなければ期限は賞味期限のまま", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "米", ExpiryDate: expiry})
+		mockRepo.On("OpenFoodItem", mock.Anything, uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.OpenFoodItem(1, 10, nil, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res.OpenedAt)
+		assert.Nil(t, res.OpenedShelfLifeDays)
+		assert.Equal(t, expiry, res.EffectiveExpiryDate)
+	})
+
+	t.Run("未来の開封日時と範囲外の日数は受け付けない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10})
+		future := time.Now().Add(time.Hour)
+
+		_, err := usecase.OpenFoodItem(1, 10, &future, nil)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		_, err = usecase.OpenFoodItem(1, 10, nil, days(0))
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "OpenFoodItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_ChangeFoodState(t *testing.T) {
+	days := func(n int) *int { return &n }
+	expiry := time.Now().AddDate(0, 0, 3)
+	chicken := &model.Category{Name: "鶏肉", FrozenShelfLifeDays: days(60), Parent: &model.Category{
+		Name: "肉", FrozenShelfLifeDays: days(90), ThawedShelfLifeDays: days(1),
+	}}
+	newUsecase := func(foodItem model.FoodItem) (IFoodItemUsecase, *MockFoodItemRepository) {
+		mockRepo := new(MockFoodItemRepository)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = foodItem
+		}).Return(nil)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+		return usecase, mockRepo
+	}
+
+	t.Run("冷凍するとカテゴリの日数で期限を延ばす", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken})
+		mockRepo.On("ChangeFoodState", mock.Anything, mock.MatchedBy(func(transition *model.FoodStateTransition) bool {
+			return transition.FromState == model.StateFresh && transition.ToState == model.StateFrozen &&
+				transition.ShelfLifeDays == 60 && transition.ExpiryBefore.Equal(expiry)
+		}), uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.ChangeFoodState(1, 10, model.StateFrozen, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StateFrozen, res.State)
+		assert.Equal(t, expiry, res.ExpiryDate)
+		assert.InDelta(t, 60, time.Until(res.EffectiveExpiryDate).Hours()/24, 0.01)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("冷凍しても今の期限より早めない", func(t *testing.T) {
+		printed := time.Now().AddDate(0, 6, 0)
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "冷凍えび", ExpiryDate: printed})
+		mockRepo.On("ChangeFoodState", mock.Anything, mock.MatchedBy(func(transition *model.FoodStateTransition) bool {
+			return transition.ExpiryAfter.Equal(printed)
+		}), uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.ChangeFoodState(1, 10, model.StateFrozen, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, printed, res.EffectiveExpiryDate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("解凍すると親カテゴリの日数で期限を縮める", func(t *testing.T) {
+		frozenExpiry := time.Now().AddDate(0, 2, 0)
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken, State: model.StateFrozen, StateExpiryDate: &frozenExpiry})
+		mockRepo.On("ChangeFoodState", mock.Anything, mock.Anything, uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.ChangeFoodState(1, 10, model.StateThawed, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StateThawed, res.State)
+		assert.InDelta(t, 1, time.Until(res.EffectiveExpiryDate).Hours()/24, 0.01)
+	})
+
+	t.Run("指定した日数はカテゴリの日数より優先する", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", ExpiryDate: expiry, Category: chicken})
+		mockRepo.On("ChangeFoodState", mock.Anything, mock.Anything, uint(1), uint(10)).Return(nil)
+
+		res, err := usecase.ChangeFoodState(1, 10, model.StateCooked, days(2))
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 2, time.Until(*res.StateExpiryDate).Hours()/24, 0.01)
+	})
+
+	t.Run("変更できない状態は409", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉", State: model.StateThawed})
+
+		_, err := usecase.ChangeFoodState(1, 10, model.StateFrozen, nil)
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "ChangeFoodState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("同時に状態が変わっていれば409", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10, Title: "鶏もも肉"})
+		mockRepo.On("ChangeFoodState", mock.Anything, mock.Anything, uint(1), uint(10)).Return(model.ErrInvalidStateTransition)
+
+		_, err := usecase.ChangeFoodState(1, 10, model.StateFrozen, nil)
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+	})
+
+	t.Run("範囲外の日数は受け付けない", func(t *testing.T) {
+		usecase, mockRepo := newUsecase(model.FoodItem{ID: 10})
+
+		_, err := usecase.ChangeFoodState(1, 10, model.StateFrozen, days(0))
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "GetFoodItemById", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_CreateFoodItem_Classification(t *testing.T) {
+	vegetables := uint(1)
+
+	t.Run("タグ名を正規化してタグに変換する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockCategoryRepo := new(MockCategoryRepository)
+		mockTagRepo := new(MockTagRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, cr: mockCategoryRepo, tr: mockTagRepo})
+
+		mockCategoryRepo.On("GetCategoryById", mock.Anything, vegetables).
+			Return(model.Category{ID: vegetables, Code: "vegetables", Name: "野菜"}, nil)
+		mockTagRepo.On("GetOrCreateTags", mock.Anything, uint(1), []string{"特売", "作り置き"}).
+			Return([]model.Tag{{ID: 1, Name: "作り置き"}, {ID: 2, Name: "特売"}}, nil)
+		mockRepo.On("CreateFoodItem", mock.AnythingOfType("*model.FoodItem")).Return(nil)
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{
+			Title:      "キャベツ",
+			ExpiryDate: time.Now().AddDate(0, 0, 7),
+			UserId:     1,
+			CategoryId: &vegetables,
+			TagNames:   []string{" 特売", "作り置き", "", "特売"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &vegetables, res.CategoryId)
+		assert.Equal(t, []string{"作り置き", "特売"}, res.Tags)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しないカテゴリは指定できない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockCategoryRepo := new(MockCategoryRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, cr: mockCategoryRepo})
+
+		missing := uint(999)
+		mockCategoryRepo.On("GetCategoryById", mock.Anything, missing).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := usecase.CreateFoodItem(model.FoodItem{Title: "キャベツ", ExpiryDate: time.Now().AddDate(0, 0, 7), UserId: 1, CategoryId: &missing})
+
+		assert.ErrorIs(t, err, apperrors.CategoryNotFound)
+		mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_GetFoodItemsByCategory(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	vegetables := model.Category{ID: 1, Code: "vegetables", Name: "野菜"}
+	mockRepo.On("GetAllFoodItems", mock.Anything, uint(1), model.FoodItemFilter{}).Return([]model.FoodItem{
+		{ID: 1, Title: "キャベツ", Category: &vegetables, CategoryId: &vegetables.ID},
+		{ID: 2, Title: "謎の瓶"},
+		{ID: 3, Title: "にんじん", Category: &vegetables, CategoryId: &vegetables.ID},
+	}, nil)
+
+	groups, _, err := usecase.GetFoodItemsByCategory(1, model.FoodItemFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "野菜", groups[0].Category.Name)
+	assert.Len(t, groups[0].Items, 2)
+	assert.Nil(t, groups[1].Category)
+	assert.Equal(t, "謎の瓶", groups[1].Items[0].Title)
+}
+
+func TestFoodItemUsecase_CreateFoodItem_InitialLot(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	expiry := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("CreateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+		return len(foodItem.Lots) == 1 && foodItem.Lots[0].Quantity == 1 && foodItem.Lots[0].ExpiryDate.Equal(expiry)
+	})).Return(nil)
+
+	_, err := usecase.CreateFoodItem(model.FoodItem{Title: "牛乳", Quantity: 1, Unit: model.UnitLiter, ExpiryDate: expiry, UserId: 1})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFoodItemUsecase_CreateFoodItem_EstimatedExpiry(t *testing.T) {
+	freezer := uint(5)
+	purchasedAt := time.Now().AddDate(0, 0, -1)
+
+	t.Run("賞味期限を省略すると購入日時と保管場所の日持ちから求める", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		estimator := &stubShelfLifeEstimator{estimate: &model.ShelfLifeEstimate{Ingredient: "chicken", Days: 60}}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo, se: estimator})
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezer).
+			Return(model.StorageLocation{ID: freezer, Type: model.LocationFreezer, HouseholdId: 1}, nil)
+		mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil)
+		mockRepo.On("CreateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+			return foodItem.Lots[0].PurchasedAt.Equal(purchasedAt)
+		})).Return(nil)
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{Title: "鶏もも肉", Quantity: 1, PurchasedAt: &purchasedAt, StorageLocationId: &freezer, UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, purchasedAt.AddDate(0, 0, 60), res.ExpiryDate)
+		// 見積もった期限は学習しない
+		assert.Empty(t, estimator.learned)
+	})
+
+	t.Run("冷凍庫に登録した食材は冷凍にする", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		mockLocationRepo := new(MockStorageLocationRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, slr: mockLocationRepo})
+		mockLocationRepo.On("GetStorageLocationById", mock.Anything, uint(1), freezer).
+			Return(model.StorageLocation{ID: freezer, Type: model.LocationFreezer, HouseholdId: 1}, nil)
+		mockRepo.On("ResolveHousehold", mock.Anything, uint(1)).Return(uint(1), nil)
+		mockRepo.On("CreateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+			return len(foodItem.StateTransitions) == 1 &&
+				foodItem.StateTransitions[0].ToState == model.StateFrozen &&
+				foodItem.StateTransitions[0].UserId == 1
+		})).Return(nil)
+		expiry := purchasedAt.AddDate(0, 0, 2)
+
+		res, err := usecase.CreateFoodItem(model.FoodItem{Title: "豚こま", Quantity: 1, ExpiryDate: expiry, PurchasedAt: &purchasedAt, StorageLocationId: &freezer, UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.StateFrozen, res.State)
+		assert.Equal(t, expiry, res.ExpiryDate)
+		// 冷凍した日（購入日時）から既定の 30 日
+		assert.Equal(t, purchasedAt.AddDate(0, 0, 30), res.EffectiveExpiryDate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("入力された賞味期限から日持ちを学習する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		estimator := &stubShelfLifeEstimator{}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, se: estimator})
+		mockRepo.On("CreateFoodItem", mock.Anything).Return(nil)
+		expiry := time.Now().AddDate(0, 0, 3)
+
+		_, err := usecase.CreateFoodItem(model.FoodItem{Title: "食パン", Quantity: 1, ExpiryDate: expiry, UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{expiry}, estimator.learned)
+	})
+
+	t.Run("目安がなければ賞味期限は必須", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+		_, err := usecase.CreateFoodItem(model.FoodItem{Title: "ケチャップ", Quantity: 1, UserId: 1})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_ConsumeFoodItem(t *testing.T) {
+	// 食材の単位は L
+	stubItem := func(m *MockFoodItemRepository) {
+		m.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 10, Title: "牛乳", Quantity: 1.5, Unit: model.UnitLiter, UserId: 1}
+			}).
+			Return(nil)
+		m.On("GetHouseholdMemberIds", mock.Anything, uint(10)).Return([]uint{1}, nil).Maybe()
+	}
+	newUsecase := func(m *MockFoodItemRepository, deleteEmptyItems bool) IFoodItemUsecase {
+		settingRepo := new(MockUserSettingRepository)
+		settingRepo.On("GetUserSetting", mock.Anything, uint(1)).
+			Return(model.UserSetting{UserId: 1, DeleteEmptyItems: deleteEmptyItems}, nil)
+		return newFoodItemUsecase(foodItemUsecaseDeps{fr: m, usr: settingRepo})
+	}
+
+	t.Run("指定した単位を食材の単位に換算して消費を記録する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo, false)
+		stubItem(mockRepo)
+		mockRepo.On("ConsumeFoodItem", mock.Anything, mock.MatchedBy(func(movement *model.InventoryMovement) bool {
+			return movement.Delta == -0.2 && movement.Reason == model.MovementConsume && movement.Note == "朝食"
+		}), false, uint(1), uint(10)).Return(nil)
+
+		change, err := usecase.ConsumeFoodItem(1, 10, model.Quantity{Amount: 200, Unit: "ml"}, "朝食")
+
+		assert.NoError(t, err)
+		assert.Equal(t, -0.2, change.Movement.Delta)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("使い切った食材を設定に応じて削除する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo, true)
+		stubItem(mockRepo)
+		mockRepo.On("ConsumeFoodItem", mock.Anything, mock.Anything, true, uint(1), uint(10)).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 10, Title: "牛乳", Quantity: 0, Unit: model.UnitLiter}
+			}).
+			Return(nil)
+
+		change, err := usecase.ConsumeFoodItem(1, 10, model.Quantity{Amount: 1.5}, "")
+
+		assert.NoError(t, err)
+		assert.True(t, change.Deleted)
+	})
+
+	t.Run("換算できない単位は指定できない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo, false)
+		stubItem(mockRepo)
+
+		_, err := usecase.ConsumeFoodItem(1, 10, model.Quantity{Amount: 200, Unit: "g"}, "")
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "ConsumeFoodItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("在庫不足", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo, false)
+		stubItem(mockRepo)
+		mockRepo.On("ConsumeFoodItem", mock.Anything, mock.Anything, false, uint(1), uint(10)).Return(model.ErrInsufficientStock)
+
+		_, err := usecase.ConsumeFoodItem(1, 10, model.Quantity{Amount: 3}, "")
+
+		assert.ErrorIs(t, err, apperrors.InsufficientStock)
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestFoodItemUsecase_RestockFoodItem(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	expiry := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 10, Unit: model.UnitLiter, UserId: 1}
+		}).
+		Return(nil)
+	mockRepo.On("RestockFoodItem", mock.Anything, mock.MatchedBy(func(lot *model.FoodLot) bool {
+		return lot.Quantity == 2 && lot.ExpiryDate.Equal(expiry) &&
+			*lot.Price == 476 && *lot.UnitPrice == 238 && lot.Currency == "JPY"
+	}), mock.MatchedBy(func(movement *model.InventoryMovement) bool {
+		return movement.Delta == 2 && movement.Reason == model.MovementRestock
+	}), uint(1), uint(10)).Return(nil)
+
+	_, err := usecase.RestockFoodItem(1, 10, model.Quantity{Amount: 2000, Unit: "ml"}, expiry, &model.Money{Amount: 476, Currency: "jpy"}, "")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+
+	t.Run("通貨コードの形式", func(t *testing.T) {
+		_, err := usecase.RestockFoodItem(1, 10, model.Quantity{Amount: 1, Unit: "L"}, expiry, &model.Money{Amount: 100, Currency: "円"}, "")
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestFoodItemUsecase_DiscardFoodItem(t *testing.T) {
+	newUsecase := func(m *MockFoodItemRepository) IFoodItemUsecase {
+		settingRepo := new(MockUserSettingRepository)
+		settingRepo.On("GetUserSetting", mock.Anything, uint(1)).Return(model.UserSetting{UserId: 1}, nil)
+		return newFoodItemUsecase(foodItemUsecaseDeps{fr: m, usr: settingRepo})
+	}
+
+	t.Run("廃棄を消費とは別に記録する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).
+			Run(func(args mock.Arguments) {
+				*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 10, Title: "鶏むね肉", Unit: model.UnitGram, UserId: 1}
+			}).
+			Return(nil)
+		cost := 180.0
+		mockRepo.On("DiscardFoodItem", mock.Anything, mock.MatchedBy(func(movement *model.InventoryMovement) bool {
+			return movement.Delta == -300 && movement.Reason == model.MovementDiscard
+		}), mock.MatchedBy(func(waste *model.WasteRecord) bool {
+			return waste.Quantity == 300 && waste.Reason == model.WasteSpoiled && *waste.EstimatedCost == cost && waste.Currency == model.DefaultCurrency && !waste.DiscardedAt.IsZero()
+		}), false, uint(1), uint(10)).Return(nil)
+		mockRepo.On("GetHouseholdMemberIds", mock.Anything, uint(10)).Return([]uint{1}, nil)
+
+		change, err := usecase.DiscardFoodItem(model.WasteRecord{Quantity: 0.3, Unit: "kg", Reason: model.WasteSpoiled, EstimatedCost: &cost}, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.WasteSpoiled, change.Waste.Reason)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("未定義の廃棄理由", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		usecase := newUsecase(mockRepo)
+
+		_, err := usecase.DiscardFoodItem(model.WasteRecord{Quantity: 1, Reason: "forgot"}, 1, 10)
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "DiscardFoodItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFoodItemUsecase_Trash(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	deletedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	mockRepo.On("GetTrashedFoodItems", mock.Anything, uint(1)).Return([]model.FoodItem{
+		{ID: 3, Title: "豆腐", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}, nil)
+	mockRepo.On("RestoreFoodItem", mock.Anything, uint(1), uint(3)).Run(func(args mock.Arguments) {
+		*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 3, Title: "豆腐"}
+	}).Return(nil)
+	mockRepo.On("RestoreFoodItem", mock.Anything, uint(1), uint(4)).Return(gorm.ErrRecordNotFound)
+
+	trashed, err := usecase.GetTrashedFoodItems(1)
+	assert.NoError(t, err)
+	assert.Len(t, trashed, 1)
+	assert.Equal(t, deletedAt, *trashed[0].DeletedAt)
+
+	restored, err := usecase.RestoreFoodItem(1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "豆腐", restored.Title)
+	assert.Nil(t, restored.DeletedAt)
+
+	// ごみ箱にない食材は戻せない
+	_, err = usecase.RestoreFoodItem(1, 4)
+	assert.Equal(t, apperrors.FoodItemNotFound, err)
+}
+
+func TestFoodItemUsecase_UpdateFoodItem_VersionConflict(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	mockRepo.On("UpdateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+		return foodItem.Version == 2
+	}), uint(1), uint(5)).Return(gorm.ErrRecordNotFound)
+	mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(5)).Run(func(args mock.Arguments) {
+		*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 5, Title: "先に更新された名前", Version: 3}
+	}).Return(nil)
+
+	current, err := usecase.UpdateFoodItem(model.FoodItem{Title: "古い画面からの更新", Version: 2}, 1, 5)
+
+	assert.ErrorIs(t, err, apperrors.VersionConflict)
+	assert.Equal(t, http.StatusPreconditionFailed, apperrors.GetHTTPStatus(err))
+	assert.Equal(t, uint(3), current.Version)
+	assert.Equal(t, "先に更新された名前", current.Title)
+}
+
+func TestFoodItemUsecase_PatchFoodItem(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	mockCategoryRepo := new(MockCategoryRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, cr: mockCategoryRepo})
+
+	categoryId := uint(4)
+	mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(5)).Run(func(args mock.Arguments) {
+		*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 5, Title: "りんご", CategoryId: &categoryId, Version: 2}
+	}).Return(nil)
+	mockCategoryRepo.On("GetCategoryById", mock.Anything, categoryId).Return(model.Category{ID: categoryId}, nil)
+	// 指定していないカテゴリとタグはそのまま残す
+	mockRepo.On("UpdateFoodItem", mock.MatchedBy(func(foodItem *model.FoodItem) bool {
+		return foodItem.Title == "青りんご" && foodItem.CategoryId != nil && *foodItem.CategoryId == categoryId && foodItem.Tags == nil && foodItem.Version == 2
+	}), uint(1), uint(5)).Return(nil)
+
+	patch := model.FoodItemPatch{Title: model.PatchField[string]{Set: true, Value: "青りんご"}}
+	updated, err := usecase.PatchFoodItem(patch, 1, 5, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "青りんご", updated.Title)
+	mockRepo.AssertExpectations(t)
+
+	// バージョンが古い場合は更新せずに最新の食材を返す
+	current, err := usecase.PatchFoodItem(patch, 1, 5, 1)
+	assert.ErrorIs(t, err, apperrors.VersionConflict)
+	assert.Equal(t, uint(2), current.Version)
+	mockRepo.AssertNumberOfCalls(t, "UpdateFoodItem", 1)
+
+	// 名前を空にはできない
+	_, err = usecase.PatchFoodItem(model.FoodItemPatch{Title: model.PatchField[string]{Set: true}}, 1, 5, 2)
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+}
+
+func TestFoodItemUsecase_CreateFoodItem_Validation(t *testing.T) {
+	price := 1980.0
+	tests := []struct {
+		name       string
+		foodItem   model.FoodItem
+		wantFields []string
+	}{
+		{
+			name:       "名前・数量・賞味期限がない",
+			foodItem:   model.FoodItem{Quantity: -1, UserId: 1},
+			wantFields: []string{"title", "quantity", "expiry_date"},
+		},
+		{
+			name:       "数量が数値でない",
+			foodItem:   model.FoodItem{Title: "米", Quantity: math.NaN(), ExpiryDate: time.Now(), UserId: 1},
+			wantFields: []string{"quantity"},
+		},
+		{
+			name:       "名前が長すぎる",
+			foodItem:   model.FoodItem{Title: strings.Repeat("あ", validator.FoodItemTitleMaxLength+1), Quantity: 1, ExpiryDate: time.Now(), UserId: 1},
+			wantFields: []string{"title"},
+		},
+		{
+			name:       "数量が多すぎる",
+			foodItem:   model.FoodItem{Title: "米", Quantity: validator.FoodItemQuantityMax + 1, ExpiryDate: time.Now(), UserId: 1},
+			wantFields: []string{"quantity"},
+		},
+		{
+			name:       "賞味期限が遠すぎる過去",
+			foodItem:   model.FoodItem{Title: "牛乳", Quantity: 1, ExpiryDate: time.Now().AddDate(-2, 0, 0), UserId: 1},
+			wantFields: []string{"expiry_date"},
+		},
+		{
+			name:       "賞味期限が遠すぎる未来",
+			foodItem:   model.FoodItem{Title: "缶詰", Quantity: 1, ExpiryDate: time.Now().AddDate(20, 0, 0), UserId: 1},
+			wantFields: []string{"expiry_date"},
+		},
+		{
+			name:       "数量 0 の価格は記録できない",
+			foodItem:   model.FoodItem{Title: "米", Quantity: 0, ExpiryDate: time.Now().AddDate(0, 6, 0), Price: &price, UserId: 1},
+			wantFields: []string{"price"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFoodItemRepository)
+			usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+			_, err := usecase.CreateFoodItem(tt.foodItem)
+
+			assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+			fields := apperrors.GetFields(err)
+			assert.Len(t, fields, len(tt.wantFields))
+			for _, field := range tt.wantFields {
+				assert.Contains(t, fields, field)
+			}
+			mockRepo.AssertNotCalled(t, "CreateFoodItem", mock.Anything)
+		})
+	}
+}
+
+func TestFoodItemUsecase_UpdateFoodItem_Validation(t *testing.T) {
+	mockRepo := new(MockFoodItemRepository)
+	usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo})
+
+	// 数量と賞味期限はロットで管理するため、更新では名前だけを検証する
+	_, err := usecase.UpdateFoodItem(model.FoodItem{Version: 1}, 1, 5)
+
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	assert.Equal(t, map[string]string{"title": "title is required"}, apperrors.GetFields(err))
+	mockRepo.AssertNotCalled(t, "UpdateFoodItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFoodItemUsecase_SyncRestock(t *testing.T) {
+	t.Run("消費と削除の後に世帯のメンバー全員の買い物リストへ反映する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		settingRepo := new(MockUserSettingRepository)
+		syncer := &stubRestockSyncer{}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, usr: settingRepo, rs: syncer})
+		settingRepo.On("GetUserSetting", mock.Anything, uint(1)).Return(model.UserSetting{UserId: 1}, nil)
+		mockRepo.On("GetFoodItemById", mock.Anything, uint(1), uint(10)).Run(func(args mock.Arguments) {
+			*args.Get(0).(*model.FoodItem) = model.FoodItem{ID: 10, Title: "卵", Quantity: 6, Unit: model.UnitPiece}
+		}).Return(nil)
+		mockRepo.On("ConsumeFoodItem", mock.Anything, mock.Anything, false, uint(1), uint(10)).Return(nil)
+		mockRepo.On("DeleteFoodItem", uint(1), uint(10)).Return(nil)
+		mockRepo.On("GetHouseholdMemberIds", mock.Anything, uint(10)).Return([]uint{1, 2}, nil)
+
+		_, err := usecase.ConsumeFoodItem(1, 10, model.Quantity{Amount: 2}, "")
+		assert.NoError(t, err)
+		assert.NoError(t, usecase.DeleteFoodItem(1, 10))
+
+		assert.Equal(t, []uint{1, 2, 1, 2}, syncer.userIds)
+	})
+
+	t.Run("メンバーを取得できない場合は操作したユーザーだけ反映する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		syncer := &stubRestockSyncer{}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, rs: syncer})
+		mockRepo.On("DeleteFoodItem", uint(1), uint(10)).Return(nil)
+		mockRepo.On("GetHouseholdMemberIds", mock.Anything, uint(10)).Return(nil, errors.New("connection refused"))
+
+		assert.NoError(t, usecase.DeleteFoodItem(1, 10))
+		assert.Equal(t, []uint{1}, syncer.userIds)
+	})
+
+	t.Run("反映に失敗しても消費は成功する", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		syncer := &stubRestockSyncer{err: errors.New("connection refused")}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, rs: syncer})
+		mockRepo.On("DeleteFoodItem", uint(1), uint(10)).Return(nil)
+		mockRepo.On("GetHouseholdMemberIds", mock.Anything, uint(10)).Return([]uint{1}, nil)
+
+		assert.NoError(t, usecase.DeleteFoodItem(1, 10))
+	})
+
+	t.Run("削除できなかった場合は反映しない", func(t *testing.T) {
+		mockRepo := new(MockFoodItemRepository)
+		syncer := &stubRestockSyncer{}
+		usecase := newFoodItemUsecase(foodItemUsecaseDeps{fr: mockRepo, rs: syncer})
+		mockRepo.On("DeleteFoodItem", uint(1), uint(10)).Return(gorm.ErrRecordNotFound)
+
+		assert.Error(t, usecase.DeleteFoodItem(1, 10))
+		assert.Empty(t, syncer.userIds)
+	})
+}
@@ -0,0 +1,332 @@
+package usecase
+
+//go:generate mockgen -source=household_usecase.go -destination=../mock/household_usecase_mock.go -package=mock
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultInvitationTTL は有効期限を指定しなかった招待の有効期間
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+type IHouseholdUsecase interface {
+	GetHouseholds(userId uint) ([]model.HouseholdResponse, error)
+	GetHouseholdById(userId uint, householdId uint) (model.HouseholdResponse, error)
+	CreateHousehold(household model.Household, userId uint) (model.HouseholdResponse, error)
+	UpdateHousehold(household model.Household, userId uint, householdId uint) (model.HouseholdResponse, error)
+	GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error)
+	UpdateMemberRole(memberRole model.HouseholdMemberRole, userId uint, householdId uint, memberId uint) (model.HouseholdResponse, error)
+	RemoveMember(userId uint, householdId uint, memberId uint) error
+	TransferOwnership(transfer model.HouseholdTransfer, userId uint, householdId uint) (model.HouseholdResponse, error)
+	LeaveHousehold(userId uint, householdId uint) error
+	CreateInvitation(request model.HouseholdInvitationRequest, userId uint, householdId uint) (model.HouseholdInvitationResponse, error)
+	GetInvitations(householdId uint) ([]model.HouseholdInvitationResponse, error)
+	AcceptInvitation(token string, userId uint) (model.HouseholdResponse, error)
+	DeclineInvitation(token string, userId uint) error
+}
+
+type householdUsecase struct {
+	hr repository.IHouseholdRepository
+	hv validator.IHouseholdValidator
+}
+
+func NewHouseholdUsecase(hr repository.IHouseholdRepository, hv validator.IHouseholdValidator) IHouseholdUsecase {
+	return &householdUsecase{hr, hv}
+}
+
+func (hu *householdUsecase) GetHouseholds(userId uint) ([]model.HouseholdResponse, error) {
+	households := []model.Household{}
+	if err := hu.hr.GetHouseholds(&households, userId); err != nil {
+		return nil, err
+	}
+	resHouseholds := []model.HouseholdResponse{}
+	for _, v := range households {
+		resHouseholds = append(resHouseholds, toHouseholdResponse(v))
+	}
+	return resHouseholds, nil
+}
+
+func (hu *householdUsecase) GetHouseholdById(userId uint, householdId uint) (model.HouseholdResponse, error) {
+	household := model.Household{}
+	if err := hu.hr.GetHouseholdById(&household, userId, householdId); err != nil {
+		return model.HouseholdResponse{}, householdError(err)
+	}
+	return toHouseholdResponse(household), nil
+}
+
+// CreateHousehold は世帯を作成し、作成したユーザーをメンバーにする
+func (hu *householdUsecase) CreateHousehold(household model.Household, userId uint) (model.HouseholdResponse, error) {
+	household.Name = strings.TrimSpace(household.Name)
+	if err := hu.hv.HouseholdValidate(household); err != nil {
+		return model.HouseholdResponse{}, validationError(err)
+	}
+	if err := hu.hr.CreateHousehold(&household, userId); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+	return hu.GetHouseholdById(userId, household.ID)
+}
+
+func (hu *householdUsecase) UpdateHousehold(household model.Household, userId uint, householdId uint) (model.HouseholdResponse, error) {
+	household.Name = strings.TrimSpace(household.Name)
+	if err := hu.hv.HouseholdValidate(household); err != nil {
+		return model.HouseholdResponse{}, validationError(err)
+	}
+	if err := hu.hr.UpdateHousehold(&household, userId, householdId); err != nil {
+		return model.HouseholdResponse{}, householdError(err)
+	}
+	return hu.GetHouseholdById(userId, householdId)
+}
+
+// GetMemberRole は resource の resourceId のデータが属する世帯での、ユーザーのロールを返す。
+// ロールによるアクセス制御のミドルウェアで使う。データがない、または所属していない場合は HouseholdNotFound を返す。
+func (hu *householdUsecase) GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error) {
+	role, err := hu.hr.GetMemberRole(userId, resource, resourceId)
+	if err != nil {
+		return "", householdError(err)
+	}
+	return role, nil
+}
+
+// UpdateMemberRole はメンバーのロールを編集者または閲覧者に変更する。
+// 所有者のロールは所有権の移転でのみ変更できる。メンバーが編集できる最後の世帯では閲覧者にできない。
+func (hu *householdUsecase) UpdateMemberRole(memberRole model.HouseholdMemberRole, userId uint, householdId uint, memberId uint) (model.HouseholdResponse, error) {
+	if err := hu.hv.MemberRoleValidate(memberRole); err != nil {
+		return model.HouseholdResponse{}, validationError(err)
+	}
+	if memberId == userId {
+		return model.HouseholdResponse{}, apperrors.OwnershipTransferRequired
+	}
+	if !memberRole.Role.Allows(model.RoleEditor) {
+		if err := hu.checkOtherWritableHousehold(memberId, householdId); err != nil {
+			return model.HouseholdResponse{}, err
+		}
+	}
+	if err := hu.hr.UpdateMemberRole(householdId, memberId, memberRole.Role); err != nil {
+		return model.HouseholdResponse{}, memberError(err)
+	}
+	return hu.GetHouseholdById(userId, householdId)
+}
+
+// RemoveMember は所有者以外のメンバーを世帯から外す。メンバーが編集できる最後の世帯からは外せない。
+func (hu *householdUsecase) RemoveMember(userId uint, householdId uint, memberId uint) error {
+	if memberId == userId {
+		return apperrors.OwnershipTransferRequired
+	}
+	if err := hu.checkOtherWritableHousehold(memberId, householdId); err != nil {
+		return err
+	}
+	if err := hu.hr.RemoveMember(householdId, memberId); err != nil {
+		return memberError(err)
+	}
+	return nil
+}
+
+// checkOtherWritableHousehold は householdId の世帯のメンバー memberId が、他に編集者以上として所属する世帯を持つことを確認する。
+// 食材などの登録先は編集できる世帯から決めるため、最後の 1 つを失うと登録できなくなる。
+// householdId のメンバーでない場合は確認せず、後続の処理で見つからないエラーにする。
+func (hu *householdUsecase) checkOtherWritableHousehold(memberId uint, householdId uint) error {
+	households := []model.Household{}
+	if err := hu.hr.GetHouseholds(&households, memberId); err != nil {
+		return err
+	}
+	member, writable := false, false
+	for _, household := range households {
+		for _, m := range household.Members {
+			if m.UserId != memberId {
+				continue
+			}
+			if household.ID == householdId {
+				member = true
+			} else if m.Role.Allows(model.RoleEditor) {
+				writable = true
+			}
+		}
+	}
+	if member && !writable {
+		return apperrors.MemberLastHousehold
+	}
+	return nil
+}
+
+// TransferOwnership は世帯の所有権を他のメンバーに移す。元の所有者は編集者になる。
+func (hu *householdUsecase) TransferOwnership(transfer model.HouseholdTransfer, userId uint, householdId uint) (model.HouseholdResponse, error) {
+	if transfer.UserId == 0 || transfer.UserId == userId {
+		return model.HouseholdResponse{}, apperrors.NewValidationError("user_id: must be another member.", map[string]string{"user_id": "must be another member"})
+	}
+	if err := hu.hr.TransferOwnership(householdId, userId, transfer.UserId); err != nil {
+		return model.HouseholdResponse{}, memberError(err)
+	}
+	return hu.GetHouseholdById(userId, householdId)
+}
+
+// LeaveHousehold は世帯から抜ける。所有者は先に所有権を移す必要があり、最後の世帯からは抜けられない。
+func (hu *householdUsecase) LeaveHousehold(userId uint, householdId uint) error {
+	role, err := hu.hr.GetMemberRole(userId, model.ResourceHousehold, householdId)
+	if err != nil {
+		return householdError(err)
+	}
+	if role == model.RoleOwner {
+		return apperrors.OwnershipTransferRequired
+	}
+	households := []model.Household{}
+	if err := hu.hr.GetHouseholds(&households, userId); err != nil {
+		return err
+	}
+	if len(households) <= 1 {
+		return apperrors.LastHousehold
+	}
+	if err := hu.hr.RemoveMember(householdId, userId); err != nil {
+		return householdError(err)
+	}
+	return nil
+}
+
+// CreateInvitation は世帯への招待を作成する。トークンはこのレスポンスでのみ返す。
+func (hu *householdUsecase) CreateInvitation(request model.HouseholdInvitationRequest, userId uint, householdId uint) (model.HouseholdInvitationResponse, error) {
+	if err := hu.hv.InvitationValidate(request); err != nil {
+		return model.HouseholdInvitationResponse{}, validationError(err)
+	}
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return model.HouseholdInvitationResponse{}, err
+	}
+	ttl := DefaultInvitationTTL
+	if request.ExpiresInHours > 0 {
+		ttl = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+	invitation := model.HouseholdInvitation{
+		HouseholdId: householdId,
+		TokenHash:   tokenHash,
+		Role:        request.Role,
+		Status:      model.InvitationPending,
+		ExpiresAt:   time.Now().Add(ttl),
+		InvitedBy:   userId,
+	}
+	if err := hu.hr.CreateInvitation(&invitation); err != nil {
+		return model.HouseholdInvitationResponse{}, err
+	}
+	res := toInvitationResponse(invitation)
+	res.Token = token
+	return res, nil
+}
+
+// GetInvitations は世帯の未回答で有効期限内の招待を返す
+func (hu *householdUsecase) GetInvitations(householdId uint) ([]model.HouseholdInvitationResponse, error) {
+	invitations := []model.HouseholdInvitation{}
+	if err := hu.hr.GetPendingInvitations(&invitations, householdId, time.Now()); err != nil {
+		return nil, err
+	}
+	resInvitations := []model.HouseholdInvitationResponse{}
+	for _, v := range invitations {
+		resInvitations = append(resInvitations, toInvitationResponse(v))
+	}
+	return resInvitations, nil
+}
+
+// AcceptInvitation は招待を承諾し、招待のロールで世帯のメンバーになる
+func (hu *householdUsecase) AcceptInvitation(token string, userId uint) (model.HouseholdResponse, error) {
+	invitation := model.HouseholdInvitation{}
+	if err := hu.hr.AcceptInvitation(&invitation, hashInvitationToken(token), userId, time.Now()); err != nil {
+		return model.HouseholdResponse{}, invitationError(err)
+	}
+	return hu.GetHouseholdById(userId, invitation.HouseholdId)
+}
+
+func (hu *householdUsecase) DeclineInvitation(token string, userId uint) error {
+	if err := hu.hr.DeclineInvitation(hashInvitationToken(token), userId, time.Now()); err != nil {
+		return invitationError(err)
+	}
+	return nil
+}
+
+// newInvitationToken は招待のトークンと、保存用のハッシュを生成する
+func newInvitationToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toInvitationResponse(invitation model.HouseholdInvitation) model.HouseholdInvitationResponse {
+	return model.HouseholdInvitationResponse{
+		ID:          invitation.ID,
+		HouseholdId: invitation.HouseholdId,
+		Role:        invitation.Role,
+		Status:      invitation.Status,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+func toHouseholdResponse(household model.Household) model.HouseholdResponse {
+	members := []model.HouseholdMemberResponse{}
+	for _, member := range household.Members {
+		members = append(members, model.HouseholdMemberResponse{
+			UserId:   member.UserId,
+			Email:    member.User.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+	return model.HouseholdResponse{
+		ID:        household.ID,
+		Name:      household.Name,
+		Members:   members,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+	}
+}
+
+// householdError はリポジトリの「レコードなし」を 404 のアプリケーションエラーに変換する。
+// データの作成時に所属していない世帯を指定した場合も同じエラーになり、
+// 閲覧者として所属する世帯を指定した場合は 403、他の世帯の保管場所を指定した場合は 400 になる。
+func householdError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.HouseholdNotFound
+	}
+	if errors.Is(err, model.ErrHouseholdReadOnly) {
+		return apperrors.HouseholdForbidden
+	}
+	if errors.Is(err, model.ErrLocationHouseholdMismatch) {
+		return apperrors.LocationHouseholdMismatch
+	}
+	return err
+}
+
+// memberError はリポジトリの「レコードなし」をメンバーが見つからないエラーに変換する
+func memberError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.HouseholdMemberNotFound
+	}
+	return err
+}
+
+// invitationError は使えない招待と、すでにメンバーである場合をアプリケーションエラーに変換する
+func invitationError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.InvitationNotFound
+	}
+	if errors.Is(err, model.ErrAlreadyHouseholdMember) {
+		return apperrors.AlreadyHouseholdMember
+	}
+	return err
+}
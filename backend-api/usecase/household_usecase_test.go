@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockHouseholdRepository struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdRepository) GetHouseholds(households *[]model.Household, userId uint) error {
+	args := m.Called(households, userId)
+	if v, ok := args.Get(0).([]model.Household); ok {
+		*households = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockHouseholdRepository) GetHouseholdById(household *model.Household, userId uint, householdId uint) error {
+	args := m.Called(household, userId, householdId)
+	if v, ok := args.Get(0).(model.Household); ok {
+		*household = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockHouseholdRepository) CreateHousehold(household *model.Household, userId uint) error {
+	args := m.Called(household, userId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) UpdateHousehold(household *model.Household, userId uint, householdId uint) error {
+	args := m.Called(household, userId, householdId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) GetUsersWithoutHousehold(users *[]model.User) error {
+	args := m.Called(users)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) GetMemberRole(userId uint, resource model.HouseholdResource, resourceId uint) (model.HouseholdRole, error) {
+	args := m.Called(userId, resource, resourceId)
+	return args.Get(0).(model.HouseholdRole), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) UpdateMemberRole(householdId uint, userId uint, role model.HouseholdRole) error {
+	args := m.Called(householdId, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) RemoveMember(householdId uint, userId uint) error {
+	args := m.Called(householdId, userId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) TransferOwnership(householdId uint, fromUserId uint, toUserId uint) error {
+	args := m.Called(householdId, fromUserId, toUserId)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) CreateInvitation(invitation *model.HouseholdInvitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) GetPendingInvitations(invitations *[]model.HouseholdInvitation, householdId uint, now time.Time) error {
+	args := m.Called(invitations, householdId, now)
+	if v, ok := args.Get(0).([]model.HouseholdInvitation); ok {
+		*invitations = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockHouseholdRepository) AcceptInvitation(invitation *model.HouseholdInvitation, tokenHash string, userId uint, now time.Time) error {
+	args := m.Called(invitation, tokenHash, userId, now)
+	if v, ok := args.Get(0).(model.HouseholdInvitation); ok {
+		*invitation = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockHouseholdRepository) DeclineInvitation(tokenHash string, userId uint, now time.Time) error {
+	args := m.Called(tokenHash, userId, now)
+	return args.Error(0)
+}
+
+func newHouseholdUsecase() (IHouseholdUsecase, *MockHouseholdRepository) {
+	mockRepo := new(MockHouseholdRepository)
+	return NewHouseholdUsecase(mockRepo, validator.NewHouseholdValidator()), mockRepo
+}
+
+func TestHouseholdUsecase_GetHouseholds(t *testing.T) {
+	usecase, mockRepo := newHouseholdUsecase()
+	joinedAt := time.Now()
+	mockRepo.On("GetHouseholds", mock.Anything, uint(1)).Return([]model.Household{
+		{ID: 1, Name: model.PersonalHouseholdName, Members: []model.HouseholdMember{
+			{HouseholdId: 1, UserId: 1, User: model.User{ID: 1, Email: "a@example.com"}, CreatedAt: joinedAt},
+		}},
+		{ID: 2, Name: "わが家", Members: []model.HouseholdMember{
+			{HouseholdId: 2, UserId: 2, User: model.User{ID: 2, Email: "b@example.com"}},
+			{HouseholdId: 2, UserId: 1, User: model.User{ID: 1, Email: "a@example.com"}},
+		}},
+	}, nil)
+
+	households, err := usecase.GetHouseholds(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, households, 2)
+	assert.Equal(t, []model.HouseholdMemberResponse{{UserId: 1, Email: "a@example.com", JoinedAt: joinedAt}}, households[0].Members)
+	assert.Len(t, households[1].Members, 2)
+	assert.Equal(t, "b@example.com", households[1].Members[0].Email)
+}
+
+func TestHouseholdUsecase_CreateHousehold(t *testing.T) {
+	t.Run("作成したユーザーをメンバーにする", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("CreateHousehold", mock.MatchedBy(func(household *model.Household) bool {
+			return household.Name == "わが家"
+		}), uint(1)).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Household).ID = 5
+		}).Return(nil)
+		mockRepo.On("GetHouseholdById", mock.Anything, uint(1), uint(5)).Return(model.Household{
+			ID: 5, Name: "わが家", Members: []model.HouseholdMember{{HouseholdId: 5, UserId: 1}},
+		}, nil)
+
+		res, err := usecase.CreateHousehold(model.Household{Name: " わが家 "}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.ID)
+		assert.Len(t, res.Members, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("名前なしは項目ごとのエラーを返す", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+
+		_, err := usecase.CreateHousehold(model.Household{Name: "  "}, 1)
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		assert.Contains(t, apperrors.GetFields(err), "name")
+		mockRepo.AssertNotCalled(t, "CreateHousehold", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHouseholdUsecase_NotMember(t *testing.T) {
+	usecase, mockRepo := newHouseholdUsecase()
+	mockRepo.On("GetHouseholdById", mock.Anything, uint(1), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("UpdateHousehold", mock.Anything, uint(1), uint(9)).Return(gorm.ErrRecordNotFound)
+
+	_, err := usecase.GetHouseholdById(1, 9)
+	assert.ErrorIs(t, err, apperrors.HouseholdNotFound)
+
+	_, err = usecase.UpdateHousehold(model.Household{Name: "実家"}, 1, 9)
+	assert.ErrorIs(t, err, apperrors.HouseholdNotFound)
+}
+
+func TestHouseholdUsecase_CreateInvitation(t *testing.T) {
+	t.Run("トークンのハッシュを保存し、トークンを返す", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		var saved *model.HouseholdInvitation
+		mockRepo.On("CreateInvitation", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*model.HouseholdInvitation)
+		}).Return(nil)
+
+		res, err := usecase.CreateInvitation(model.HouseholdInvitationRequest{Role: model.RoleViewer}, 1, 2)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Token)
+		assert.Equal(t, hashInvitationToken(res.Token), saved.TokenHash)
+		assert.NotEqual(t, res.Token, saved.TokenHash)
+		assert.Equal(t, model.InvitationPending, saved.Status)
+		assert.Equal(t, uint(2), saved.HouseholdId)
+		assert.WithinDuration(t, time.Now().Add(DefaultInvitationTTL), saved.ExpiresAt, time.Minute)
+	})
+
+	t.Run("所有者としては招待できない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+
+		_, err := usecase.CreateInvitation(model.HouseholdInvitationRequest{Role: model.RoleOwner, ExpiresInHours: validator.InvitationMaxHours + 1}, 1, 2)
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		assert.Contains(t, apperrors.GetFields(err), "role")
+		assert.Contains(t, apperrors.GetFields(err), "expires_in_hours")
+		mockRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+	})
+}
+
+func TestHouseholdUsecase_AcceptInvitation(t *testing.T) {
+	t.Run("招待の世帯を返す", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("AcceptInvitation", mock.Anything, hashInvitationToken("token"), uint(3), mock.Anything).
+			Return(model.HouseholdInvitation{HouseholdId: 2}, nil)
+		mockRepo.On("GetHouseholdById", mock.Anything, uint(3), uint(2)).Return(model.Household{ID: 2, Name: "わが家"}, nil)
+
+		res, err := usecase.AcceptInvitation("token", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), res.ID)
+	})
+
+	t.Run("使用済み・期限切れの招待", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("AcceptInvitation", mock.Anything, mock.Anything, uint(3), mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := usecase.AcceptInvitation("token", 3)
+
+		assert.ErrorIs(t, err, apperrors.InvitationNotFound)
+	})
+
+	t.Run("すでにメンバー", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("AcceptInvitation", mock.Anything, mock.Anything, uint(3), mock.Anything).Return(nil, model.ErrAlreadyHouseholdMember)
+
+		_, err := usecase.AcceptInvitation("token", 3)
+
+		assert.ErrorIs(t, err, apperrors.AlreadyHouseholdMember)
+	})
+}
+
+func TestHouseholdUsecase_Members(t *testing.T) {
+	t.Run("自分のロールは変更できない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+
+		_, err := usecase.UpdateMemberRole(model.HouseholdMemberRole{Role: model.RoleViewer}, 1, 2, 1)
+
+		assert.ErrorIs(t, err, apperrors.OwnershipTransferRequired)
+		mockRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("所有者には変更できない", func(t *testing.T) {
+		usecase, _ := newHouseholdUsecase()
+
+		_, err := usecase.UpdateMemberRole(model.HouseholdMemberRole{Role: model.RoleOwner}, 1, 2, 3)
+
+		assert.Contains(t, apperrors.GetFields(err), "role")
+	})
+
+	t.Run("メンバーでないユーザーは外せない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetHouseholds", mock.Anything, uint(3)).Return([]model.Household{}, nil)
+		mockRepo.On("RemoveMember", uint(2), uint(3)).Return(gorm.ErrRecordNotFound)
+
+		err := usecase.RemoveMember(1, 2, 3)
+
+		assert.ErrorIs(t, err, apperrors.HouseholdMemberNotFound)
+	})
+
+	// ユーザー 3 は個人の世帯 5 では閲覧者、世帯 2 では編集者
+	memberHouseholds := []model.Household{
+		{ID: 5, Members: []model.HouseholdMember{{HouseholdId: 5, UserId: 3, Role: model.RoleViewer}}},
+		{ID: 2, Members: []model.HouseholdMember{{HouseholdId: 2, UserId: 1, Role: model.RoleOwner}, {HouseholdId: 2, UserId: 3, Role: model.RoleEditor}}},
+	}
+
+	t.Run("メンバーが編集できる最後の世帯からは外せない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetHouseholds", mock.Anything, uint(3)).Return(memberHouseholds, nil)
+
+		err := usecase.RemoveMember(1, 2, 3)
+
+		assert.ErrorIs(t, err, apperrors.MemberLastHousehold)
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("メンバーが編集できる最後の世帯では閲覧者にできない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetHouseholds", mock.Anything, uint(3)).Return(memberHouseholds, nil)
+
+		_, err := usecase.UpdateMemberRole(model.HouseholdMemberRole{Role: model.RoleViewer}, 1, 2, 3)
+
+		assert.ErrorIs(t, err, apperrors.MemberLastHousehold)
+		mockRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("他に編集できる世帯があれば外せる", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		households := append([]model.Household{{ID: 7, Members: []model.HouseholdMember{{HouseholdId: 7, UserId: 3, Role: model.RoleOwner}}}}, memberHouseholds...)
+		mockRepo.On("GetHouseholds", mock.Anything, uint(3)).Return(households, nil)
+		mockRepo.On("RemoveMember", uint(2), uint(3)).Return(nil)
+
+		assert.NoError(t, usecase.RemoveMember(1, 2, 3))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("所有権は他のメンバーに移す", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("TransferOwnership", uint(2), uint(1), uint(3)).Return(nil)
+		mockRepo.On("GetHouseholdById", mock.Anything, uint(1), uint(2)).Return(model.Household{ID: 2}, nil)
+
+		_, err := usecase.TransferOwnership(model.HouseholdTransfer{UserId: 1}, 1, 2)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+
+		_, err = usecase.TransferOwnership(model.HouseholdTransfer{UserId: 3}, 1, 2)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestHouseholdUsecase_LeaveHousehold(t *testing.T) {
+	t.Run("所有者は所有権を移してから抜ける", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetMemberRole", uint(1), model.ResourceHousehold, uint(2)).Return(model.RoleOwner, nil)
+
+		err := usecase.LeaveHousehold(1, 2)
+
+		assert.ErrorIs(t, err, apperrors.OwnershipTransferRequired)
+		mockRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("最後の世帯からは抜けられない", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetMemberRole", uint(1), model.ResourceHousehold, uint(2)).Return(model.RoleEditor, nil)
+		mockRepo.On("GetHouseholds", mock.Anything, uint(1)).Return([]model.Household{{ID: 2}}, nil)
+
+		err := usecase.LeaveHousehold(1, 2)
+
+		assert.ErrorIs(t, err, apperrors.LastHousehold)
+	})
+
+	t.Run("他の世帯があれば抜ける", func(t *testing.T) {
+		usecase, mockRepo := newHouseholdUsecase()
+		mockRepo.On("GetMemberRole", uint(1), model.ResourceHousehold, uint(2)).Return(model.RoleViewer, nil)
+		mockRepo.On("GetHouseholds", mock.Anything, uint(1)).Return([]model.Household{{ID: 1}, {ID: 2}}, nil)
+		mockRepo.On("RemoveMember", uint(2), uint(1)).Return(nil)
+
+		assert.NoError(t, usecase.LeaveHousehold(1, 2))
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,64 @@
+package usecase
+
+//go:generate mockgen -source=notification_usecase.go -destination=../mock/notification_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type INotificationUsecase interface {
+	GenerateExpiryAlerts(now time.Time) (int64, error)
+	GetNotifications(userId uint, unreadOnly bool) ([]model.Notification, error)
+	MarkAsRead(userId uint, notificationId uint) error
+	MarkAllAsRead(userId uint) error
+}
+
+type notificationUsecase struct {
+	nr repository.INotificationRepository
+}
+
+func NewNotificationUsecase(nr repository.INotificationRepository) INotificationUsecase {
+	return &notificationUsecase{nr}
+}
+
+// GenerateExpiryAlerts は賞味期限が近い・切れた食材の通知を世帯のメンバーごとに作成し、新しく作成した件数を返す。
+// 期限が近いかどうかはメンバーそれぞれの通知日数で判定し、通知済みのメンバーには同じ通知を作成しない。
+func (nu *notificationUsecase) GenerateExpiryAlerts(now time.Time) (int64, error) {
+	targets := []model.ExpiryAlertTarget{}
+	if err := nu.nr.GetExpiryAlertTargets(&targets, now); err != nil {
+		return 0, err
+	}
+	notifications := make([]model.Notification, 0, len(targets))
+	for _, v := range targets {
+		notifications = append(notifications, model.NewExpiryNotification(v.FoodItem, v.UserId, now))
+	}
+	return nu.nr.CreateNotifications(notifications)
+}
+
+func (nu *notificationUsecase) GetNotifications(userId uint, unreadOnly bool) ([]model.Notification, error) {
+	notifications := []model.Notification{}
+	if err := nu.nr.GetNotifications(&notifications, userId, unreadOnly); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (nu *notificationUsecase) MarkAsRead(userId uint, notificationId uint) error {
+	if err := nu.nr.MarkAsRead(userId, notificationId, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotificationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (nu *notificationUsecase) MarkAllAsRead(userId uint) error {
+	return nu.nr.MarkAllAsRead(userId, time.Now())
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) GetExpiryAlertTargets(targets *[]model.ExpiryAlertTarget, now time.Time) error {
+	args := m.Called(targets, now)
+	if items, ok := args.Get(0).([]model.ExpiryAlertTarget); ok {
+		*targets = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockNotificationRepository) CreateNotifications(notifications []model.Notification) (int64, error) {
+	args := m.Called(notifications)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetNotifications(notifications *[]model.Notification, userId uint, unreadOnly bool) error {
+	args := m.Called(notifications, userId, unreadOnly)
+	if items, ok := args.Get(0).([]model.Notification); ok {
+		*notifications = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsRead(userId uint, notificationId uint, readAt time.Time) error {
+	args := m.Called(userId, notificationId, readAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(userId uint, readAt time.Time) error {
+	args := m.Called(userId, readAt)
+	return args.Error(0)
+}
+
+func TestNotificationUsecase_GenerateExpiryAlerts(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	usecase := NewNotificationUsecase(mockRepo)
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local)
+	milk := model.FoodItem{ID: 1, Title: "牛乳", ExpiryDate: now.AddDate(0, 0, 2), UserId: 1}
+	// 世帯のメンバーそれぞれに通知する（登録したユーザー以外にも）
+	mockRepo.On("GetExpiryAlertTargets", mock.Anything, now).Return([]model.ExpiryAlertTarget{
+		{FoodItem: milk, UserId: 1},
+		{FoodItem: milk, UserId: 3},
+		{FoodItem: model.FoodItem{ID: 2, Title: "豆腐", ExpiryDate: now.AddDate(0, 0, -1), UserId: 2}, UserId: 2},
+	}, nil)
+	mockRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []model.Notification) bool {
+		return len(notifications) == 3 &&
+			notifications[0].Type == model.NotificationExpiring && *notifications[0].FoodItemId == 1 && notifications[0].UserId == 1 &&
+			notifications[1].Type == model.NotificationExpiring && *notifications[1].FoodItemId == 1 && notifications[1].UserId == 3 &&
+			notifications[2].Type == model.NotificationExpired && *notifications[2].FoodItemId == 2 && notifications[2].UserId == 2
+	})).Return(int64(2), nil) // 通知済みの1件は作成されない
+
+	created, err := usecase.GenerateExpiryAlerts(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), created)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNotificationUsecase_MarkAsRead_OtherUser(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	usecase := NewNotificationUsecase(mockRepo)
+	mockRepo.On("MarkAsRead", uint(1), uint(99), mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	err := usecase.MarkAsRead(1, 99)
+
+	assert.ErrorIs(t, err, apperrors.NotificationNotFound)
+}
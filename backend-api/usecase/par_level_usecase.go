@@ -0,0 +1,210 @@
+package usecase
+
+//go:generate mockgen -source=par_level_usecase.go -destination=../mock/par_level_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// RestockNote は最低在庫を下回って自動で追加した買い物リストの項目のメモ
+const RestockNote = "最低在庫を下回りました"
+
+type IParLevelUsecase interface {
+	GetAllParLevels(userId uint) ([]model.ParLevelResponse, error)
+	SaveParLevel(parLevel model.ParLevel) (model.ParLevelResponse, error)
+	UpdateParLevel(parLevel model.ParLevel, userId uint, parLevelId uint) (model.ParLevelResponse, error)
+	DeleteParLevel(userId uint, parLevelId uint) error
+	GetRestockSuggestions(userId uint) ([]model.RestockSuggestion, error)
+	SyncRestock(userId uint) error
+}
+
+type parLevelUsecase struct {
+	pr repository.IParLevelRepository
+	fr repository.IFoodItemRepository
+	sr repository.IShoppingListRepository
+	pv validator.IParLevelValidator
+}
+
+func NewParLevelUsecase(pr repository.IParLevelRepository, fr repository.IFoodItemRepository, sr repository.IShoppingListRepository, pv validator.IParLevelValidator) IParLevelUsecase {
+	return &parLevelUsecase{pr, fr, sr, pv}
+}
+
+func (pu *parLevelUsecase) GetAllParLevels(userId uint) ([]model.ParLevelResponse, error) {
+	parLevels := []model.ParLevel{}
+	if err := pu.pr.GetAllParLevels(&parLevels, userId); err != nil {
+		return nil, err
+	}
+	resParLevels := []model.ParLevelResponse{}
+	for _, v := range parLevels {
+		resParLevels = append(resParLevels, toParLevelResponse(v))
+	}
+	return resParLevels, nil
+}
+
+// SaveParLevel は最低在庫を登録する。同じ名前の最低在庫がある場合は置き換える。
+func (pu *parLevelUsecase) SaveParLevel(parLevel model.ParLevel) (model.ParLevelResponse, error) {
+	if err := pu.normalizeParLevel(&parLevel); err != nil {
+		return model.ParLevelResponse{}, err
+	}
+	if err := pu.pr.SaveParLevel(&parLevel); err != nil {
+		return model.ParLevelResponse{}, err
+	}
+	return toParLevelResponse(parLevel), nil
+}
+
+func (pu *parLevelUsecase) UpdateParLevel(parLevel model.ParLevel, userId uint, parLevelId uint) (model.ParLevelResponse, error) {
+	if err := pu.normalizeParLevel(&parLevel); err != nil {
+		return model.ParLevelResponse{}, err
+	}
+	if err := pu.pr.UpdateParLevel(&parLevel, userId, parLevelId); err != nil {
+		return model.ParLevelResponse{}, parLevelError(err)
+	}
+	return toParLevelResponse(parLevel), nil
+}
+
+func (pu *parLevelUsecase) DeleteParLevel(userId uint, parLevelId uint) error {
+	if err := pu.pr.DeleteParLevel(userId, parLevelId); err != nil {
+		return parLevelError(err)
+	}
+	return nil
+}
+
+// GetRestockSuggestions は最低在庫を下回っている常備品を名前順に返す
+func (pu *parLevelUsecase) GetRestockSuggestions(userId uint) ([]model.RestockSuggestion, error) {
+	suggestions, err := pu.restockSuggestions(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(suggestions) == 0 {
+		return suggestions, nil
+	}
+	items := []model.ShoppingListItem{}
+	if err := pu.sr.GetShoppingListItems(&items, userId); err != nil {
+		return nil, err
+	}
+	listed := shoppingListItemsByName(items)
+	for i := range suggestions {
+		if item, ok := listed[suggestions[i].Name]; ok {
+			id := item.ID
+			suggestions[i].ShoppingListItemId = &id
+		}
+	}
+	return suggestions, nil
+}
+
+// SyncRestock は最低在庫を下回っている常備品を買い物リストに反映する。
+// リストにない場合は不足分の項目を追加し、チェックされていない項目がある場合は数量を不足分に合わせる。
+// 消費・廃棄・削除で在庫が減った後に呼び出す。
+func (pu *parLevelUsecase) SyncRestock(userId uint) error {
+	suggestions, err := pu.restockSuggestions(userId)
+	if err != nil || len(suggestions) == 0 {
+		return err
+	}
+	items := []model.ShoppingListItem{}
+	if err := pu.sr.GetShoppingListItems(&items, userId); err != nil {
+		return err
+	}
+	listed := shoppingListItemsByName(items)
+	for _, suggestion := range suggestions {
+		item, ok := listed[suggestion.Name]
+		if !ok {
+			item = model.ShoppingListItem{
+				Name:     suggestion.Name,
+				Quantity: suggestion.Shortfall,
+				Unit:     suggestion.Unit,
+				Note:     RestockNote,
+				UserId:   userId,
+			}
+			if err := pu.sr.CreateShoppingListItem(&item); err != nil {
+				return err
+			}
+			continue
+		}
+		// 買い物かごに入れた（チェックした）項目は変更しない
+		if item.Checked || (item.Quantity == suggestion.Shortfall && item.Unit == suggestion.Unit) {
+			continue
+		}
+		item.Quantity = suggestion.Shortfall
+		item.Unit = suggestion.Unit
+		if err := pu.sr.UpdateShoppingListItem(&item, userId, item.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pu *parLevelUsecase) restockSuggestions(userId uint) ([]model.RestockSuggestion, error) {
+	parLevels := []model.ParLevel{}
+	if err := pu.pr.GetAllParLevels(&parLevels, userId); err != nil {
+		return nil, err
+	}
+	suggestions := []model.RestockSuggestion{}
+	if len(parLevels) == 0 {
+		return suggestions, nil
+	}
+	names := make([]string, 0, len(parLevels))
+	for _, parLevel := range parLevels {
+		names = append(names, parLevel.Name)
+	}
+	foodItems := []model.FoodItem{}
+	if err := pu.fr.GetFoodItemsByTitles(&foodItems, userId, names); err != nil {
+		return nil, err
+	}
+	for _, parLevel := range parLevels {
+		if suggestion, ok := parLevel.Suggest(foodItems); ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions, nil
+}
+
+// shoppingListItemsByName は買い物リストの項目を名前で引けるようにする。同じ名前が複数ある場合は先頭の項目を使う。
+func shoppingListItemsByName(items []model.ShoppingListItem) map[string]model.ShoppingListItem {
+	byName := map[string]model.ShoppingListItem{}
+	for _, item := range items {
+		if _, ok := byName[item.Name]; !ok {
+			byName[item.Name] = item
+		}
+	}
+	return byName
+}
+
+func (pu *parLevelUsecase) normalizeParLevel(parLevel *model.ParLevel) error {
+	parLevel.Name = strings.TrimSpace(parLevel.Name)
+	unit, err := model.ParseUnit(string(parLevel.Unit))
+	if err != nil {
+		return apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	parLevel.Unit = unit
+	if err := pu.pv.ParLevelValidate(*parLevel); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+func toParLevelResponse(parLevel model.ParLevel) model.ParLevelResponse {
+	return model.ParLevelResponse{
+		ID:          parLevel.ID,
+		Name:        parLevel.Name,
+		MinQuantity: parLevel.MinQuantity,
+		Unit:        parLevel.Unit,
+		CreatedAt:   parLevel.CreatedAt,
+		UpdatedAt:   parLevel.UpdatedAt,
+	}
+}
+
+// parLevelError はリポジトリの「レコードなし」を 404 のアプリケーションエラーに変換する
+func parLevelError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ParLevelNotFound
+	}
+	return err
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockParLevelRepository struct {
+	mock.Mock
+}
+
+func (m *MockParLevelRepository) GetAllParLevels(parLevels *[]model.ParLevel, userId uint) error {
+	args := m.Called(parLevels, userId)
+	if v, ok := args.Get(0).([]model.ParLevel); ok {
+		*parLevels = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockParLevelRepository) SaveParLevel(parLevel *model.ParLevel) error {
+	args := m.Called(parLevel)
+	return args.Error(0)
+}
+
+func (m *MockParLevelRepository) UpdateParLevel(parLevel *model.ParLevel, userId uint, parLevelId uint) error {
+	args := m.Called(parLevel, userId, parLevelId)
+	return args.Error(0)
+}
+
+func (m *MockParLevelRepository) DeleteParLevel(userId uint, parLevelId uint) error {
+	args := m.Called(userId, parLevelId)
+	return args.Error(0)
+}
+
+func newParLevelUsecase() (IParLevelUsecase, *MockParLevelRepository, *MockFoodItemRepository, *MockShoppingListRepository) {
+	mockRepo := new(MockParLevelRepository)
+	mockFoodItemRepo := new(MockFoodItemRepository)
+	mockShoppingListRepo := new(MockShoppingListRepository)
+	usecase := NewParLevelUsecase(mockRepo, mockFoodItemRepo, mockShoppingListRepo, validator.NewParLevelValidator())
+	return usecase, mockRepo, mockFoodItemRepo, mockShoppingListRepo
+}
+
+// stubRestockStock は卵の最低在庫を 10 個、牛乳を 2 L とし、卵が 4 個、牛乳が 2000 ml ある状態にする
+func stubRestockStock(mockRepo *MockParLevelRepository, mockFoodItemRepo *MockFoodItemRepository) {
+	mockRepo.On("GetAllParLevels", mock.Anything, uint(1)).Return([]model.ParLevel{
+		{ID: 1, Name: "卵", MinQuantity: 10, Unit: model.UnitPiece},
+		{ID: 2, Name: "牛乳", MinQuantity: 2, Unit: model.UnitLiter},
+	}, nil)
+	mockFoodItemRepo.On("GetFoodItemsByTitles", mock.Anything, uint(1), []string{"卵", "牛乳"}).Return([]model.FoodItem{
+		{Title: "卵", Quantity: 4, Unit: model.UnitPiece},
+		{Title: "牛乳", Quantity: 2000, Unit: model.UnitMilliliter},
+	}, nil)
+}
+
+func TestParLevelUsecase_SaveParLevel(t *testing.T) {
+	t.Run("名前と単位を正規化して保存する", func(t *testing.T) {
+		usecase, mockRepo, _, _ := newParLevelUsecase()
+		mockRepo.On("SaveParLevel", mock.MatchedBy(func(parLevel *model.ParLevel) bool {
+			return parLevel.Name == "米" && parLevel.Unit == model.UnitKilogram && parLevel.MinQuantity == 2
+		})).Return(nil)
+
+		res, err := usecase.SaveParLevel(model.ParLevel{Name: " 米 ", MinQuantity: 2, Unit: "kg", UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "米", res.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("最低在庫は0より大きい数量が必要", func(t *testing.T) {
+		usecase, mockRepo, _, _ := newParLevelUsecase()
+
+		_, err := usecase.SaveParLevel(model.ParLevel{MinQuantity: -1, UserId: 1})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		assert.Contains(t, apperrors.GetFields(err), "name")
+		assert.Contains(t, apperrors.GetFields(err), "min_quantity")
+		mockRepo.AssertNotCalled(t, "SaveParLevel", mock.Anything)
+	})
+}
+
+func TestParLevelUsecase_DeleteParLevel_NotFound(t *testing.T) {
+	usecase, mockRepo, _, _ := newParLevelUsecase()
+	mockRepo.On("DeleteParLevel", uint(1), uint(9)).Return(gorm.ErrRecordNotFound)
+
+	err := usecase.DeleteParLevel(1, 9)
+
+	assert.ErrorIs(t, err, apperrors.ParLevelNotFound)
+}
+
+func TestParLevelUsecase_GetRestockSuggestions(t *testing.T) {
+	usecase, mockRepo, mockFoodItemRepo, mockShoppingListRepo := newParLevelUsecase()
+	stubRestockStock(mockRepo, mockFoodItemRepo)
+	mockShoppingListRepo.On("GetShoppingListItems", mock.Anything, uint(1)).
+		Return([]model.ShoppingListItem{{ID: 5, Name: "卵", Quantity: 6}}, nil)
+
+	suggestions, err := usecase.GetRestockSuggestions(1)
+
+	assert.NoError(t, err)
+	// 牛乳は単位を換算すると最低在庫ちょうどのため提案しない
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "卵", suggestions[0].Name)
+	assert.Equal(t, 4.0, suggestions[0].CurrentQuantity)
+	assert.Equal(t, 6.0, suggestions[0].Shortfall)
+	assert.Equal(t, uint(5), *suggestions[0].ShoppingListItemId)
+}
+
+func TestParLevelUsecase_SyncRestock(t *testing.T) {
+	t.Run("リストにない場合は不足分を追加する", func(t *testing.T) {
+		usecase, mockRepo, mockFoodItemRepo, mockShoppingListRepo := newParLevelUsecase()
+		stubRestockStock(mockRepo, mockFoodItemRepo)
+		mockShoppingListRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return([]model.ShoppingListItem{}, nil)
+		mockShoppingListRepo.On("CreateShoppingListItem", mock.MatchedBy(func(item *model.ShoppingListItem) bool {
+			return item.Name == "卵" && item.Quantity == 6 && item.Unit == model.UnitPiece && item.Note == RestockNote && item.UserId == 1
+		})).Return(nil)
+
+		assert.NoError(t, usecase.SyncRestock(1))
+		mockShoppingListRepo.AssertExpectations(t)
+	})
+
+	t.Run("チェックされていない項目は数量を不足分に合わせる", func(t *testing.T) {
+		usecase, mockRepo, mockFoodItemRepo, mockShoppingListRepo := newParLevelUsecase()
+		stubRestockStock(mockRepo, mockFoodItemRepo)
+		mockShoppingListRepo.On("GetShoppingListItems", mock.Anything, uint(1)).
+			Return([]model.ShoppingListItem{{ID: 5, Name: "卵", Quantity: 2, Unit: model.UnitPiece}}, nil)
+		mockShoppingListRepo.On("UpdateShoppingListItem", mock.MatchedBy(func(item *model.ShoppingListItem) bool {
+			return item.Quantity == 6
+		}), uint(1), uint(5)).Return(nil)
+
+		assert.NoError(t, usecase.SyncRestock(1))
+		mockShoppingListRepo.AssertNotCalled(t, "CreateShoppingListItem", mock.Anything)
+		mockShoppingListRepo.AssertExpectations(t)
+	})
+
+	t.Run("チェック済みの項目は変更しない", func(t *testing.T) {
+		usecase, mockRepo, mockFoodItemRepo, mockShoppingListRepo := newParLevelUsecase()
+		stubRestockStock(mockRepo, mockFoodItemRepo)
+		mockShoppingListRepo.On("GetShoppingListItems", mock.Anything, uint(1)).
+			Return([]model.ShoppingListItem{{ID: 5, Name: "卵", Quantity: 2, Unit: model.UnitPiece, Checked: true}}, nil)
+
+		assert.NoError(t, usecase.SyncRestock(1))
+		mockShoppingListRepo.AssertNotCalled(t, "CreateShoppingListItem", mock.Anything)
+		mockShoppingListRepo.AssertNotCalled(t, "UpdateShoppingListItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("最低在庫がない場合はリストを読み込まない", func(t *testing.T) {
+		usecase, mockRepo, _, mockShoppingListRepo := newParLevelUsecase()
+		mockRepo.On("GetAllParLevels", mock.Anything, uint(1)).Return([]model.ParLevel{}, nil)
+
+		assert.NoError(t, usecase.SyncRestock(1))
+		mockShoppingListRepo.AssertNotCalled(t, "GetShoppingListItems", mock.Anything, mock.Anything)
+	})
+}
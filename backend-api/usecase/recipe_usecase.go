@@ -1,5 +1,7 @@
 package usecase
 
+//go:generate mockgen -source=recipe_usecase.go -destination=../mock/recipe_usecase_mock.go -package=mock
+
 import (
 	"fmt"
 	"go-rest-api/model"
@@ -21,9 +23,9 @@ func NewRecipeUsecase(fr repository.IFoodItemRepository, gs services.IGeminiServ
 }
 
 func (ru *recipeUsecase) GetRecipeSuggestions(userId uint) (string, error) {
-	// ユーザーの食材一覧を取得
+	// ユーザーの食材一覧を取得（使い切って数量 0 で残っている食材は使える食材に含めない）
 	var foodItems []model.FoodItem
-	if err := ru.fr.GetAllFoodItems(&foodItems); err != nil {
+	if err := ru.fr.GetAllFoodItems(&foodItems, userId, model.FoodItemFilter{InStock: true}); err != nil {
 		return "", fmt.Errorf("食材の取得に失敗しました: %v", err)
 	}
 
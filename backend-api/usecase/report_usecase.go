@@ -0,0 +1,223 @@
+package usecase
+
+//go:generate mockgen -source=report_usecase.go -destination=../mock/report_usecase_mock.go -package=mock
+
+import (
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"sort"
+	"time"
+)
+
+// uncategorizedLabel はカテゴリ未設定の食材の集計キー
+const uncategorizedLabel = "未分類"
+
+type IReportUsecase interface {
+	GetWasteReport(userId uint, from time.Time, to time.Time) (model.WasteReport, error)
+	GetSpendingReport(userId uint, from time.Time, to time.Time) (model.SpendingReport, error)
+}
+
+type reportUsecase struct {
+	wr repository.IWasteRepository
+	pr repository.IPurchaseRepository
+}
+
+func NewReportUsecase(wr repository.IWasteRepository, pr repository.IPurchaseRepository) IReportUsecase {
+	return &reportUsecase{wr, pr}
+}
+
+// GetWasteReport は from 以上 to 未満の廃棄を月・カテゴリ・理由ごとに集計する。
+// from が未指定の場合は11か月前の月初、to が未指定の場合は現在時刻とする。
+func (ru *reportUsecase) GetWasteReport(userId uint, from time.Time, to time.Time) (model.WasteReport, error) {
+	from, to = reportPeriod(from, to)
+	records := []model.WasteRecord{}
+	if err := ru.wr.GetWasteRecords(&records, userId, from, to); err != nil {
+		return model.WasteReport{}, err
+	}
+
+	report := model.WasteReport{From: from, To: to}
+	byMonth := newWasteAggregator()
+	byCategory := newWasteAggregator()
+	byReason := newWasteAggregator()
+	for _, record := range records {
+		report.Count++
+		if record.EstimatedCost != nil {
+			report.EstimatedCost += *record.EstimatedCost
+		}
+		byMonth.add(record.DiscardedAt.In(time.Local).Format("2006-01"), record)
+		byCategory.add(categoryKey(record.Category), record)
+		byReason.add(string(record.Reason), record)
+	}
+
+	// 月は時系列、カテゴリと理由は推定金額・件数の多い順に並べる
+	report.ByMonth = byMonth.summaries()
+	sort.SliceStable(report.ByMonth, func(i, j int) bool {
+		return report.ByMonth[i].Key < report.ByMonth[j].Key
+	})
+	report.ByCategory = byCategory.summaries()
+	sortWasteSummaries(report.ByCategory)
+	report.ByReason = byReason.summaries()
+	sortWasteSummaries(report.ByReason)
+	return report, nil
+}
+
+// GetSpendingReport は from 以上 to 未満の購入金額を週・月・カテゴリごとに集計する。
+// 同じ期間に廃棄した食材の金額（廃棄の記録の推定金額）を廃棄の損失として並べて集計する。
+// 金額は通貨ごとに分けて合計し、期間の既定値は GetWasteReport と同じ。
+func (ru *reportUsecase) GetSpendingReport(userId uint, from time.Time, to time.Time) (model.SpendingReport, error) {
+	from, to = reportPeriod(from, to)
+	purchases := []model.Purchase{}
+	if err := ru.pr.GetPurchases(&purchases, userId, from, to); err != nil {
+		return model.SpendingReport{}, err
+	}
+	records := []model.WasteRecord{}
+	if err := ru.wr.GetWasteRecords(&records, userId, from, to); err != nil {
+		return model.SpendingReport{}, err
+	}
+
+	report := model.SpendingReport{From: from, To: to, Amounts: model.MoneyTotals{}, WasteCost: model.MoneyTotals{}}
+	byWeek := newSpendingAggregator()
+	byMonth := newSpendingAggregator()
+	byCategory := newSpendingAggregator()
+	for _, purchase := range purchases {
+		price := model.Money{Amount: purchase.Price, Currency: purchase.Currency}
+		report.Count++
+		report.Amounts.Add(price)
+		for _, s := range []*model.SpendingSummary{
+			byWeek.get(weekKey(purchase.PurchasedAt)),
+			byMonth.get(purchase.PurchasedAt.In(time.Local).Format("2006-01")),
+			byCategory.get(categoryKey(purchase.Category)),
+		} {
+			s.Count++
+			s.Amounts.Add(price)
+		}
+	}
+	for _, record := range records {
+		if record.EstimatedCost == nil {
+			continue
+		}
+		cost := model.Money{Amount: *record.EstimatedCost, Currency: record.Currency}
+		report.WasteCost.Add(cost)
+		byWeek.get(weekKey(record.DiscardedAt)).WasteCost.Add(cost)
+		byMonth.get(record.DiscardedAt.In(time.Local).Format("2006-01")).WasteCost.Add(cost)
+		byCategory.get(categoryKey(record.Category)).WasteCost.Add(cost)
+	}
+
+	// 週と月は時系列、カテゴリは既定の通貨の金額・件数の多い順に並べる
+	report.ByWeek = byWeek.sortedByKey()
+	report.ByMonth = byMonth.sortedByKey()
+	report.ByCategory = byCategory.summaries()
+	sort.SliceStable(report.ByCategory, func(i, j int) bool {
+		a, b := report.ByCategory[i], report.ByCategory[j]
+		if a.Amounts[model.DefaultCurrency] != b.Amounts[model.DefaultCurrency] {
+			return a.Amounts[model.DefaultCurrency] > b.Amounts[model.DefaultCurrency]
+		}
+		return a.Count > b.Count
+	})
+	return report, nil
+}
+
+// weekKey は t を含む週の月曜日（"2006-01-02"）を返す
+func weekKey(t time.Time) string {
+	t = t.In(time.Local)
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+// categoryKey はカテゴリの集計キーを返す
+func categoryKey(category *model.Category) string {
+	if category == nil {
+		return uncategorizedLabel
+	}
+	return category.Path()
+}
+
+// reportPeriod はレポートの期間の既定値を補う
+func reportPeriod(from time.Time, to time.Time) (time.Time, time.Time) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		y, m, _ := to.In(time.Local).Date()
+		from = time.Date(y, m-11, 1, 0, 0, 0, 0, time.Local)
+	}
+	return from, to
+}
+
+// wasteAggregator はキーごとに廃棄の記録を集計する。キーは最初に現れた順に保持する。
+type wasteAggregator struct {
+	keys    []string
+	summary map[string]*model.WasteSummary
+}
+
+func newWasteAggregator() *wasteAggregator {
+	return &wasteAggregator{summary: map[string]*model.WasteSummary{}}
+}
+
+func (a *wasteAggregator) add(key string, record model.WasteRecord) {
+	s, ok := a.summary[key]
+	if !ok {
+		s = &model.WasteSummary{Key: key, Quantities: model.QuantityTotals{}}
+		a.summary[key] = s
+		a.keys = append(a.keys, key)
+	}
+	s.Count++
+	s.Quantities.Add(model.Quantity{Amount: record.Quantity, Unit: record.Unit})
+	if record.EstimatedCost != nil {
+		s.EstimatedCost += *record.EstimatedCost
+	}
+}
+
+func (a *wasteAggregator) summaries() []model.WasteSummary {
+	result := make([]model.WasteSummary, 0, len(a.keys))
+	for _, key := range a.keys {
+		result = append(result, *a.summary[key])
+	}
+	return result
+}
+
+func sortWasteSummaries(summaries []model.WasteSummary) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].EstimatedCost != summaries[j].EstimatedCost {
+			return summaries[i].EstimatedCost > summaries[j].EstimatedCost
+		}
+		return summaries[i].Count > summaries[j].Count
+	})
+}
+
+// spendingAggregator はキーごとに購入金額と廃棄の金額を集計する。キーは最初に現れた順に保持する。
+type spendingAggregator struct {
+	keys    []string
+	summary map[string]*model.SpendingSummary
+}
+
+func newSpendingAggregator() *spendingAggregator {
+	return &spendingAggregator{summary: map[string]*model.SpendingSummary{}}
+}
+
+func (a *spendingAggregator) get(key string) *model.SpendingSummary {
+	s, ok := a.summary[key]
+	if !ok {
+		s = &model.SpendingSummary{Key: key, Amounts: model.MoneyTotals{}, WasteCost: model.MoneyTotals{}}
+		a.summary[key] = s
+		a.keys = append(a.keys, key)
+	}
+	return s
+}
+
+func (a *spendingAggregator) summaries() []model.SpendingSummary {
+	result := make([]model.SpendingSummary, 0, len(a.keys))
+	for _, key := range a.keys {
+		result = append(result, *a.summary[key])
+	}
+	return result
+}
+
+// sortedByKey はキー（週・月）の昇順に並べた集計を返す
+func (a *spendingAggregator) sortedByKey() []model.SpendingSummary {
+	result := a.summaries()
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
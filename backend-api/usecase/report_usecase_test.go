@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"go-rest-api/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWasteRepository struct {
+	mock.Mock
+}
+
+func (m *MockWasteRepository) GetWasteRecords(records *[]model.WasteRecord, userId uint, from time.Time, to time.Time) error {
+	args := m.Called(records, userId, from, to)
+	if items, ok := args.Get(0).([]model.WasteRecord); ok {
+		*records = items
+	}
+	return args.Error(1)
+}
+
+type MockPurchaseRepository struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseRepository) GetPurchases(purchases *[]model.Purchase, userId uint, from time.Time, to time.Time) error {
+	args := m.Called(purchases, userId, from, to)
+	if items, ok := args.Get(0).([]model.Purchase); ok {
+		*purchases = items
+	}
+	return args.Error(1)
+}
+
+func TestReportUsecase_GetWasteReport(t *testing.T) {
+	mockRepo := new(MockWasteRepository)
+	usecase := NewReportUsecase(mockRepo, new(MockPurchaseRepository))
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	meat := model.Category{ID: 7, Code: "meat", Name: "肉"}
+	cost := func(v float64) *float64 { return &v }
+	mockRepo.On("GetWasteRecords", mock.Anything, uint(1), from, to).Return([]model.WasteRecord{
+		{Title: "鶏むね肉", Category: &meat, Quantity: 300, Unit: model.UnitGram, Reason: model.WasteSpoiled, EstimatedCost: cost(180), DiscardedAt: time.Date(2026, 9, 10, 12, 0, 0, 0, time.Local)},
+		{Title: "豚こま", Category: &meat, Quantity: 0.2, Unit: model.UnitKilogram, Reason: model.WasteExpired, EstimatedCost: cost(250), DiscardedAt: time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local)},
+		{Title: "カレーの残り", Quantity: 1, Unit: model.UnitPiece, Reason: model.WasteLeftover, DiscardedAt: time.Date(2026, 10, 5, 12, 0, 0, 0, time.Local)},
+	}, nil)
+
+	report, err := usecase.GetWasteReport(1, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Count)
+	assert.Equal(t, 430.0, report.EstimatedCost)
+
+	assert.Len(t, report.ByMonth, 2)
+	assert.Equal(t, "2026-09", report.ByMonth[0].Key)
+	assert.Equal(t, 2, report.ByMonth[1].Count)
+
+	// 単位の異なる数量は基準単位に換算して合計する
+	assert.Equal(t, "肉", report.ByCategory[0].Key)
+	assert.Equal(t, 500.0, report.ByCategory[0].Quantities[model.UnitGram])
+	assert.Equal(t, "未分類", report.ByCategory[1].Key)
+
+	assert.Equal(t, "expired", report.ByReason[0].Key)
+	assert.Len(t, report.ByReason, 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReportUsecase_GetSpendingReport(t *testing.T) {
+	wasteRepo := new(MockWasteRepository)
+	purchaseRepo := new(MockPurchaseRepository)
+	usecase := NewReportUsecase(wasteRepo, purchaseRepo)
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	meat := model.Category{ID: 7, Code: "meat", Name: "肉"}
+	cost := func(v float64) *float64 { return &v }
+	purchaseRepo.On("GetPurchases", mock.Anything, uint(1), from, to).Return([]model.Purchase{
+		// 2026-09-30 は水曜日、2026-10-04 は日曜日で同じ週
+		{Title: "鶏むね肉", Category: &meat, Price: 398, Currency: "JPY", PurchasedAt: time.Date(2026, 9, 30, 18, 0, 0, 0, time.Local)},
+		{Title: "牛乳", Price: 238, Currency: "JPY", PurchasedAt: time.Date(2026, 10, 4, 18, 0, 0, 0, time.Local)},
+		{Title: "豚こま", Category: &meat, Price: 4.5, Currency: "USD", PurchasedAt: time.Date(2026, 10, 5, 18, 0, 0, 0, time.Local)},
+	}, nil)
+	wasteRepo.On("GetWasteRecords", mock.Anything, uint(1), from, to).Return([]model.WasteRecord{
+		{Title: "鶏むね肉", Category: &meat, EstimatedCost: cost(199), Currency: "JPY", DiscardedAt: time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local)},
+		{Title: "カレーの残り", Currency: "JPY", DiscardedAt: time.Date(2026, 10, 5, 12, 0, 0, 0, time.Local)},
+	}, nil)
+
+	report, err := usecase.GetSpendingReport(1, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Count)
+	// 通貨は換算せずに分けて合計する
+	assert.Equal(t, model.MoneyTotals{"JPY": 636, "USD": 4.5}, report.Amounts)
+	assert.Equal(t, model.MoneyTotals{"JPY": 199}, report.WasteCost)
+
+	assert.Len(t, report.ByWeek, 2)
+	assert.Equal(t, "2026-09-28", report.ByWeek[0].Key)
+	assert.Equal(t, 2, report.ByWeek[0].Count)
+	assert.Equal(t, model.MoneyTotals{"JPY": 199}, report.ByWeek[0].WasteCost)
+	assert.Equal(t, "2026-10-05", report.ByWeek[1].Key)
+
+	assert.Equal(t, []string{"2026-09", "2026-10"}, []string{report.ByMonth[0].Key, report.ByMonth[1].Key})
+	assert.Equal(t, model.MoneyTotals{"JPY": 238, "USD": 4.5}, report.ByMonth[1].Amounts)
+
+	assert.Equal(t, "肉", report.ByCategory[0].Key)
+	assert.Equal(t, model.MoneyTotals{"JPY": 398, "USD": 4.5}, report.ByCategory[0].Amounts)
+	assert.Equal(t, model.MoneyTotals{"JPY": 199}, report.ByCategory[0].WasteCost)
+	assert.Equal(t, "未分類", report.ByCategory[1].Key)
+	purchaseRepo.AssertExpectations(t)
+	wasteRepo.AssertExpectations(t)
+}
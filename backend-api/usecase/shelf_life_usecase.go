@@ -0,0 +1,118 @@
+package usecase
+
+//go:generate mockgen -source=shelf_life_usecase.go -destination=../mock/shelf_life_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IShelfLifeUsecase interface {
+	EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error)
+	LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time, expiryDate time.Time) error
+}
+
+type shelfLifeUsecase struct {
+	sr repository.IShelfLifeRepository
+}
+
+func NewShelfLifeUsecase(sr repository.IShelfLifeRepository) IShelfLifeUsecase {
+	return &shelfLifeUsecase{sr}
+}
+
+// EstimateShelfLife は食材の日持ちの目安と、購入日時 purchasedAt から求めた賞味期限を返す。
+// ユーザーの入力から学習した日数があればそれを、なければ同梱のデータ（model.DefaultShelfLives）を使う。
+// locationType を省略した場合は食材ごとの既定の保管場所（同梱のデータにない食材は冷蔵）の日持ちを返す。
+// 目安がない場合は apperrors.ShelfLifeUnknown を返す。
+func (su *shelfLifeUsecase) EstimateShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time) (model.ShelfLifeEstimate, error) {
+	if strings.TrimSpace(title) == "" {
+		return model.ShelfLifeEstimate{}, apperrors.New(apperrors.ValidationError, "食材名を指定してください", http.StatusBadRequest, nil)
+	}
+	entry, known := model.FindShelfLifeEntry(title)
+	locationType, err := shelfLifeLocation(entry, locationType)
+	if err != nil {
+		return model.ShelfLifeEstimate{}, err
+	}
+	estimate := model.ShelfLifeEstimate{
+		Ingredient:   model.CanonicalIngredient(title),
+		Name:         title,
+		LocationType: locationType,
+		PurchasedAt:  purchasedAt,
+	}
+	if known {
+		estimate.Name = entry.Name
+	}
+
+	override := model.ShelfLifeOverride{}
+	err = su.sr.GetShelfLifeOverride(&override, userId, estimate.Ingredient, locationType)
+	switch {
+	case err == nil:
+		estimate.Days, estimate.Source = override.Days, model.ShelfLifeSourceUser
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return model.ShelfLifeEstimate{}, err
+	case entry.Days[locationType] > 0:
+		estimate.Days, estimate.Source = entry.Days[locationType], model.ShelfLifeSourceDefault
+	default:
+		return model.ShelfLifeEstimate{}, apperrors.ShelfLifeUnknown
+	}
+	estimate.ExpiryDate = purchasedAt.AddDate(0, 0, estimate.Days)
+	return estimate, nil
+}
+
+// LearnShelfLife はユーザーが入力した賞味期限から、食材と保管場所の種類ごとの日持ちを学習する。
+// 購入日から賞味期限までの日数が 1〜validator.FoodItemStateShelfLifeMaxDays 日の範囲にない入力は学習しない。
+func (su *shelfLifeUsecase) LearnShelfLife(userId uint, title string, locationType model.LocationType, purchasedAt time.Time, expiryDate time.Time) error {
+	if strings.TrimSpace(title) == "" {
+		return nil
+	}
+	days := daysBetween(purchasedAt, expiryDate)
+	if days < 1 || days > validator.FoodItemStateShelfLifeMaxDays {
+		return nil
+	}
+	entry, _ := model.FindShelfLifeEntry(title)
+	locationType, err := shelfLifeLocation(entry, locationType)
+	if err != nil {
+		return err
+	}
+	override := model.ShelfLifeOverride{}
+	ingredient := model.CanonicalIngredient(title)
+	if err := su.sr.GetShelfLifeOverride(&override, userId, ingredient, locationType); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		override = model.ShelfLifeOverride{UserId: userId, Ingredient: ingredient, LocationType: locationType}
+	}
+	override.Learn(days)
+	return su.sr.SaveShelfLifeOverride(&override)
+}
+
+// shelfLifeLocation は日持ちを調べる保管場所の種類を決める。省略した場合は食材の既定の保管場所にする
+func shelfLifeLocation(entry model.ShelfLifeEntry, locationType model.LocationType) (model.LocationType, error) {
+	switch locationType {
+	case model.LocationFridge, model.LocationFreezer, model.LocationPantry:
+		return locationType, nil
+	case "":
+		if entry.DefaultLocation != "" {
+			return entry.DefaultLocation, nil
+		}
+		return model.LocationFridge, nil
+	default:
+		return "", apperrors.New(apperrors.ValidationError, "保管場所は fridge、freezer、pantry のいずれかを指定してください", http.StatusBadRequest, nil)
+	}
+}
+
+// daysBetween は from の日付から to の日付までの日数を返す（時刻は無視する）
+func daysBetween(from time.Time, to time.Time) int {
+	to = to.In(from.Location())
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
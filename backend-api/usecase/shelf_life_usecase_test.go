@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockShelfLifeRepository struct {
+	mock.Mock
+}
+
+func (m *MockShelfLifeRepository) GetShelfLifeOverride(override *model.ShelfLifeOverride, userId uint, ingredient string, locationType model.LocationType) error {
+	args := m.Called(override, userId, ingredient, locationType)
+	if v, ok := args.Get(0).(model.ShelfLifeOverride); ok {
+		*override = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockShelfLifeRepository) SaveShelfLifeOverride(override *model.ShelfLifeOverride) error {
+	args := m.Called(override)
+	return args.Error(0)
+}
+
+func TestShelfLifeUsecase_EstimateShelfLife(t *testing.T) {
+	purchasedAt := time.Date(2026, 10, 17, 10, 0, 0, 0, time.Local)
+
+	t.Run("同梱のデータから既定の保管場所の日持ちを返す", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+		mockRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), "cabbage", model.LocationFridge).Return(nil, gorm.ErrRecordNotFound)
+
+		estimate, err := usecase.EstimateShelfLife(1, "キャベツ", "", purchasedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.LocationFridge, estimate.LocationType)
+		assert.Equal(t, 14, estimate.Days)
+		assert.Equal(t, model.ShelfLifeSourceDefault, estimate.Source)
+		assert.Equal(t, purchasedAt.AddDate(0, 0, 14), estimate.ExpiryDate)
+	})
+
+	t.Run("学習した日数を優先する", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+		mockRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), "bread", model.LocationPantry).
+			Return(model.ShelfLifeOverride{Days: 2}, nil)
+
+		estimate, err := usecase.EstimateShelfLife(1, "バゲット", model.LocationPantry, purchasedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, estimate.Days)
+		assert.Equal(t, model.ShelfLifeSourceUser, estimate.Source)
+	})
+
+	t.Run("目安がない保管場所は404", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+		mockRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), "potato", model.LocationFreezer).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := usecase.EstimateShelfLife(1, "じゃがいも", model.LocationFreezer, purchasedAt)
+
+		assert.Equal(t, http.StatusNotFound, apperrors.GetHTTPStatus(err))
+	})
+
+	t.Run("食材名と保管場所の誤りは400", func(t *testing.T) {
+		usecase := NewShelfLifeUsecase(new(MockShelfLifeRepository))
+
+		_, err := usecase.EstimateShelfLife(1, " ", "", purchasedAt)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		_, err = usecase.EstimateShelfLife(1, "キャベツ", "cellar", purchasedAt)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestShelfLifeUsecase_LearnShelfLife(t *testing.T) {
+	purchasedAt := time.Date(2026, 10, 17, 20, 0, 0, 0, time.Local)
+
+	t.Run("初めての入力は購入日からの日数をそのまま記録する", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+		mockRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), "けちゃっぷ", model.LocationFridge).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("SaveShelfLifeOverride", mock.MatchedBy(func(override *model.ShelfLifeOverride) bool {
+			return override.UserId == 1 && override.Ingredient == "けちゃっぷ" && override.Days == 30 && override.SampleCount == 1
+		})).Return(nil)
+
+		// 時刻は無視して日付の差で数える
+		err := usecase.LearnShelfLife(1, "ケチャップ", "", purchasedAt, time.Date(2026, 11, 16, 0, 0, 0, 0, time.Local))
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("学習済みの日数と平均する", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+		mockRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), "bread", model.LocationPantry).
+			Return(model.ShelfLifeOverride{ID: 3, UserId: 1, Ingredient: "bread", LocationType: model.LocationPantry, Days: 3, SampleCount: 1}, nil)
+		mockRepo.On("SaveShelfLifeOverride", mock.MatchedBy(func(override *model.ShelfLifeOverride) bool {
+			return override.ID == 3 && override.Days == 2 && override.SampleCount == 2
+		})).Return(nil)
+
+		err := usecase.LearnShelfLife(1, "食パン", model.LocationPantry, purchasedAt, purchasedAt.AddDate(0, 0, 1))
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("購入日より前の賞味期限は学習しない", func(t *testing.T) {
+		mockRepo := new(MockShelfLifeRepository)
+		usecase := NewShelfLifeUsecase(mockRepo)
+
+		err := usecase.LearnShelfLife(1, "食パン", "", purchasedAt, purchasedAt.AddDate(0, 0, -1))
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "SaveShelfLifeOverride", mock.Anything)
+	})
+}
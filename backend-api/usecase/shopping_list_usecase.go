@@ -0,0 +1,240 @@
+package usecase
+
+//go:generate mockgen -source=shopping_list_usecase.go -destination=../mock/shopping_list_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	"fmt"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IShoppingListUsecase interface {
+	GetShoppingListItems(userId uint) ([]model.ShoppingListItemResponse, error)
+	CreateShoppingListItem(item model.ShoppingListItem) (model.ShoppingListItemResponse, error)
+	UpdateShoppingListItem(item model.ShoppingListItem, userId uint, itemId uint) (model.ShoppingListItemResponse, error)
+	DeleteShoppingListItem(userId uint, itemId uint) error
+	ReorderShoppingListItems(userId uint, itemIds []uint) ([]model.ShoppingListItemResponse, error)
+	PurchaseShoppingListItems(userId uint, purchase model.ShoppingListPurchase) ([]model.PurchasedItem, error)
+}
+
+type shoppingListUsecase struct {
+	sr  repository.IShoppingListRepository
+	slr repository.IStorageLocationRepository
+	sv  validator.IShoppingListItemValidator
+	fv  validator.IFoodItemValidator
+	se  ShelfLifeEstimator
+}
+
+func NewShoppingListUsecase(sr repository.IShoppingListRepository, slr repository.IStorageLocationRepository, sv validator.IShoppingListItemValidator, fv validator.IFoodItemValidator, se ShelfLifeEstimator) IShoppingListUsecase {
+	return &shoppingListUsecase{sr, slr, sv, fv, se}
+}
+
+func (su *shoppingListUsecase) GetShoppingListItems(userId uint) ([]model.ShoppingListItemResponse, error) {
+	items := []model.ShoppingListItem{}
+	if err := su.sr.GetShoppingListItems(&items, userId); err != nil {
+		return nil, err
+	}
+	resItems := []model.ShoppingListItemResponse{}
+	for _, v := range items {
+		resItems = append(resItems, toShoppingListItemResponse(v))
+	}
+	return resItems, nil
+}
+
+func (su *shoppingListUsecase) CreateShoppingListItem(item model.ShoppingListItem) (model.ShoppingListItemResponse, error) {
+	if err := su.normalizeShoppingListItem(&item); err != nil {
+		return model.ShoppingListItemResponse{}, err
+	}
+	if err := su.sr.CreateShoppingListItem(&item); err != nil {
+		return model.ShoppingListItemResponse{}, err
+	}
+	return toShoppingListItemResponse(item), nil
+}
+
+func (su *shoppingListUsecase) UpdateShoppingListItem(item model.ShoppingListItem, userId uint, itemId uint) (model.ShoppingListItemResponse, error) {
+	if err := su.normalizeShoppingListItem(&item); err != nil {
+		return model.ShoppingListItemResponse{}, err
+	}
+	if err := su.sr.UpdateShoppingListItem(&item, userId, itemId); err != nil {
+		return model.ShoppingListItemResponse{}, shoppingListItemError(err)
+	}
+	return toShoppingListItemResponse(item), nil
+}
+
+func (su *shoppingListUsecase) DeleteShoppingListItem(userId uint, itemId uint) error {
+	if err := su.sr.DeleteShoppingListItem(userId, itemId); err != nil {
+		return shoppingListItemError(err)
+	}
+	return nil
+}
+
+// ReorderShoppingListItems はリストを itemIds の順に並べ替える。
+// 一部だけの並べ替えによる位置の重なりを防ぐため、リストのすべての項目を 1 回ずつ指定する必要がある。
+func (su *shoppingListUsecase) ReorderShoppingListItems(userId uint, itemIds []uint) ([]model.ShoppingListItemResponse, error) {
+	items := []model.ShoppingListItem{}
+	if err := su.sr.GetShoppingListItems(&items, userId); err != nil {
+		return nil, err
+	}
+	current := make([]uint, 0, len(items))
+	for _, item := range items {
+		current = append(current, item.ID)
+	}
+	sorted := slices.Clone(itemIds)
+	slices.Sort(sorted)
+	slices.Sort(current)
+	if !slices.Equal(sorted, current) {
+		return nil, apperrors.New(apperrors.ValidationError, "item_ids にはリストのすべての項目を 1 回ずつ指定してください", http.StatusBadRequest, nil)
+	}
+	if err := su.sr.ReorderShoppingListItems(userId, itemIds); err != nil {
+		return nil, shoppingListItemError(err)
+	}
+	return su.GetShoppingListItems(userId)
+}
+
+// PurchaseShoppingListItems はチェック済みの項目を食材として登録し、リストから削除する。
+// 賞味期限が入力されていない項目は、食材の登録と同じく日持ちの目安（ShelfLifeEstimator）と
+// 保管場所の種類から見積もり、目安がない場合は賞味期限の入力を求める。
+// 入力された賞味期限からは日持ちを学習する。
+func (su *shoppingListUsecase) PurchaseShoppingListItems(userId uint, purchase model.ShoppingListPurchase) ([]model.PurchasedItem, error) {
+	items := []model.ShoppingListItem{}
+	if err := su.sr.GetShoppingListItems(&items, userId); err != nil {
+		return nil, err
+	}
+	checked := map[uint]model.ShoppingListItem{}
+	for _, item := range items {
+		if item.Checked {
+			checked[item.ID] = item
+		}
+	}
+	if len(checked) == 0 {
+		return nil, apperrors.New(apperrors.ValidationError, "チェックされた項目がありません", http.StatusBadRequest, nil)
+	}
+	entries := map[uint]model.ShoppingListPurchaseEntry{}
+	locationTypes := map[uint]model.LocationType{}
+	for _, entry := range purchase.Items {
+		if _, ok := checked[entry.ItemId]; !ok {
+			return nil, apperrors.New(apperrors.ValidationError, fmt.Sprintf("項目 %d はチェックされていません", entry.ItemId), http.StatusBadRequest, nil)
+		}
+		if entry.StorageLocationId != nil {
+			location := model.StorageLocation{}
+			if err := su.slr.GetStorageLocationById(&location, userId, *entry.StorageLocationId); err != nil {
+				return nil, storageLocationError(err)
+			}
+			locationTypes[entry.ItemId] = location.Type
+		}
+		entries[entry.ItemId] = entry
+	}
+
+	now := time.Now()
+	itemIds := []uint{}
+	foodItems := []model.FoodItem{}
+	purchased := []model.PurchasedItem{}
+	for _, item := range items {
+		if !item.Checked {
+			continue
+		}
+		entry := entries[item.ID]
+		estimated := entry.ExpiryDate == nil
+		var expiryDate time.Time
+		if estimated {
+			estimate, err := su.se.EstimateShelfLife(userId, item.Name, locationTypes[item.ID], now)
+			if errors.Is(err, apperrors.ShelfLifeUnknown) {
+				return nil, apperrors.New(apperrors.ValidationError, fmt.Sprintf("「%s」の日持ちの目安がないため、賞味期限を入力してください", item.Name), http.StatusBadRequest, nil)
+			}
+			if err != nil {
+				return nil, err
+			}
+			expiryDate = estimate.ExpiryDate
+		} else {
+			expiryDate = *entry.ExpiryDate
+		}
+		foodItem := model.FoodItem{
+			Title:             item.Name,
+			Quantity:          item.Quantity,
+			Unit:              item.Unit,
+			ExpiryDate:        expiryDate,
+			StorageLocationId: entry.StorageLocationId,
+			UserId:            userId,
+			Lots: []model.FoodLot{{
+				Quantity:    item.Quantity,
+				ExpiryDate:  expiryDate,
+				PurchasedAt: now,
+			}},
+		}
+		if err := su.fv.FoodItemValidate(foodItem); err != nil {
+			return nil, validationError(err)
+		}
+		fillNutrition(&foodItem)
+		applyStorageState(&foodItem, locationTypes[item.ID], now)
+		itemIds = append(itemIds, item.ID)
+		foodItems = append(foodItems, foodItem)
+		purchased = append(purchased, model.PurchasedItem{ShoppingListItemId: item.ID, ExpiryEstimated: estimated})
+	}
+
+	if err := su.sr.PurchaseShoppingListItems(userId, itemIds, foodItems); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 読み込んだ後に項目が削除またはチェックを外された
+			return nil, apperrors.New(apperrors.BusinessError, "買い物リストが変更されました。もう一度お試しください", http.StatusConflict, err)
+		}
+		return nil, householdError(err)
+	}
+	for i := range purchased {
+		purchased[i].FoodItem = toFoodItemResponse(foodItems[i])
+		if !purchased[i].ExpiryEstimated {
+			// 学習に失敗しても購入は取り消さない
+			if err := su.se.LearnShelfLife(userId, foodItems[i].Title, locationTypes[purchased[i].ShoppingListItemId], now, foodItems[i].ExpiryDate); err != nil {
+				log.Printf("日持ちの学習に失敗しました（ユーザー %d）: %v", userId, err)
+			}
+		}
+	}
+	return purchased, nil
+}
+
+// normalizeShoppingListItem は単位を正規化し、数量が省略された場合は 1 にして検証する
+func (su *shoppingListUsecase) normalizeShoppingListItem(item *model.ShoppingListItem) error {
+	item.Name = strings.TrimSpace(item.Name)
+	unit, err := model.ParseUnit(string(item.Unit))
+	if err != nil {
+		return apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, nil)
+	}
+	item.Unit = unit
+	if item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	if err := su.sv.ShoppingListItemValidate(*item); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+func toShoppingListItemResponse(item model.ShoppingListItem) model.ShoppingListItemResponse {
+	return model.ShoppingListItemResponse{
+		ID:        item.ID,
+		Name:      item.Name,
+		Quantity:  item.Quantity,
+		Unit:      item.Unit,
+		Note:      item.Note,
+		Checked:   item.Checked,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+// shoppingListItemError はリポジトリの「レコードなし」を 404 のアプリケーションエラーに変換する
+func shoppingListItemError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ShoppingListItemNotFound
+	}
+	return err
+}
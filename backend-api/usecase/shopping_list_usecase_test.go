@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockShoppingListRepository struct {
+	mock.Mock
+}
+
+func (m *MockShoppingListRepository) GetShoppingListItems(items *[]model.ShoppingListItem, userId uint) error {
+	args := m.Called(items, userId)
+	if v, ok := args.Get(0).([]model.ShoppingListItem); ok {
+		*items = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockShoppingListRepository) GetShoppingListItemById(item *model.ShoppingListItem, userId uint, itemId uint) error {
+	args := m.Called(item, userId, itemId)
+	if v, ok := args.Get(0).(model.ShoppingListItem); ok {
+		*item = v
+	}
+	return args.Error(1)
+}
+
+func (m *MockShoppingListRepository) CreateShoppingListItem(item *model.ShoppingListItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockShoppingListRepository) UpdateShoppingListItem(item *model.ShoppingListItem, userId uint, itemId uint) error {
+	args := m.Called(item, userId, itemId)
+	return args.Error(0)
+}
+
+func (m *MockShoppingListRepository) DeleteShoppingListItem(userId uint, itemId uint) error {
+	args := m.Called(userId, itemId)
+	return args.Error(0)
+}
+
+func (m *MockShoppingListRepository) ReorderShoppingListItems(userId uint, itemIds []uint) error {
+	args := m.Called(userId, itemIds)
+	return args.Error(0)
+}
+
+func (m *MockShoppingListRepository) PurchaseShoppingListItems(userId uint, itemIds []uint, foodItems []model.FoodItem) error {
+	args := m.Called(userId, itemIds, foodItems)
+	return args.Error(0)
+}
+
+// newShoppingListUsecase は学習した日持ちがなく、同梱のデータ（model.DefaultShelfLives）で賞味期限を見積もるユースケースを返す
+func newShoppingListUsecase() (IShoppingListUsecase, *MockShoppingListRepository, *MockShelfLifeRepository) {
+	mockRepo := new(MockShoppingListRepository)
+	mockShelfLifeRepo := new(MockShelfLifeRepository)
+	mockShelfLifeRepo.On("GetShelfLifeOverride", mock.Anything, uint(1), mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	mockShelfLifeRepo.On("SaveShelfLifeOverride", mock.Anything).Return(nil).Maybe()
+	usecase := NewShoppingListUsecase(mockRepo, new(MockStorageLocationRepository), validator.NewShoppingListItemValidator(), validator.NewFoodItemValidator(), NewShelfLifeUsecase(mockShelfLifeRepo))
+	return usecase, mockRepo, mockShelfLifeRepo
+}
+
+func TestShoppingListUsecase_CreateShoppingListItem(t *testing.T) {
+	t.Run("数量を省略すると1、単位は正規化する", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("CreateShoppingListItem", mock.MatchedBy(func(item *model.ShoppingListItem) bool {
+			return item.Name == "卵" && item.Quantity == 1 && item.Unit == model.UnitPack
+		})).Return(nil)
+
+		res, err := usecase.CreateShoppingListItem(model.ShoppingListItem{Name: " 卵 ", Unit: "パック", UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "卵", res.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("名前なしは項目ごとのエラーを返す", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+
+		_, err := usecase.CreateShoppingListItem(model.ShoppingListItem{Quantity: -1, UserId: 1})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		assert.Contains(t, apperrors.GetFields(err), "name")
+		assert.Contains(t, apperrors.GetFields(err), "quantity")
+		mockRepo.AssertNotCalled(t, "CreateShoppingListItem", mock.Anything)
+	})
+}
+
+func TestShoppingListUsecase_UpdateShoppingListItem_NotFound(t *testing.T) {
+	usecase, mockRepo, _ := newShoppingListUsecase()
+	mockRepo.On("UpdateShoppingListItem", mock.Anything, uint(1), uint(9)).Return(gorm.ErrRecordNotFound)
+
+	_, err := usecase.UpdateShoppingListItem(model.ShoppingListItem{Name: "牛乳"}, 1, 9)
+
+	assert.ErrorIs(t, err, apperrors.ShoppingListItemNotFound)
+}
+
+func TestShoppingListUsecase_ReorderShoppingListItems(t *testing.T) {
+	items := []model.ShoppingListItem{{ID: 1, Name: "卵"}, {ID: 2, Name: "牛乳"}, {ID: 3, Name: "米"}}
+
+	t.Run("すべての項目を指定して並べ替える", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return(items, nil)
+		mockRepo.On("ReorderShoppingListItems", uint(1), []uint{3, 1, 2}).Return(nil)
+
+		_, err := usecase.ReorderShoppingListItems(1, []uint{3, 1, 2})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("足りない・重複した指定は受け付けない", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return(items, nil)
+
+		_, err := usecase.ReorderShoppingListItems(1, []uint{3, 1})
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		_, err = usecase.ReorderShoppingListItems(1, []uint{3, 1, 1})
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "ReorderShoppingListItems", mock.Anything, mock.Anything)
+	})
+}
+
+func TestShoppingListUsecase_PurchaseShoppingListItems(t *testing.T) {
+	items := []model.ShoppingListItem{
+		{ID: 1, Name: "牛乳", Quantity: 1, Unit: model.UnitLiter, Checked: true},
+		{ID: 2, Name: "卵", Quantity: 1, Unit: model.UnitPack, Checked: true},
+		{ID: 3, Name: "豆腐", Quantity: 2, Unit: model.UnitPiece, Checked: true},
+		{ID: 4, Name: "米", Quantity: 5, Unit: model.UnitKilogram},
+	}
+	expiry := time.Now().AddDate(0, 0, 10).Truncate(time.Second)
+
+	usecase, mockRepo, mockShelfLifeRepo := newShoppingListUsecase()
+	mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return(items, nil)
+	var created []model.FoodItem
+	mockRepo.On("PurchaseShoppingListItems", uint(1), []uint{1, 2, 3}, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(2).([]model.FoodItem)
+	}).Return(nil)
+
+	purchased, err := usecase.PurchaseShoppingListItems(1, model.ShoppingListPurchase{
+		Items: []model.ShoppingListPurchaseEntry{{ItemId: 1, ExpiryDate: &expiry}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, purchased, 3)
+	assert.False(t, purchased[0].ExpiryEstimated)
+	assert.True(t, purchased[1].ExpiryEstimated)
+	assert.Equal(t, expiry, created[0].ExpiryDate)
+	// 賞味期限を省略した項目は食材の登録と同じ日持ちの目安（卵 14 日・豆腐 5 日）で見積もる
+	assert.InDelta(t, 14, time.Until(created[1].ExpiryDate).Hours()/24, 0.01)
+	assert.InDelta(t, 5, time.Until(created[2].ExpiryDate).Hours()/24, 0.01)
+	assert.Len(t, created[2].Lots, 1)
+	assert.Equal(t, 2.0, created[2].Lots[0].Quantity)
+	// 入力された賞味期限だけを学習する
+	mockShelfLifeRepo.AssertNumberOfCalls(t, "SaveShelfLifeOverride", 1)
+	mockShelfLifeRepo.AssertCalled(t, "SaveShelfLifeOverride", mock.MatchedBy(func(override *model.ShelfLifeOverride) bool {
+		return override.Ingredient == "milk" && override.LocationType == model.LocationFridge
+	}))
+}
+
+func TestShoppingListUsecase_PurchaseShoppingListItems_Invalid(t *testing.T) {
+	t.Run("チェックされた項目がない", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return([]model.ShoppingListItem{{ID: 4, Name: "米", Quantity: 1}}, nil)
+
+		_, err := usecase.PurchaseShoppingListItems(1, model.ShoppingListPurchase{})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+	})
+
+	t.Run("チェックされていない項目の賞味期限は指定できない", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return([]model.ShoppingListItem{
+			{ID: 1, Name: "牛乳", Quantity: 1, Checked: true},
+			{ID: 4, Name: "米", Quantity: 1},
+		}, nil)
+
+		_, err := usecase.PurchaseShoppingListItems(1, model.ShoppingListPurchase{
+			Items: []model.ShoppingListPurchaseEntry{{ItemId: 4}},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "PurchaseShoppingListItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("日持ちの目安がない項目は賞味期限の入力が必要", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return([]model.ShoppingListItem{{ID: 1, Name: "ケチャップ", Quantity: 1, Checked: true}}, nil)
+
+		_, err := usecase.PurchaseShoppingListItems(1, model.ShoppingListPurchase{})
+
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "PurchaseShoppingListItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("読み込んだ後にリストが変更された", func(t *testing.T) {
+		usecase, mockRepo, _ := newShoppingListUsecase()
+		mockRepo.On("GetShoppingListItems", mock.Anything, uint(1)).Return([]model.ShoppingListItem{{ID: 1, Name: "牛乳", Quantity: 1, Checked: true}}, nil)
+		mockRepo.On("PurchaseShoppingListItems", uint(1), []uint{1}, mock.Anything).Return(gorm.ErrRecordNotFound)
+
+		_, err := usecase.PurchaseShoppingListItems(1, model.ShoppingListPurchase{})
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+	})
+}
@@ -0,0 +1,97 @@
+package usecase
+
+//go:generate mockgen -source=storage_location_usecase.go -destination=../mock/storage_location_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type IStorageLocationUsecase interface {
+	GetAllStorageLocations(userId uint) ([]model.StorageLocationResponse, error)
+	GetStorageLocationById(userId uint, locationId uint) (model.StorageLocationResponse, error)
+	CreateStorageLocation(location model.StorageLocation) (model.StorageLocationResponse, error)
+	UpdateStorageLocation(location model.StorageLocation, userId uint, locationId uint) (model.StorageLocationResponse, error)
+	DeleteStorageLocation(userId uint, locationId uint) error
+}
+
+type storageLocationUsecase struct {
+	sr repository.IStorageLocationRepository
+	sv validator.IStorageLocationValidator
+}
+
+func NewStorageLocationUsecase(sr repository.IStorageLocationRepository, sv validator.IStorageLocationValidator) IStorageLocationUsecase {
+	return &storageLocationUsecase{sr, sv}
+}
+
+func (su *storageLocationUsecase) GetAllStorageLocations(userId uint) ([]model.StorageLocationResponse, error) {
+	locations := []model.StorageLocation{}
+	if err := su.sr.GetAllStorageLocations(&locations, userId); err != nil {
+		return nil, err
+	}
+	resLocations := []model.StorageLocationResponse{}
+	for _, v := range locations {
+		resLocations = append(resLocations, toStorageLocationResponse(v))
+	}
+	return resLocations, nil
+}
+
+func (su *storageLocationUsecase) GetStorageLocationById(userId uint, locationId uint) (model.StorageLocationResponse, error) {
+	location := model.StorageLocation{}
+	if err := su.sr.GetStorageLocationById(&location, userId, locationId); err != nil {
+		return model.StorageLocationResponse{}, storageLocationError(err)
+	}
+	return toStorageLocationResponse(location), nil
+}
+
+func (su *storageLocationUsecase) CreateStorageLocation(location model.StorageLocation) (model.StorageLocationResponse, error) {
+	if err := su.sv.StorageLocationValidate(location); err != nil {
+		return model.StorageLocationResponse{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, err)
+	}
+	if err := su.sr.CreateStorageLocation(&location); err != nil {
+		return model.StorageLocationResponse{}, householdError(err)
+	}
+	return toStorageLocationResponse(location), nil
+}
+
+func (su *storageLocationUsecase) UpdateStorageLocation(location model.StorageLocation, userId uint, locationId uint) (model.StorageLocationResponse, error) {
+	if err := su.sv.StorageLocationValidate(location); err != nil {
+		return model.StorageLocationResponse{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, err)
+	}
+	if err := su.sr.UpdateStorageLocation(&location, userId, locationId); err != nil {
+		return model.StorageLocationResponse{}, storageLocationError(err)
+	}
+	return toStorageLocationResponse(location), nil
+}
+
+func (su *storageLocationUsecase) DeleteStorageLocation(userId uint, locationId uint) error {
+	if err := su.sr.DeleteStorageLocation(userId, locationId); err != nil {
+		return storageLocationError(err)
+	}
+	return nil
+}
+
+func toStorageLocationResponse(location model.StorageLocation) model.StorageLocationResponse {
+	return model.StorageLocationResponse{
+		ID:          location.ID,
+		Name:        location.Name,
+		Type:        location.Type,
+		HouseholdId: location.HouseholdId,
+		CreatedAt:   location.CreatedAt,
+		UpdatedAt:   location.UpdatedAt,
+	}
+}
+
+// storageLocationError はリポジトリの「レコードなし」を 404 のアプリケーションエラーに変換する
+func storageLocationError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.StorageLocationNotFound
+	}
+	return err
+}
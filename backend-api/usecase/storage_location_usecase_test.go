@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockStorageLocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockStorageLocationRepository) GetAllStorageLocations(locations *[]model.StorageLocation, userId uint) error {
+	args := m.Called(locations, userId)
+	if items, ok := args.Get(0).([]model.StorageLocation); ok {
+		*locations = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockStorageLocationRepository) GetStorageLocationById(location *model.StorageLocation, userId uint, locationId uint) error {
+	args := m.Called(location, userId, locationId)
+	if l, ok := args.Get(0).(model.StorageLocation); ok {
+		*location = l
+	}
+	return args.Error(1)
+}
+
+func (m *MockStorageLocationRepository) CreateStorageLocation(location *model.StorageLocation) error {
+	args := m.Called(location)
+	return args.Error(0)
+}
+
+func (m *MockStorageLocationRepository) UpdateStorageLocation(location *model.StorageLocation, userId uint, locationId uint) error {
+	args := m.Called(location, userId, locationId)
+	return args.Error(0)
+}
+
+func (m *MockStorageLocationRepository) DeleteStorageLocation(userId uint, locationId uint) error {
+	args := m.Called(userId, locationId)
+	return args.Error(0)
+}
+
+func TestStorageLocationUsecase_CreateStorageLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   model.StorageLocation
+		wantStatus int
+	}{
+		{
+			name:     "正常系：冷凍庫の作成",
+			location: model.StorageLocation{Name: "冷凍庫", Type: model.LocationFreezer, UserId: 1},
+		},
+		{
+			name:       "異常系：名前なし",
+			location:   model.StorageLocation{Type: model.LocationFridge, UserId: 1},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "異常系：未対応の種類",
+			location:   model.StorageLocation{Name: "ベランダ", Type: "balcony", UserId: 1},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockStorageLocationRepository)
+			usecase := NewStorageLocationUsecase(mockRepo, validator.NewStorageLocationValidator())
+			if tt.wantStatus == 0 {
+				mockRepo.On("CreateStorageLocation", mock.AnythingOfType("*model.StorageLocation")).Return(nil)
+			}
+
+			res, err := usecase.CreateStorageLocation(tt.location)
+
+			if tt.wantStatus != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantStatus, apperrors.GetHTTPStatus(err))
+				mockRepo.AssertNotCalled(t, "CreateStorageLocation", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.location.Name, res.Name)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStorageLocationUsecase_CrossUserAccess(t *testing.T) {
+	mockRepo := new(MockStorageLocationRepository)
+	usecase := NewStorageLocationUsecase(mockRepo, validator.NewStorageLocationValidator())
+	mockRepo.On("GetStorageLocationById", mock.Anything, uint(1), uint(99)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("DeleteStorageLocation", uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+
+	_, err := usecase.GetStorageLocationById(1, 99)
+	assert.ErrorIs(t, err, apperrors.StorageLocationNotFound)
+
+	err = usecase.DeleteStorageLocation(1, 99)
+	assert.ErrorIs(t, err, apperrors.StorageLocationNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+//go:generate mockgen -source=tag_usecase.go -destination=../mock/tag_usecase_mock.go -package=mock
+
+import (
+	"errors"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"go-rest-api/validator"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type ITagUsecase interface {
+	GetAllTags(userId uint) ([]model.TagResponse, error)
+	CreateTag(tag model.Tag) (model.TagResponse, error)
+	UpdateTag(tag model.Tag, userId uint, tagId uint) (model.TagResponse, error)
+	DeleteTag(userId uint, tagId uint) error
+}
+
+type tagUsecase struct {
+	tr repository.ITagRepository
+	tv validator.ITagValidator
+}
+
+func NewTagUsecase(tr repository.ITagRepository, tv validator.ITagValidator) ITagUsecase {
+	return &tagUsecase{tr, tv}
+}
+
+func (tu *tagUsecase) GetAllTags(userId uint) ([]model.TagResponse, error) {
+	tags := []model.Tag{}
+	if err := tu.tr.GetAllTags(&tags, userId); err != nil {
+		return nil, err
+	}
+	resTags := []model.TagResponse{}
+	for _, v := range tags {
+		resTags = append(resTags, model.TagResponse{ID: v.ID, Name: v.Name})
+	}
+	return resTags, nil
+}
+
+func (tu *tagUsecase) CreateTag(tag model.Tag) (model.TagResponse, error) {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if err := tu.tv.TagValidate(tag); err != nil {
+		return model.TagResponse{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, err)
+	}
+	if err := tu.tr.CreateTag(&tag); err != nil {
+		return model.TagResponse{}, tagError(err)
+	}
+	return model.TagResponse{ID: tag.ID, Name: tag.Name}, nil
+}
+
+func (tu *tagUsecase) UpdateTag(tag model.Tag, userId uint, tagId uint) (model.TagResponse, error) {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if err := tu.tv.TagValidate(tag); err != nil {
+		return model.TagResponse{}, apperrors.New(apperrors.ValidationError, err.Error(), http.StatusBadRequest, err)
+	}
+	if err := tu.tr.UpdateTag(&tag, userId, tagId); err != nil {
+		return model.TagResponse{}, tagError(err)
+	}
+	return model.TagResponse{ID: tag.ID, Name: tag.Name}, nil
+}
+
+func (tu *tagUsecase) DeleteTag(userId uint, tagId uint) error {
+	if err := tu.tr.DeleteTag(userId, tagId); err != nil {
+		return tagError(err)
+	}
+	return nil
+}
+
+// tagError はリポジトリの「レコードなし」を 404、タグ名の重複を 409 のアプリケーションエラーに変換する
+func tagError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.TagNotFound
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperrors.TagExists
+	}
+	return err
+}
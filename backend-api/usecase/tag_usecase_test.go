@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/validator"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) GetAllTags(tags *[]model.Tag, userId uint) error {
+	args := m.Called(tags, userId)
+	if items, ok := args.Get(0).([]model.Tag); ok {
+		*tags = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockTagRepository) GetOrCreateTags(tags *[]model.Tag, userId uint, names []string) error {
+	args := m.Called(tags, userId, names)
+	if items, ok := args.Get(0).([]model.Tag); ok {
+		*tags = items
+	}
+	return args.Error(1)
+}
+
+func (m *MockTagRepository) CreateTag(tag *model.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) UpdateTag(tag *model.Tag, userId uint, tagId uint) error {
+	args := m.Called(tag, userId, tagId)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) DeleteTag(userId uint, tagId uint) error {
+	args := m.Called(userId, tagId)
+	return args.Error(0)
+}
+
+func TestTagUsecase_CreateTag(t *testing.T) {
+	t.Run("正常系：前後の空白を除いて作成", func(t *testing.T) {
+		mockRepo := new(MockTagRepository)
+		usecase := NewTagUsecase(mockRepo, validator.NewTagValidator())
+		mockRepo.On("CreateTag", mock.MatchedBy(func(tag *model.Tag) bool {
+			return tag.Name == "作り置き"
+		})).Return(nil)
+
+		res, err := usecase.CreateTag(model.Tag{Name: " 作り置き ", UserId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "作り置き", res.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系：空のタグ名", func(t *testing.T) {
+		mockRepo := new(MockTagRepository)
+		usecase := NewTagUsecase(mockRepo, validator.NewTagValidator())
+
+		_, err := usecase.CreateTag(model.Tag{Name: "  ", UserId: 1})
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetHTTPStatus(err))
+		mockRepo.AssertNotCalled(t, "CreateTag", mock.Anything)
+	})
+
+	t.Run("異常系：同じ名前のタグがある", func(t *testing.T) {
+		mockRepo := new(MockTagRepository)
+		usecase := NewTagUsecase(mockRepo, validator.NewTagValidator())
+		mockRepo.On("CreateTag", mock.Anything).Return(gorm.ErrDuplicatedKey)
+
+		_, err := usecase.CreateTag(model.Tag{Name: "作り置き", UserId: 1})
+
+		assert.ErrorIs(t, err, apperrors.TagExists)
+		assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+	})
+}
+
+func TestTagUsecase_UpdateTag_Duplicate(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	usecase := NewTagUsecase(mockRepo, validator.NewTagValidator())
+	mockRepo.On("UpdateTag", mock.Anything, uint(1), uint(2)).Return(gorm.ErrDuplicatedKey)
+
+	_, err := usecase.UpdateTag(model.Tag{Name: "作り置き"}, 1, 2)
+
+	assert.ErrorIs(t, err, apperrors.TagExists)
+	assert.Equal(t, http.StatusConflict, apperrors.GetHTTPStatus(err))
+}
+
+func TestTagUsecase_DeleteTag_OtherUser(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	usecase := NewTagUsecase(mockRepo, validator.NewTagValidator())
+	mockRepo.On("DeleteTag", uint(1), uint(99)).Return(gorm.ErrRecordNotFound)
+
+	err := usecase.DeleteTag(1, 99)
+
+	assert.ErrorIs(t, err, apperrors.TagNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+//go:generate mockgen -source=user_setting_usecase.go -destination=../mock/user_setting_usecase_mock.go -package=mock
+
+import (
+	"fmt"
+	apperrors "go-rest-api/errors"
+	"go-rest-api/model"
+	"go-rest-api/repository"
+	"net/http"
+)
+
+type IUserSettingUsecase interface {
+	GetUserSetting(userId uint) (model.UserSetting, error)
+	UpdateUserSetting(setting model.UserSetting, userId uint) (model.UserSetting, error)
+}
+
+type userSettingUsecase struct {
+	usr repository.IUserSettingRepository
+}
+
+func NewUserSettingUsecase(usr repository.IUserSettingRepository) IUserSettingUsecase {
+	return &userSettingUsecase{usr}
+}
+
+func (su *userSettingUsecase) GetUserSetting(userId uint) (model.UserSetting, error) {
+	setting := model.UserSetting{}
+	if err := su.usr.GetUserSetting(&setting, userId); err != nil {
+		return model.UserSetting{}, err
+	}
+	return setting, nil
+}
+
+// maxExpiryWarningDays は賞味期限の通知を始める日数の上限
+const maxExpiryWarningDays = 60
+
+func (su *userSettingUsecase) UpdateUserSetting(setting model.UserSetting, userId uint) (model.UserSetting, error) {
+	if setting.ExpiryWarningDays < 0 || setting.ExpiryWarningDays > maxExpiryWarningDays {
+		return model.UserSetting{}, apperrors.New(apperrors.ValidationError, fmt.Sprintf("expiry_warning_days は0から%dの範囲で指定してください", maxExpiryWarningDays), http.StatusBadRequest, nil)
+	}
+	setting.UserId = userId
+	if err := su.usr.SaveUserSetting(&setting); err != nil {
+		return model.UserSetting{}, err
+	}
+	return setting, nil
+}
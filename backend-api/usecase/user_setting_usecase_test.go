@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"go-rest-api/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserSettingRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSettingRepository) GetUserSetting(setting *model.UserSetting, userId uint) error {
+	args := m.Called(setting, userId)
+	if s, ok := args.Get(0).(model.UserSetting); ok {
+		*setting = s
+	}
+	return args.Error(1)
+}
+
+func (m *MockUserSettingRepository) SaveUserSetting(setting *model.UserSetting) error {
+	args := m.Called(setting)
+	return args.Error(0)
+}
+
+func TestUserSettingUsecase_UpdateUserSetting(t *testing.T) {
+	mockRepo := new(MockUserSettingRepository)
+	usecase := NewUserSettingUsecase(mockRepo)
+
+	// リクエストのユーザーIDは無視し、認証済みユーザーの設定として保存する
+	mockRepo.On("SaveUserSetting", mock.MatchedBy(func(setting *model.UserSetting) bool {
+		return setting.UserId == 1 && setting.DeleteEmptyItems
+	})).Return(nil)
+
+	res, err := usecase.UpdateUserSetting(model.UserSetting{UserId: 2, DeleteEmptyItems: true, ExpiryWarningDays: 3}, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, res.DeleteEmptyItems)
+	mockRepo.AssertExpectations(t)
+}
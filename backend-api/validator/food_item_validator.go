@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"errors"
+	"go-rest-api/model"
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// 食材の名前の最大文字数と数量の上限
+const (
+	FoodItemTitleMaxLength = 50
+	FoodItemQuantityMax    = 1000000
+)
+
+// 購入金額の上限
+const FoodItemPriceMax = 10000000
+
+// 栄養成分の各値の上限（単位 1 つあたりの値も受け付けるため、100 g あたりの上限より大きくする）
+const FoodItemNutritionValueMax = 100000
+
+// 開封後に食べきるまでの日数と、保存状態を変更した後の日持ちの日数の上限
+const (
+	FoodItemOpenedShelfLifeMaxDays = 365
+	FoodItemStateShelfLifeMaxDays  = 730
+)
+
+// 賞味期限として受け付ける範囲（今日からの年数）。日付の入力ミスを弾くための目安
+const (
+	foodItemExpiryPastYears   = 1
+	foodItemExpiryFutureYears = 10
+)
+
+type IFoodItemValidator interface {
+	FoodItemValidate(foodItem model.FoodItem) error
+	FoodItemUpdateValidate(foodItem model.FoodItem) error
+	NutritionValidate(nutrition model.Nutrition) error
+}
+
+type foodItemValidator struct{}
+
+func NewFoodItemValidator() IFoodItemValidator {
+	return &foodItemValidator{}
+}
+
+// FoodItemValidate は登録する食材の名前・数量・賞味期限・購入日時・購入金額・開封後の日数・栄養成分を検証する。
+// 誤りは項目（JSON のキー）ごとに validation.Errors で返す。
+func (fv *foodItemValidator) FoodItemValidate(foodItem model.FoodItem) error {
+	now := time.Now()
+	return validation.ValidateStruct(&foodItem,
+		titleRule(&foodItem),
+		validation.Field(
+			&foodItem.Quantity,
+			validation.By(quantityRule),
+			validation.Min(0.0).Error("must be no less than 0"),
+			validation.Max(float64(FoodItemQuantityMax)).Error("must be no greater than 1000000"),
+		),
+		validation.Field(
+			&foodItem.ExpiryDate,
+			validation.Required.Error("expiry_date is required"),
+			validation.Min(now.AddDate(-foodItemExpiryPastYears, 0, 0)).Error("must not be more than 1 year ago"),
+			validation.Max(now.AddDate(foodItemExpiryFutureYears, 0, 0)).Error("must not be more than 10 years ahead"),
+		),
+		validation.Field(
+			&foodItem.PurchasedAt,
+			validation.Max(now).Error("must not be in the future"),
+		),
+		validation.Field(
+			&foodItem.Price,
+			// 価格は最初のロットに記録するため、ロットを作らない数量 0 の食材には指定できない
+			validation.When(foodItem.Quantity == 0, validation.Nil.Error("requires quantity greater than 0")),
+			validation.Min(0.0).Error("must be no less than 0"),
+			validation.Max(float64(FoodItemPriceMax)).Error("must be no greater than 10000000"),
+		),
+		validation.Field(
+			&foodItem.Currency,
+			validation.By(currencyRule),
+		),
+		validation.Field(
+			&foodItem.OpenedShelfLifeDays,
+			validation.Min(1).Error("must be no less than 1"),
+			validation.Max(FoodItemOpenedShelfLifeMaxDays).Error("must be no greater than 365"),
+		),
+		validation.Field(
+			&foodItem.Nutrition,
+			validation.By(nutritionRule),
+		),
+	)
+}
+
+// NutritionValidate は食材の栄養成分の基準量と値の範囲を検証する。
+// 基準量と値をすべて省略した場合は成分表から補うため誤りにしない。
+func (fv *foodItemValidator) NutritionValidate(nutrition model.Nutrition) error {
+	return validation.ValidateStruct(&nutrition,
+		validation.Field(
+			&nutrition.Basis,
+			validation.When(!nutrition.NutritionValues.IsZero(), validation.Required.Error("basis is required")),
+			validation.In(model.NutritionPer100g, model.NutritionPerUnit).Error("must be 100g or unit"),
+		),
+		nutritionValueRule(&nutrition.Kcal),
+		nutritionValueRule(&nutrition.Protein),
+		nutritionValueRule(&nutrition.Fat),
+		nutritionValueRule(&nutrition.Carbohydrate),
+		nutritionValueRule(&nutrition.Salt),
+	)
+}
+
+func nutritionValueRule(value *float64) *validation.FieldRules {
+	return validation.Field(
+		value,
+		validation.Min(0.0).Error("must be no less than 0"),
+		validation.Max(float64(FoodItemNutritionValueMax)).Error("must be no greater than 100000"),
+	)
+}
+
+// nutritionRule は登録する食材の栄養成分を NutritionValidate と同じ規則で検証する
+func nutritionRule(value interface{}) error {
+	return (&foodItemValidator{}).NutritionValidate(value.(model.Nutrition))
+}
+
+// FoodItemUpdateValidate は更新で変更できる項目（名前）を検証する。
+// 数量と賞味期限はロットで管理するため、更新では検証しない。
+func (fv *foodItemValidator) FoodItemUpdateValidate(foodItem model.FoodItem) error {
+	return validation.ValidateStruct(&foodItem,
+		titleRule(&foodItem),
+	)
+}
+
+func titleRule(foodItem *model.FoodItem) *validation.FieldRules {
+	return validation.Field(
+		&foodItem.Title,
+		validation.Required.Error("title is required"),
+		validation.RuneLength(1, FoodItemTitleMaxLength).Error("limited max 50 char"),
+	)
+}
+
+// quantityRule は数量が数値である（NaN・無限大でない）ことを検証する。範囲は Min・Max で検証する
+func quantityRule(value interface{}) error {
+	v := value.(float64)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return errors.New("must be a number")
+	}
+	return nil
+}
+
+// currencyRule は通貨コードの形式を検証する
+func currencyRule(value interface{}) error {
+	if _, err := model.ParseCurrency(value.(string)); err != nil {
+		return errors.New("must be a 3-letter currency code")
+	}
+	return nil
+}
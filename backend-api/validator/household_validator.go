@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"go-rest-api/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// InvitationMaxHours は招待の有効期限として指定できる最大の時間（30 日）
+const InvitationMaxHours = 720
+
+type IHouseholdValidator interface {
+	HouseholdValidate(household model.Household) error
+	InvitationValidate(invitation model.HouseholdInvitationRequest) error
+	MemberRoleValidate(memberRole model.HouseholdMemberRole) error
+}
+
+type householdValidator struct{}
+
+func NewHouseholdValidator() IHouseholdValidator {
+	return &householdValidator{}
+}
+
+func (hv *householdValidator) HouseholdValidate(household model.Household) error {
+	return validation.ValidateStruct(&household,
+		validation.Field(
+			&household.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, 30).Error("limited max 30 char"),
+		),
+	)
+}
+
+func (hv *householdValidator) InvitationValidate(invitation model.HouseholdInvitationRequest) error {
+	return validation.ValidateStruct(&invitation,
+		validation.Field(&invitation.Role, memberRoleRules()...),
+		validation.Field(
+			&invitation.ExpiresInHours,
+			validation.Min(0).Error("must be no less than 0"),
+			validation.Max(InvitationMaxHours).Error("must be no greater than 720"),
+		),
+	)
+}
+
+func (hv *householdValidator) MemberRoleValidate(memberRole model.HouseholdMemberRole) error {
+	return validation.ValidateStruct(&memberRole,
+		validation.Field(&memberRole.Role, memberRoleRules()...),
+	)
+}
+
+// memberRoleRules は招待やロールの変更で指定できるロールの規則。所有者は所有権の移転でのみ変更する
+func memberRoleRules() []validation.Rule {
+	return []validation.Rule{
+		validation.Required.Error("role is required"),
+		validation.In(model.RoleEditor, model.RoleViewer).Error("must be editor or viewer"),
+	}
+}
@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"go-rest-api/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IParLevelValidator interface {
+	ParLevelValidate(parLevel model.ParLevel) error
+}
+
+type parLevelValidator struct{}
+
+func NewParLevelValidator() IParLevelValidator {
+	return &parLevelValidator{}
+}
+
+func (pv *parLevelValidator) ParLevelValidate(parLevel model.ParLevel) error {
+	return validation.ValidateStruct(&parLevel,
+		validation.Field(
+			&parLevel.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, FoodItemTitleMaxLength).Error("limited max 50 char"),
+		),
+		validation.Field(
+			&parLevel.MinQuantity,
+			validation.Required.Error("min_quantity is required"),
+			validation.By(quantityRule),
+			validation.Min(0.0).Exclusive().Error("must be greater than 0"),
+			validation.Max(float64(FoodItemQuantityMax)).Error("must be no greater than 1000000"),
+		),
+	)
+}
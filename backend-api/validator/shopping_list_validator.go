@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"go-rest-api/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IShoppingListItemValidator interface {
+	ShoppingListItemValidate(item model.ShoppingListItem) error
+}
+
+type shoppingListItemValidator struct{}
+
+func NewShoppingListItemValidator() IShoppingListItemValidator {
+	return &shoppingListItemValidator{}
+}
+
+func (sv *shoppingListItemValidator) ShoppingListItemValidate(item model.ShoppingListItem) error {
+	return validation.ValidateStruct(&item,
+		validation.Field(
+			&item.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, FoodItemTitleMaxLength).Error("limited max 50 char"),
+		),
+		validation.Field(
+			&item.Quantity,
+			validation.By(quantityRule),
+			validation.Min(0.0).Exclusive().Error("must be greater than 0"),
+			validation.Max(float64(FoodItemQuantityMax)).Error("must be no greater than 1000000"),
+		),
+		validation.Field(
+			&item.Note,
+			validation.RuneLength(0, 200).Error("limited max 200 char"),
+		),
+	)
+}
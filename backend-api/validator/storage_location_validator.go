@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"go-rest-api/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IStorageLocationValidator interface {
+	StorageLocationValidate(location model.StorageLocation) error
+}
+
+type storageLocationValidator struct{}
+
+func NewStorageLocationValidator() IStorageLocationValidator {
+	return &storageLocationValidator{}
+}
+
+func (sv *storageLocationValidator) StorageLocationValidate(location model.StorageLocation) error {
+	return validation.ValidateStruct(&location,
+		validation.Field(
+			&location.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, 30).Error("limited max 30 char"),
+		),
+		validation.Field(
+			&location.Type,
+			validation.Required.Error("type is required"),
+			validation.In(model.LocationFridge, model.LocationFreezer, model.LocationPantry).Error("must be fridge, freezer or pantry"),
+		),
+	)
+}
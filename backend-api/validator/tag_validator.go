@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"go-rest-api/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ITagValidator interface {
+	TagValidate(tag model.Tag) error
+}
+
+type tagValidator struct{}
+
+func NewTagValidator() ITagValidator {
+	return &tagValidator{}
+}
+
+func (tv *tagValidator) TagValidate(tag model.Tag) error {
+	return validation.ValidateStruct(&tag,
+		validation.Field(
+			&tag.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, 20).Error("limited max 20 char"),
+		),
+	)
+}
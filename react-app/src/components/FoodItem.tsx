@@ -1,14 +1,23 @@
 import React, { FC } from 'react'
 import { Box, Button, Typography, Paper } from '@mui/material'
-import { FoodItem as FoodItemType } from '../types'
+import { FoodItem as FoodItemType, getEffectiveExpiry } from '../types'
 import { useMutateFoodItem } from '../hooks/useMutateFoodItem'
 
+// 生以外の保存状態の表示名
+const stateLabels = {
+  fresh: '生',
+  frozen: '冷凍',
+  thawed: '解凍済み',
+  cooked: '調理済み',
+}
+
 interface Props {
   foodItem: FoodItemType
 }
 
 export const FoodItem: FC<Props> = ({ foodItem }) => {
   const { deleteFoodItemMutation } = useMutateFoodItem()
+  const expiryDate = getEffectiveExpiry(foodItem)
 
   const getDaysUntilExpiry = (expiryDateStr: string): number => {
     const today = new Date()
@@ -28,10 +37,10 @@ export const FoodItem: FC<Props> = ({ foodItem }) => {
   }
 
   const getStatusColor = () => {
-    if (isExpired(foodItem.expiry_date)) {
+    if (isExpired(expiryDate)) {
       return 'error.main'
     }
-    if (isExpiringSoon(foodItem.expiry_date)) {
+    if (isExpiringSoon(expiryDate)) {
       return 'warning.main'
     }
     return 'info.main'
@@ -111,7 +120,11 @@ export const FoodItem: FC<Props> = ({ foodItem }) => {
                 alignItems: 'center',
               }}
             >
-              期限: {new Date(foodItem.expiry_date).toLocaleDateString('ja-JP')}
+              期限: {new Date(expiryDate).toLocaleDateString('ja-JP')}
+              {foodItem.opened_at && '（開封済み）'}
+              {foodItem.state &&
+                foodItem.state !== 'fresh' &&
+                `（${stateLabels[foodItem.state]}）`}
             </Typography>
             <Typography
               variant="body2"
@@ -123,7 +136,7 @@ export const FoodItem: FC<Props> = ({ foodItem }) => {
                 alignItems: 'center',
               }}
             >
-              残り{getDaysUntilExpiry(foodItem.expiry_date)}日
+              残り{getDaysUntilExpiry(expiryDate)}日
             </Typography>
           </Box>
         </Box>
@@ -14,7 +14,7 @@ import {
 } from '@mui/material'
 import { useQueryFoodItems } from '../hooks/useQueryFoodItems'
 import { useQueryRecipe } from '../hooks/useQueryRecipe'
-import { FoodItem, Recipe } from '../types'
+import { FoodItem, Recipe, getEffectiveExpiry } from '../types'
 import ReactMarkdown from 'react-markdown'
 
 export const RecipeSuggestions: FC = () => {
@@ -54,7 +54,7 @@ export const RecipeSuggestions: FC = () => {
             <List>
               {foodItems && foodItems.length > 0 ? (
                 foodItems.map((item: FoodItem) => {
-                  const expiryDate = new Date(item.expiry_date)
+                  const expiryDate = new Date(getEffectiveExpiry(item))
                   const daysUntilExpiry = Math.ceil(
                     (expiryDate.getTime() - new Date().getTime()) /
                       (1000 * 3600 * 24)
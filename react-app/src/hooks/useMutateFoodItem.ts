@@ -79,9 +79,19 @@ export const useMutateFoodItem = () => {
   const updateFoodItemMutation = useMutation({
     mutationFn: async (foodItem: FoodItem) => {
       await getCsrfToken()
+      // 取得後に他の更新があった場合はサーバーが 412 を返す
+      // PUT は置き換えのため、変更できる項目をすべて送る
+      // 数量と賞味期限はロットで管理しており、含めるとサーバーが 400 を返すため送らない
       return await axiosInstance.put<ApiResponse>(
         `/food-items/${foodItem.id}`,
-        foodItem
+        {
+          title: foodItem.title,
+          category_id: foodItem.category_id ?? null,
+          tags: foodItem.tags ?? [],
+        },
+        foodItem.version !== undefined
+          ? { headers: { 'If-Match': `"${foodItem.version}"` } }
+          : undefined
       )
     },
     onSuccess: (res: AxiosResponse<ApiResponse>, variables: FoodItem) => {
@@ -4,7 +4,7 @@ import { useQueryClient } from '@tanstack/react-query'
 import { useQueryFoodItems } from '../hooks/useQueryFoodItems'
 import { useQueryRecipe } from '../hooks/useQueryRecipe'
 import { Typography, Box, Grid, Paper, CircularProgress } from '@mui/material'
-import { Recipe } from '../types'
+import { Recipe, getEffectiveExpiry } from '../types'
 
 export const PantryPage: FC = () => {
   const navigate = useNavigate()
@@ -25,9 +25,9 @@ export const PantryPage: FC = () => {
   const expiredItems = foodItems.filter((item) => {
     if (!item?.expiry_date) return false
     try {
-      return getDaysUntilExpiry(item.expiry_date) < 0
+      return getDaysUntilExpiry(getEffectiveExpiry(item)) < 0
     } catch (e) {
-      console.error('Invalid date:', item.expiry_date)
+      console.error('Invalid date:', getEffectiveExpiry(item))
       return false
     }
   })
@@ -36,10 +36,10 @@ export const PantryPage: FC = () => {
   const nearExpiryItems = foodItems.filter((item) => {
     if (!item?.expiry_date) return false
     try {
-      const daysUntilExpiry = getDaysUntilExpiry(item.expiry_date)
+      const daysUntilExpiry = getDaysUntilExpiry(getEffectiveExpiry(item))
       return daysUntilExpiry <= 7 && daysUntilExpiry >= 0
     } catch (e) {
-      console.error('Invalid date:', item.expiry_date)
+      console.error('Invalid date:', getEffectiveExpiry(item))
       return false
     }
   })
@@ -155,7 +155,7 @@ export const PantryPage: FC = () => {
                         mt: 0.5,
                       }}
                     >
-                      期限: {formatDate(item.expiry_date)}
+                      期限: {formatDate(getEffectiveExpiry(item))}
                     </Typography>
                   </Box>
                 ))}
@@ -231,7 +231,7 @@ export const PantryPage: FC = () => {
                         mt: 0.5,
                       }}
                     >
-                      期限: {formatDate(item.expiry_date)}
+                      期限: {formatDate(getEffectiveExpiry(item))}
                     </Typography>
                   </Box>
                 ))}
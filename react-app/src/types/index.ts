@@ -26,8 +26,16 @@ export type FoodItem = {
   title: string
   quantity: number
   expiry_date: string // Date型から文字列型に変更
+  opened_at?: string | null // 開封した日時
+  opened_shelf_life_days?: number | null // 開封後に食べきるまでの日数
+  state?: 'fresh' | 'frozen' | 'thawed' | 'cooked' // 保存状態
+  state_expiry_date?: string | null // 保存状態を変更したときに計算し直した期限
+  effective_expiry_date?: string // 保存状態の期限、なければ賞味期限と開封後の期限の早いほう
   created_at?: string // 同様に文字列型に変更
   updated_at?: string // 同様に文字列型に変更
+  version?: number // 更新時に If-Match ヘッダーで送るバージョン
+  category_id?: number | null
+  tags?: string[]
   user_id?: number
 }
 
@@ -44,3 +52,7 @@ export type EditedTask = {
   id: number
   title: string
 }
+
+// 期限の判定には、開封後の期限と保存状態を考慮した実質の期限を使う
+export const getEffectiveExpiry = (item: FoodItem): string =>
+  item.effective_expiry_date ?? item.expiry_date